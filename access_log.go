@@ -0,0 +1,191 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+var _ slog.Handler = (*AccessLogHandler)(nil)
+
+// ErrMissingAccessAttr is returned by AccessLogHandler.Handle for records lacking one of the
+// required access log attributes.
+var ErrMissingAccessAttr = errors.New("slogs: missing access log attribute")
+
+// AccessLogFormat is the line format written by an AccessLogHandler.
+type AccessLogFormat int
+
+const (
+	// CommonLogFormat is the Common Log Format:
+	//
+	//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.0" 200 2326
+	CommonLogFormat AccessLogFormat = iota
+	// CombinedLogFormat is the Common Log Format followed by the quoted referer and user agent.
+	CombinedLogFormat
+)
+
+// Keys of the attributes read by an AccessLogHandler.
+const (
+	AccessRemoteKey  = "remote"
+	AccessUserKey    = "user"
+	AccessTimeKey    = "time"
+	AccessRequestKey = "request"
+	AccessStatusKey  = "status"
+	AccessBytesKey   = "bytes"
+	AccessRefererKey = "referer"
+	AccessAgentKey   = "agent"
+)
+
+// accessLogTime is the time layout of the Common Log Format.
+const accessLogTime = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogHandler writes records as Apache-style access log lines, so that one logger can
+// produce both structured application logs and standard access logs, e.g. through a
+// MultiHandler.
+//
+// The line is built from the top-level attributes of the record and of WithAttrs calls:
+//   - remote, request and status are required; records lacking one are skipped and Handle
+//     returns an error wrapping ErrMissingAccessAttr
+//   - user, bytes, referer and agent are written as "-" when missing
+//   - time defaults to the record time
+//
+// Attributes in groups are ignored, as are the record message and level.
+//
+// Example:
+//
+//	access := slogs.NewAccessLogHandler(accessFile, slogs.CombinedLogFormat)
+//	logger := slogs.New(slogs.NewHandler(slogs.MultiHandler(jsonHandler, access)))
+//	logger.Info("request",
+//		slogs.AccessRemoteKey, r.RemoteAddr,
+//		slogs.AccessRequestKey, r.Method+" "+r.RequestURI+" "+r.Proto,
+//		slogs.AccessStatusKey, status,
+//		slogs.AccessBytesKey, written,
+//	)
+type AccessLogHandler struct {
+	out     *accessLogWriter
+	format  AccessLogFormat
+	attrs   []slog.Attr
+	grouped bool
+}
+
+// accessLogWriter serializes the writes of an AccessLogHandler and the handlers derived from it.
+type accessLogWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewAccessLogHandler creates an AccessLogHandler writing lines in the given format to w.
+//
+// Panics if w is nil.
+func NewAccessLogHandler(w io.Writer, format AccessLogFormat) *AccessLogHandler {
+	if w == nil {
+		panic("slogs: writer cannot be nil")
+	}
+
+	return &AccessLogHandler{out: &accessLogWriter{w: w}, format: format}
+}
+
+// Enabled reports true: access log lines are written regardless of their level.
+func (h *AccessLogHandler) Enabled(_ context.Context, _ slog.Level) bool {
+	return true
+}
+
+// Handle writes r as an access log line, with a single Write call.
+func (h *AccessLogHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := make(map[string]slog.Value, 8)
+	for _, a := range h.attrs {
+		fields[a.Key] = a.Value.Resolve()
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		if !h.grouped {
+			fields[a.Key] = a.Value.Resolve()
+		}
+		return true
+	})
+
+	for _, key := range []string{AccessRemoteKey, AccessRequestKey, AccessStatusKey} {
+		if _, ok := fields[key]; !ok {
+			return fmt.Errorf("%w: %q", ErrMissingAccessAttr, key)
+		}
+	}
+
+	t := r.Time
+	if v, ok := fields[AccessTimeKey]; ok && v.Kind() == slog.KindTime {
+		t = v.Time()
+	}
+
+	line := make([]byte, 0, 256)
+	line = append(line, accessField(fields, AccessRemoteKey)...)
+	line = append(line, " - "...)
+	line = append(line, accessField(fields, AccessUserKey)...)
+	line = append(line, " ["...)
+	line = t.AppendFormat(line, accessLogTime)
+	line = append(line, "] "...)
+	line = strconv.AppendQuote(line, accessField(fields, AccessRequestKey))
+	line = append(line, ' ')
+	line = append(line, accessField(fields, AccessStatusKey)...)
+	line = append(line, ' ')
+	line = append(line, accessBytes(fields)...)
+	if h.format == CombinedLogFormat {
+		line = append(line, ' ')
+		line = strconv.AppendQuote(line, accessField(fields, AccessRefererKey))
+		line = append(line, ' ')
+		line = strconv.AppendQuote(line, accessField(fields, AccessAgentKey))
+	}
+	line = append(line, '\n')
+
+	h.out.mu.Lock()
+	defer h.out.mu.Unlock()
+	_, err := h.out.w.Write(line)
+	return err
+}
+
+// WithAttrs returns an AccessLogHandler writing to the same writer that also reads attrs.
+// Attributes added after WithGroup are ignored.
+func (h *AccessLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 || h.grouped {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = append(slices.Clip(h.attrs), attrs...)
+	return &h2
+}
+
+// WithGroup returns an AccessLogHandler writing to the same writer that ignores the
+// attributes added afterwards, since they are no longer top-level.
+func (h *AccessLogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.grouped = true
+	return &h2
+}
+
+// accessField returns the value of key as a string, or "-" if it is missing or empty.
+func accessField(fields map[string]slog.Value, key string) string {
+	v, ok := fields[key]
+	if !ok {
+		return "-"
+	}
+	if s := v.String(); s != "" {
+		return s
+	}
+	return "-"
+}
+
+// accessBytes returns the response size, or "-" if it is missing or zero as in Apache's %b.
+func accessBytes(fields map[string]slog.Value) string {
+	s := accessField(fields, AccessBytesKey)
+	if s == "0" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,134 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLogHandler_Handle(t *testing.T) {
+	at := time.Date(2000, time.October, 10, 13, 55, 36, 0, time.FixedZone("", -7*60*60))
+	request := []any{
+		AccessRemoteKey, "127.0.0.1",
+		AccessRequestKey, "GET /apache_pb.gif HTTP/1.0",
+		AccessStatusKey, 200,
+	}
+
+	tests := []struct {
+		name   string
+		format AccessLogFormat
+		logger func(l *slog.Logger) *slog.Logger
+		args   []any
+		want   string
+	}{
+		{
+			name:   "common",
+			format: CommonLogFormat,
+			args:   append([]any{AccessUserKey, "frank", AccessBytesKey, 2326}, request...),
+			want:   `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326` + "\n",
+		},
+		{
+			name:   "combined",
+			format: CombinedLogFormat,
+			args: append([]any{
+				AccessUserKey, "frank", AccessBytesKey, 2326,
+				AccessRefererKey, "http://www.example.com/start.html", AccessAgentKey, `Mozilla/4.08 "quoted"`,
+			}, request...),
+			want: `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 ` +
+				`"http://www.example.com/start.html" "Mozilla/4.08 \"quoted\""` + "\n",
+		},
+		{
+			name:   "optional attributes default to a dash",
+			format: CombinedLogFormat,
+			args:   append([]any{AccessBytesKey, 0}, request...),
+			want:   `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 - "-" "-"` + "\n",
+		},
+		{
+			name:   "time attribute overrides the record time",
+			format: CommonLogFormat,
+			args:   append([]any{AccessTimeKey, at.Add(time.Hour)}, request...),
+			want:   `127.0.0.1 - - [10/Oct/2000:14:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 -` + "\n",
+		},
+		{
+			name:   "attributes from With",
+			format: CommonLogFormat,
+			logger: func(l *slog.Logger) *slog.Logger { return l.With(AccessRemoteKey, "10.0.0.1") },
+			args:   request[2:],
+			want:   `10.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 -` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			var h slog.Handler = NewAccessLogHandler(buf, tt.format)
+			if tt.logger != nil {
+				h = tt.logger(slog.New(h)).Handler()
+			}
+
+			r := slog.NewRecord(at, slog.LevelInfo, "request", 0)
+			r.Add(tt.args...)
+			require.NoError(t, h.Handle(context.Background(), r))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestAccessLogHandler_MissingAttr(t *testing.T) {
+	tests := []struct {
+		name   string
+		logger func(l *slog.Logger) *slog.Logger
+		args   []any
+	}{
+		{
+			name: "missing status",
+			args: []any{AccessRemoteKey, "127.0.0.1", AccessRequestKey, "GET / HTTP/1.1"},
+		},
+		{
+			name:   "required attributes in a group",
+			logger: func(l *slog.Logger) *slog.Logger { return l.WithGroup("http") },
+			args:   []any{AccessRemoteKey, "127.0.0.1", AccessRequestKey, "GET / HTTP/1.1", AccessStatusKey, 200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			var h slog.Handler = NewAccessLogHandler(buf, CommonLogFormat)
+			if tt.logger != nil {
+				h = tt.logger(slog.New(h)).Handler()
+			}
+
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "request", 0)
+			r.Add(tt.args...)
+			assert.ErrorIs(t, h.Handle(context.Background(), r), ErrMissingAccessAttr)
+			assert.Empty(t, buf.String())
+		})
+	}
+}
+
+func TestAccessLogHandler_WithMultiHandler(t *testing.T) {
+	jsonBuf := &bytes.Buffer{}
+	accessBuf := &bytes.Buffer{}
+	logger := New(NewHandler(MultiHandler(
+		slog.NewJSONHandler(jsonBuf, nil),
+		NewAccessLogHandler(accessBuf, CommonLogFormat),
+	)))
+
+	logger.Info("request", AccessRemoteKey, "127.0.0.1", AccessRequestKey, "GET / HTTP/1.1", AccessStatusKey, 204)
+
+	assert.Contains(t, jsonBuf.String(), `"status":204`)
+	assert.Contains(t, accessBuf.String(), `127.0.0.1 - - [`)
+	assert.Contains(t, accessBuf.String(), `] "GET / HTTP/1.1" 204 -`)
+}
+
+func TestNewAccessLogHandler_NilWriter(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: writer cannot be nil", func() {
+		NewAccessLogHandler(nil, CommonLogFormat)
+	})
+}
@@ -0,0 +1,249 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+var (
+	_ slog.Handler = (*AsyncHandler)(nil)
+	_ Closer       = (*AsyncHandler)(nil)
+)
+
+// OverflowPolicy defines what an AsyncHandler does with a record when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// BlockWhenFull makes Handle wait until the buffer has room, so that no record is lost and
+	// records reach the next handler in the order they were handled.
+	BlockWhenFull OverflowPolicy = iota
+	// DropWhenFull makes Handle drop the record, so that logging never blocks the caller.
+	DropWhenFull
+)
+
+// AsyncHandler passes records to the next handler from a background goroutine, so that logging
+// does not add the latency of the next handler, e.g. a slow disk or network, to request paths.
+//
+// Records are cloned and queued in a buffer drained by a single goroutine, in order. When the
+// buffer is full, the OverflowPolicy decides whether Handle waits or drops the record. Handle
+// returns nil once the record is queued; errors of the next handler are returned by Close. The
+// context passed to the next handler keeps the values of the context of Handle, but not its
+// cancellation, since the record may be handled after the request ended.
+//
+// Close must be called to handle the queued records and stop the background goroutine.
+//
+// Example:
+//
+//	async := slogs.NewAsyncHandler(slog.NewJSONHandler(os.Stdout, nil), 4096, slogs.DropWhenFull)
+//	defer async.Close(context.Background())
+//	logger := slogs.New(slogs.NewHandler(async))
+type AsyncHandler struct {
+	next     slog.Handler
+	queue    *asyncQueue
+	reporter DropReporter
+}
+
+// maxAsyncErrors bounds the number of errors of the next handler kept for Close.
+const maxAsyncErrors = 100
+
+// asyncQueue is the buffer shared by an AsyncHandler and the handlers derived from it.
+type asyncQueue struct {
+	policy  OverflowPolicy
+	records chan asyncRecord
+
+	// mu guards closed. Handle only holds it for reading while registering in senders, never
+	// while waiting for room, so that Close cannot be blocked by a full buffer.
+	mu     sync.RWMutex
+	closed bool
+	// senders tracks the Handle calls that may still queue a record.
+	senders sync.WaitGroup
+	// done is closed by Close; it releases the Handle calls waiting for room.
+	done chan struct{}
+
+	stopped chan struct{}
+	errMu   sync.Mutex
+	errs    []error
+	// skipped counts the errors not kept in errs.
+	skipped int
+}
+
+// asyncRecord is a queued record with the context and the handler it is passed to.
+type asyncRecord struct {
+	ctx  context.Context
+	next slog.Handler
+	r    slog.Record
+}
+
+// NewAsyncHandler creates an AsyncHandler queueing up to bufferSize records for next, and
+// applying policy when the buffer is full. A bufferSize <= 0 defaults to 1024.
+//
+// Panics if next is nil; see NewAsyncHandlerE.
+func NewAsyncHandler(next slog.Handler, bufferSize int, policy OverflowPolicy) *AsyncHandler {
+	h, err := NewAsyncHandlerE(next, bufferSize, policy)
+	if err != nil {
+		panic(err.Error())
+	}
+	return h
+}
+
+// NewAsyncHandlerE is like NewAsyncHandler but returns ErrNilNextHandler instead of panicking
+// if next is nil.
+func NewAsyncHandlerE(next slog.Handler, bufferSize int, policy OverflowPolicy) (*AsyncHandler, error) {
+	if next == nil {
+		return nil, ErrNilNextHandler
+	}
+	if bufferSize <= 0 {
+		bufferSize = 1024
+	}
+
+	q := &asyncQueue{
+		policy:  policy,
+		records: make(chan asyncRecord, bufferSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go q.run()
+
+	return &AsyncHandler{next: next, queue: q}, nil
+}
+
+// WithDropReporter returns an AsyncHandler sharing the same buffer that reports every record
+// dropped because the buffer is full to reporter with ReasonBufferFull. A nil reporter disables
+// reporting.
+func (h *AsyncHandler) WithDropReporter(reporter DropReporter) *AsyncHandler {
+	h2 := *h
+	h2.reporter = reporter
+	return &h2
+}
+
+// Close handles the queued records and stops the background goroutine.
+//
+// It returns the errors returned by the next handler joined, up to 100 of them, or ctx.Err() if
+// ctx is done before all queued records are handled. Records handled after Close, and records
+// waiting for room in a full buffer when Close is called, fail with ErrHandlerClosed.
+func (h *AsyncHandler) Close(ctx context.Context) error {
+	q := h.queue
+
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	q.errMu.Lock()
+	defer q.errMu.Unlock()
+	errs := q.errs
+	if q.skipped > 0 {
+		errs = append(errs[:len(errs):len(errs)], fmt.Errorf("slogs: %d more errors of the next handler", q.skipped))
+	}
+	return errors.Join(errs...)
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *AsyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle queues a clone of r for the next handler, waiting for room or dropping it if the
+// buffer is full, according to the OverflowPolicy.
+func (h *AsyncHandler) Handle(ctx context.Context, r slog.Record) error {
+	q := h.queue
+	rec := asyncRecord{ctx: context.WithoutCancel(ctx), next: h.next, r: r.Clone()}
+
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return ErrHandlerClosed
+	}
+	q.senders.Add(1)
+	q.mu.RUnlock()
+	defer q.senders.Done()
+
+	if q.policy == DropWhenFull {
+		select {
+		case q.records <- rec:
+		default:
+			if h.reporter != nil {
+				h.reporter.Dropped(r.Level, ReasonBufferFull, 1)
+			}
+		}
+		return nil
+	}
+
+	select {
+	case q.records <- rec:
+		return nil
+	case <-q.done:
+		return ErrHandlerClosed
+	}
+}
+
+// WithAttrs returns an AsyncHandler sharing the same buffer whose next handler has the given attributes.
+func (h *AsyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns an AsyncHandler sharing the same buffer whose next handler has the given group.
+func (h *AsyncHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.stopped)
+
+	for {
+		select {
+		case rec := <-q.records:
+			q.handle(rec)
+		case <-q.done:
+			// No record is queued once the senders registered before Close are gone.
+			q.senders.Wait()
+			for {
+				select {
+				case rec := <-q.records:
+					q.handle(rec)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (q *asyncQueue) handle(rec asyncRecord) {
+	err := rec.next.Handle(rec.ctx, rec.r)
+	if err == nil {
+		return
+	}
+
+	q.errMu.Lock()
+	defer q.errMu.Unlock()
+	if len(q.errs) < maxAsyncErrors {
+		q.errs = append(q.errs, err)
+	} else {
+		q.skipped++
+	}
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *AsyncHandler) unwrap() slog.Handler {
+	return h.next
+}
@@ -0,0 +1,202 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedRecorder is a testHandler whose Handle signals started, then waits for gate to be closed.
+type gatedRecorder struct {
+	*testHandler
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func newGatedRecorder() *gatedRecorder {
+	return &gatedRecorder{testHandler: newTestHandler(true), started: make(chan struct{}, 1), gate: make(chan struct{})}
+}
+
+func (h *gatedRecorder) Handle(ctx context.Context, r slog.Record) error {
+	select {
+	case h.started <- struct{}{}:
+	default:
+	}
+	<-h.gate
+	return h.testHandler.Handle(ctx, r)
+}
+
+func TestNewAsyncHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewAsyncHandler(nil, 1, BlockWhenFull)
+	})
+
+	h, err := NewAsyncHandlerE(nil, 1, BlockWhenFull)
+	assert.ErrorIs(t, err, ErrNilNextHandler)
+	assert.Nil(t, h)
+}
+
+func TestAsyncHandler_BlockWhenFull(t *testing.T) {
+	next := newGatedRecorder()
+	h := NewAsyncHandler(next, 2, BlockWhenFull)
+	logger := New(NewHandler(h))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			logger.Info(fmt.Sprint(i))
+		}
+	}()
+
+	<-next.started
+	select {
+	case <-done:
+		t.Fatal("logging should block while the buffer is full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(next.gate)
+	<-done
+	require.NoError(t, h.Close(context.Background()))
+
+	want := make([]string, 20)
+	for i := range want {
+		want[i] = fmt.Sprint(i)
+	}
+	assert.Equal(t, want, messages(next.getRecords()), "records are handled in order")
+}
+
+func TestAsyncHandler_DropWhenFull(t *testing.T) {
+	next := newGatedRecorder()
+	reporter := newDropCounter()
+	h := NewAsyncHandler(next, 2, DropWhenFull).WithDropReporter(reporter)
+	logger := New(NewHandler(h))
+
+	logger.Info("0")
+	<-next.started // the first record is being handled, so the buffer is empty
+	for i := 1; i < 10; i++ {
+		logger.Warn(fmt.Sprint(i))
+	}
+	close(next.gate)
+
+	require.NoError(t, h.Close(context.Background()))
+	assert.Equal(t, []string{"0", "1", "2"}, messages(next.getRecords()))
+	assert.Equal(t, map[string]int{"WARN buffer_full": 7}, reporter.get())
+}
+
+func TestAsyncHandler_Close(t *testing.T) {
+	next := newTestHandler(true)
+	next.err = errors.New("write failed")
+	h := NewAsyncHandler(next, 0, BlockWhenFull)
+	logger := New(NewHandler(h))
+
+	for i := 0; i < 100; i++ {
+		logger.With("i", i).WithGroup("g").Info("m")
+	}
+
+	assert.ErrorIs(t, h.Close(context.Background()), next.err)
+	assert.Equal(t, 100, next.recordCount(), "Close handles all queued records")
+	assert.ErrorIs(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "late", 0)), ErrHandlerClosed)
+	assert.ErrorIs(t, h.Close(context.Background()), next.err, "Close can be called again")
+}
+
+func TestAsyncHandler_Close_ContextDone(t *testing.T) {
+	next := newGatedRecorder()
+	h := NewAsyncHandler(next, 1, BlockWhenFull)
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "m", 0)))
+	<-next.started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, h.Close(ctx), context.Canceled)
+
+	close(next.gate)
+	assert.NoError(t, h.Close(context.Background()))
+	assert.Equal(t, 1, next.recordCount())
+}
+
+func TestAsyncHandler_Close_BlockedSender(t *testing.T) {
+	next := newGatedRecorder()
+	h := NewAsyncHandler(next, 1, BlockWhenFull)
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "a", 0)))
+	<-next.started
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "b", 0)))
+
+	blocked := make(chan error, 1)
+	go func() {
+		blocked <- h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "c", 0))
+	}()
+	time.Sleep(10 * time.Millisecond) // lets the sender block on the full buffer
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, h.Close(ctx), context.DeadlineExceeded, "Close honors ctx while a sender is blocked")
+	assert.ErrorIs(t, <-blocked, ErrHandlerClosed, "the blocked sender is released by Close")
+
+	close(next.gate)
+	require.NoError(t, h.Close(context.Background()))
+	assert.Equal(t, []string{"a", "b"}, messages(next.getRecords()))
+}
+
+// messageErrHandler fails every record with an error holding its message.
+type messageErrHandler struct {
+	*testHandler
+}
+
+func (h messageErrHandler) Handle(_ context.Context, r slog.Record) error {
+	return errors.New(r.Message)
+}
+
+func TestAsyncHandler_Close_JoinsErrors(t *testing.T) {
+	h := NewAsyncHandler(messageErrHandler{newTestHandler(true)}, 0, BlockWhenFull)
+	for i := 0; i < maxAsyncErrors+2; i++ {
+		require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, fmt.Sprint("failure ", i), 0)))
+	}
+
+	err := h.Close(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failure 0\n")
+	assert.Contains(t, err.Error(), fmt.Sprint("failure ", maxAsyncErrors-1, "\n"))
+	assert.NotContains(t, err.Error(), fmt.Sprint("failure ", maxAsyncErrors))
+	assert.Contains(t, err.Error(), "2 more errors")
+}
+
+func TestAsyncHandler_Context(t *testing.T) {
+	type key struct{}
+	var got context.Context
+	next := newTestHandler(true)
+	h := NewAsyncHandler(contextRecorder{Handler: next, got: &got}, 1, BlockWhenFull)
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "v"))
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "m", 0)))
+	cancel()
+	require.NoError(t, h.Close(context.Background()))
+
+	assert.Equal(t, "v", got.Value(key{}), "values are kept")
+	assert.NoError(t, got.Err(), "cancellation is not")
+}
+
+// contextRecorder stores the context of the last record it handles in got.
+type contextRecorder struct {
+	slog.Handler
+	got *context.Context
+}
+
+func (h contextRecorder) Handle(ctx context.Context, r slog.Record) error {
+	*h.got = ctx
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestAsyncHandler_Sinks(t *testing.T) {
+	h := NewAsyncHandler(newTestHandler(true), 1, BlockWhenFull)
+	defer h.Close(context.Background())
+
+	assert.Equal(t, []string{"*slogs.testHandler"}, handlerSinks(h, nil))
+}
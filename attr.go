@@ -1,11 +1,99 @@
 package slogs
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/rockcookies/go-slogs/internal/stacktrace"
 )
 
+// ErrorKey is the key used by Err.
+const ErrorKey = "error"
+
+// Err constructs a field that stores err under the key "error".
+//
+// Handlers render the value using err.Error(). If err is nil, an empty attribute is
+// returned, which handlers ignore.
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	return slog.Any(ErrorKey, err)
+}
+
+// Errors constructs a field that stores errs under key as a list of structured entries, one per
+// error, each holding the error message and its type:
+//
+//	{"errors":[{"message":"open a.txt: no such file","type":"*fs.PathError"},{"message":"timeout","type":"*errors.errorString"}]}
+//
+// Errors wrapping several errors through an Unwrap() []error method, such as those returned by
+// errors.Join, are expanded into their wrapped errors, recursively. nil errors are skipped; if
+// no error remains, an empty attribute is returned, which handlers ignore.
+//
+// The JSON handler renders the list as an array; handlers rendering values as text, such as
+// the text handler, render the messages separated by semicolons.
+func Errors(key string, errs ...error) slog.Attr {
+	list := flattenErrors(nil, errs)
+	if len(list) == 0 {
+		return slog.Attr{}
+	}
+	return slog.Any(key, list)
+}
+
+// errorList is the value of an Errors attribute.
+type errorList []error
+
+// errorEntry is the JSON representation of an error of an errorList.
+type errorEntry struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// MarshalJSON renders the list as an array of errorEntry.
+func (l errorList) MarshalJSON() ([]byte, error) {
+	entries := make([]errorEntry, len(l))
+	for i, err := range l {
+		entries[i] = errorEntry{Message: err.Error(), Type: fmt.Sprintf("%T", err)}
+	}
+	return json.Marshal(entries)
+}
+
+// MarshalText renders the messages of the list separated by semicolons.
+func (l errorList) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// String returns the messages of the list separated by semicolons.
+func (l errorList) String() string {
+	msgs := make([]string, len(l))
+	for i, err := range l {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// flattenErrors appends to list the non-nil errors of errs, expanding multi-errors.
+func flattenErrors(list errorList, errs []error) errorList {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			list = flattenErrors(list, multi.Unwrap())
+			continue
+		}
+		list = append(list, err)
+	}
+	return list
+}
+
 // Stack constructs a field that stores a stacktrace of the current goroutine
 // under provided key. Keep in mind that taking a stacktrace is eager and
 // expensive (relatively speaking); this function both makes an allocation and
@@ -19,3 +107,84 @@ func Stack(key string) slog.Attr {
 func StackSkip(key string, skip int) slog.Attr {
 	return slog.String(key, stacktrace.Take(skip+1)) // skip StackSkip
 }
+
+// BytesEncoding selects how Bytes renders a byte slice.
+type BytesEncoding int
+
+const (
+	// BytesBase64 renders the bytes using standard base64 encoding,
+	// matching how slog renders []byte by default.
+	BytesBase64 BytesEncoding = iota
+	// BytesHex renders the bytes as lowercase hexadecimal.
+	BytesHex
+	// BytesUTF8 renders the bytes as a string when they are valid, printable UTF-8,
+	// and falls back to hexadecimal otherwise.
+	BytesUTF8
+)
+
+// BytesLimit is the maximum number of bytes rendered by Bytes.
+// Larger slices are truncated and annotated with their total size.
+const BytesLimit = 1024
+
+// Bytes constructs a field that renders b using the given encoding.
+//
+// Slices longer than BytesLimit are truncated so that large payloads cannot blow up
+// log size; the rendered value then ends with "...(N bytes)" where N is len(b).
+func Bytes(key string, b []byte, enc BytesEncoding) slog.Attr {
+	truncated := len(b) > BytesLimit
+	data := b
+	if truncated {
+		data = b[:BytesLimit]
+	}
+
+	var s string
+	switch enc {
+	case BytesHex:
+		s = hex.EncodeToString(data)
+	case BytesUTF8:
+		if truncated {
+			data = trimIncompleteRune(data)
+		}
+		if isPrintableUTF8(data) {
+			s = string(data)
+		} else {
+			s = hex.EncodeToString(data)
+		}
+	default:
+		s = base64.StdEncoding.EncodeToString(data)
+	}
+
+	if truncated {
+		s += "...(" + strconv.Itoa(len(b)) + " bytes)"
+	}
+	return slog.String(key, s)
+}
+
+// trimIncompleteRune drops a trailing partial UTF-8 sequence left behind by truncation.
+func trimIncompleteRune(b []byte) []byte {
+	for i := 0; i < utf8.UTFMax && i < len(b); i++ {
+		if utf8.RuneStart(b[len(b)-1-i]) {
+			if !utf8.FullRune(b[len(b)-1-i:]) {
+				return b[:len(b)-1-i]
+			}
+			break
+		}
+	}
+	return b
+}
+
+// isPrintableUTF8 reports whether b is valid UTF-8 consisting only of printable
+// characters and common whitespace.
+func isPrintableUTF8(b []byte) bool {
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size <= 1 {
+			return false
+		}
+		if !unicode.IsPrint(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+		b = b[size:]
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+var _ slog.Handler = (*AttrRoutingHandler)(nil)
+
+// AttrRoutingHandler passes each record to a single handler chosen by the value of one of its
+// attributes, e.g. the records with tenant=acme to the sink of the acme tenant, so that the logs
+// of several tenants can be separated without a logger per tenant.
+//
+// The routing attribute is looked up among the top-level attributes of the record and of
+// WithAttrs calls made before any WithGroup call, and its value is compared as a string;
+// attributes of the record take precedence. Records without a matching route go to the default
+// handler, or are dropped if there is none.
+//
+// Example:
+//
+//	handler := slogs.RouteByAttr("tenant", map[string]slog.Handler{
+//		"acme":   slog.NewJSONHandler(acmeFile, nil),
+//		"globex": slog.NewJSONHandler(globexFile, nil),
+//	}).Default(slog.NewJSONHandler(os.Stdout, nil))
+//	logger := slogs.New(slogs.NewHandler(handler))
+//	logger.Info("order created", "tenant", "acme") // written to acmeFile
+type AttrRoutingHandler struct {
+	key    string
+	values []string // sorted, so that derived handlers are built in a stable order
+	routes map[string]slog.Handler
+	def    slog.Handler // nil if there is no default handler
+
+	// value is the routing value found in WithAttrs calls, if found is set.
+	value string
+	found bool
+	// grouped is set after WithGroup, when record attributes are no longer top-level.
+	grouped bool
+}
+
+// RouteByAttr creates an AttrRoutingHandler passing the records whose key attribute has a
+// value of valueToHandler to the corresponding handler. nil handlers are ignored.
+func RouteByAttr(key string, valueToHandler map[string]slog.Handler) *AttrRoutingHandler {
+	h := &AttrRoutingHandler{key: key, routes: make(map[string]slog.Handler, len(valueToHandler))}
+	for value, next := range valueToHandler {
+		if next != nil {
+			h.values = append(h.values, value)
+			h.routes[value] = next
+		}
+	}
+	sort.Strings(h.values)
+	return h
+}
+
+// Default returns an AttrRoutingHandler that passes the records matching no route to next. A
+// nil next drops them.
+func (h *AttrRoutingHandler) Default(next slog.Handler) *AttrRoutingHandler {
+	h2 := *h
+	h2.def = next
+	return &h2
+}
+
+// Enabled reports whether a handler records at the given level could be passed to is enabled.
+// Once the routing value is known from WithAttrs, only the handler it selects is consulted.
+func (h *AttrRoutingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.found {
+		next := h.find(h.value, true)
+		return next != nil && next.Enabled(ctx, level)
+	}
+
+	for _, value := range h.values {
+		if h.routes[value].Enabled(ctx, level) {
+			return true
+		}
+	}
+	return h.def != nil && h.def.Enabled(ctx, level)
+}
+
+// Handle passes r to the handler selected by its routing attribute, if it is enabled.
+func (h *AttrRoutingHandler) Handle(ctx context.Context, r slog.Record) error {
+	value, found := h.value, h.found
+	if !h.grouped {
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == h.key {
+				value, found = a.Value.Resolve().String(), true
+				return false
+			}
+			return true
+		})
+	}
+
+	next := h.find(value, found)
+	if next == nil || !next.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return next.Handle(ctx, r)
+}
+
+// WithAttrs returns an AttrRoutingHandler whose handlers all have the given attributes, and
+// which routes by the value of the routing attribute if it is among them.
+func (h *AttrRoutingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := h.derive(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+	if !h.grouped {
+		for _, a := range attrs {
+			if a.Key == h.key {
+				h2.value, h2.found = a.Value.Resolve().String(), true
+			}
+		}
+	}
+	return h2
+}
+
+// WithGroup returns an AttrRoutingHandler whose handlers all have the given group.
+func (h *AttrRoutingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := h.derive(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+	h2.grouped = true
+	return h2
+}
+
+func (h *AttrRoutingHandler) derive(fn func(next slog.Handler) slog.Handler) *AttrRoutingHandler {
+	h2 := *h
+	h2.routes = make(map[string]slog.Handler, len(h.routes))
+	for _, value := range h.values {
+		h2.routes[value] = fn(h.routes[value])
+	}
+	if h.def != nil {
+		h2.def = fn(h.def)
+	}
+	return &h2
+}
+
+// find returns the handler of value if found is set and value has a route, the default
+// handler, or nil.
+func (h *AttrRoutingHandler) find(value string, found bool) slog.Handler {
+	if found {
+		if next, ok := h.routes[value]; ok {
+			return next
+		}
+	}
+	return h.def
+}
+
+// unwrapAll returns the handlers h passes records to, see handlerSinks.
+func (h *AttrRoutingHandler) unwrapAll() []slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.values)+1)
+	for _, value := range h.values {
+		handlers = append(handlers, h.routes[value])
+	}
+	if h.def != nil {
+		handlers = append(handlers, h.def)
+	}
+	return handlers
+}
@@ -0,0 +1,132 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteByAttr(t *testing.T) {
+	tests := []struct {
+		name       string
+		withDef    bool
+		log        func(l *slog.Logger)
+		wantTarget string
+		wantLine   string
+	}{
+		{
+			name:       "record attribute",
+			log:        func(l *slog.Logger) { l.Info("m", "a", 1, "tenant", "acme") },
+			wantTarget: "acme",
+			wantLine:   `"msg":"m","a":1,"tenant":"acme"}`,
+		},
+		{
+			name:       "attribute from WithAttrs",
+			log:        func(l *slog.Logger) { l.With("tenant", "globex").WithGroup("g").Info("m", "b", 2) },
+			wantTarget: "globex",
+			wantLine:   `"msg":"m","tenant":"globex","g":{"b":2}}`,
+		},
+		{
+			name:       "record attribute takes precedence",
+			log:        func(l *slog.Logger) { l.With("tenant", "globex").Info("m", "tenant", "acme") },
+			wantTarget: "acme",
+			wantLine:   `"msg":"m","tenant":"globex","tenant":"acme"}`,
+		},
+		{
+			name:       "non-string value",
+			log:        func(l *slog.Logger) { l.Info("m", "tenant", 42) },
+			wantTarget: "42",
+			wantLine:   `"msg":"m","tenant":42}`,
+		},
+		{
+			name:       "grouped attribute is ignored",
+			withDef:    true,
+			log:        func(l *slog.Logger) { l.WithGroup("g").Info("m", "tenant", "acme") },
+			wantTarget: "default",
+			wantLine:   `"msg":"m","g":{"tenant":"acme"}}`,
+		},
+		{
+			name:       "unknown value",
+			withDef:    true,
+			log:        func(l *slog.Logger) { l.Info("m", "tenant", "initech") },
+			wantTarget: "default",
+			wantLine:   `"msg":"m","tenant":"initech"}`,
+		},
+		{
+			name:       "missing attribute",
+			withDef:    true,
+			log:        func(l *slog.Logger) { l.Info("m") },
+			wantTarget: "default",
+			wantLine:   `"msg":"m"}`,
+		},
+		{
+			name: "no default",
+			log:  func(l *slog.Logger) { l.Info("m", "tenant", "initech") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bufs := map[string]*bytes.Buffer{"acme": {}, "globex": {}, "42": {}, "default": {}}
+			h := RouteByAttr("tenant", map[string]slog.Handler{
+				"acme":   slog.NewJSONHandler(bufs["acme"], nil),
+				"globex": slog.NewJSONHandler(bufs["globex"], nil),
+				"42":     slog.NewJSONHandler(bufs["42"], nil),
+				"nil":    nil,
+			})
+			if tt.withDef {
+				h = h.Default(slog.NewJSONHandler(bufs["default"], nil))
+			}
+
+			tt.log(slog.New(h))
+
+			for name, buf := range bufs {
+				if name == tt.wantTarget {
+					assert.Contains(t, buf.String(), tt.wantLine, name)
+				} else {
+					assert.Empty(t, buf.String(), name)
+				}
+			}
+		})
+	}
+}
+
+func TestRouteByAttr_Enabled(t *testing.T) {
+	ctx := context.Background()
+	warnOnly := slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn})
+	h := RouteByAttr("tenant", map[string]slog.Handler{
+		"acme":   warnOnly,
+		"globex": slog.NewJSONHandler(io.Discard, nil),
+	})
+
+	assert.True(t, h.Enabled(ctx, slog.LevelInfo), "a route is enabled")
+	assert.False(t, h.Enabled(ctx, slog.LevelDebug), "no route is enabled")
+	assert.False(t, h.WithAttrs([]slog.Attr{slog.String("tenant", "acme")}).Enabled(ctx, slog.LevelInfo),
+		"only the selected route is consulted")
+	assert.False(t, h.WithAttrs([]slog.Attr{slog.String("tenant", "initech")}).Enabled(ctx, slog.LevelInfo),
+		"no default handler")
+
+	// The selected route is checked again on Handle, since Enabled may have consulted another one.
+	buf := &bytes.Buffer{}
+	h2 := RouteByAttr("tenant", map[string]slog.Handler{
+		"acme":   slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelWarn}),
+		"globex": slog.NewJSONHandler(io.Discard, nil),
+	})
+	slog.New(h2).Info("m", "tenant", "acme")
+	assert.Empty(t, buf.String())
+}
+
+func TestRouteByAttr_Sinks(t *testing.T) {
+	h := RouteByAttr("tenant", map[string]slog.Handler{
+		"b": slog.NewTextHandler(io.Discard, nil),
+		"a": slog.NewJSONHandler(io.Discard, nil),
+	}).Default(slog.NewJSONHandler(io.Discard, nil))
+
+	assert.Equal(t, []string{"*slog.JSONHandler", "*slog.TextHandler", "*slog.JSONHandler"}, handlerSinks(h, nil))
+	assert.Same(t, h, h.WithAttrs(nil))
+	assert.Same(t, h, h.WithGroup(""))
+}
@@ -1,7 +1,10 @@
 package slogs
 
 import (
+	"bytes"
+	"errors"
 	"log/slog"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -85,3 +88,133 @@ func TestStackLargeSkip(t *testing.T) {
 	lines := strings.Count(stackStr, "\n")
 	assert.Less(t, lines, 10)
 }
+
+func TestBytes(t *testing.T) {
+	long := []byte(strings.Repeat("a", BytesLimit+10))
+	longRunes := []byte(strings.Repeat("€", BytesLimit)) // 3 bytes per rune
+
+	tests := []struct {
+		name string
+		b    []byte
+		enc  BytesEncoding
+		want string
+	}{
+		{name: "base64", b: []byte("hello"), enc: BytesBase64, want: "aGVsbG8="},
+		{name: "hex", b: []byte("hello"), enc: BytesHex, want: "68656c6c6f"},
+		{name: "utf8 printable", b: []byte("hello\tworld\n"), enc: BytesUTF8, want: "hello\tworld\n"},
+		{name: "utf8 non-printable falls back to hex", b: []byte{'a', 0x00, 'b'}, enc: BytesUTF8, want: "610062"},
+		{name: "utf8 invalid falls back to hex", b: []byte{0xff, 0xfe}, enc: BytesUTF8, want: "fffe"},
+		{name: "empty", b: nil, enc: BytesHex, want: ""},
+		{name: "exactly at limit is not truncated", b: long[:BytesLimit], enc: BytesUTF8, want: string(long[:BytesLimit])},
+		{
+			name: "truncated utf8",
+			b:    long,
+			enc:  BytesUTF8,
+			want: strings.Repeat("a", BytesLimit) + "...(1034 bytes)",
+		},
+		{
+			name: "truncated hex",
+			b:    long,
+			enc:  BytesHex,
+			want: strings.Repeat("61", BytesLimit) + "...(1034 bytes)",
+		},
+		{
+			name: "truncation does not split runes",
+			b:    longRunes,
+			enc:  BytesUTF8,
+			want: strings.Repeat("€", BytesLimit/3) + "...(3072 bytes)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Bytes("payload", tt.b, tt.enc)
+			assert.Equal(t, "payload", a.Key)
+			assert.Equal(t, slog.KindString, a.Value.Kind())
+			assert.Equal(t, tt.want, a.Value.String())
+		})
+	}
+}
+
+func TestErr(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "non-nil error", err: errors.New("boom"), want: `"error":"boom"`},
+		{name: "nil error is omitted", err: nil, want: `{"msg":"m"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+			logger.Info("m", Err(tt.err))
+
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+type codeError struct{ code int }
+
+func (e *codeError) Error() string { return "code " + strconv.Itoa(e.code) }
+
+func TestErrors(t *testing.T) {
+	boom := errors.New("boom")
+	coded := &codeError{code: 7}
+
+	tests := []struct {
+		name     string
+		errs     []error
+		wantJSON string
+		wantText string
+	}{
+		{
+			name:     "explicit slice",
+			errs:     []error{boom, coded},
+			wantJSON: `{"msg":"m","errs":[{"message":"boom","type":"*errors.errorString"},{"message":"code 7","type":"*slogs.codeError"}]}`,
+			wantText: `msg=m errs="boom; code 7"`,
+		},
+		{
+			name:     "joined errors are expanded",
+			errs:     []error{errors.Join(boom, errors.Join(coded, nil))},
+			wantJSON: `{"msg":"m","errs":[{"message":"boom","type":"*errors.errorString"},{"message":"code 7","type":"*slogs.codeError"}]}`,
+			wantText: `msg=m errs="boom; code 7"`,
+		},
+		{
+			name:     "nil errors are omitted",
+			errs:     []error{nil, nil},
+			wantJSON: `{"msg":"m"}`,
+			wantText: `msg=m`,
+		},
+	}
+
+	noTimeLevel := &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonBuf, textBuf := &bytes.Buffer{}, &bytes.Buffer{}
+			slog.New(slog.NewJSONHandler(jsonBuf, noTimeLevel)).Info("m", Errors("errs", tt.errs...))
+			slog.New(slog.NewTextHandler(textBuf, noTimeLevel)).Info("m", Errors("errs", tt.errs...))
+
+			assert.JSONEq(t, tt.wantJSON, jsonBuf.String())
+			assert.Equal(t, tt.wantText+"\n", textBuf.String())
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package slogs
 
-import "log/slog"
+import (
+	"log/slog"
+	"slices"
+)
 
 // GroupOrAttrs represents a node in a linked list that holds either a group name or attributes.
 //
@@ -58,3 +61,66 @@ func (g *GroupOrAttrs) WithAttrs(attrs []slog.Attr) *GroupOrAttrs {
 		next:  g,
 	}
 }
+
+// withAttrsReplacing is like WithAttrs, but first removes the attributes with the same keys
+// from the nodes of the current group level, that is, the nodes before the first group node.
+// If attrs itself repeats a key, the last occurrence is kept.
+//
+// The removed attributes are dropped by copying the affected nodes, since nodes are shared
+// between handlers.
+func (g *GroupOrAttrs) withAttrsReplacing(attrs []slog.Attr) *GroupOrAttrs {
+	keys := make(map[string]bool, len(attrs))
+	deduped := make([]slog.Attr, 0, len(attrs))
+	for i := len(attrs) - 1; i >= 0; i-- {
+		a := attrs[i]
+		if a.Key != "" {
+			if keys[a.Key] {
+				continue
+			}
+			keys[a.Key] = true
+		}
+		deduped = append(deduped, a)
+	}
+	if len(deduped) == 0 {
+		return g
+	}
+	slices.Reverse(deduped)
+
+	// Collect the attribute nodes of the current level, newest first.
+	var level []*GroupOrAttrs
+	base := g
+	for base != nil && base.group == "" {
+		level = append(level, base)
+		base = base.next
+	}
+
+	replaced := false
+	for _, n := range level {
+		if containsKey(n.attrs, keys) {
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		return g.WithAttrs(deduped)
+	}
+
+	// Rebuild the level on top of base, oldest first, without the replaced attributes.
+	for i := len(level) - 1; i >= 0; i-- {
+		kept := slices.DeleteFunc(slices.Clone(level[i].attrs), func(a slog.Attr) bool {
+			return a.Key != "" && keys[a.Key]
+		})
+		base = base.WithAttrs(kept)
+	}
+	return base.WithAttrs(deduped)
+}
+
+// containsKey reports whether one of attrs has a key in keys.
+func containsKey(attrs []slog.Attr, keys map[string]bool) bool {
+	for _, a := range attrs {
+		if a.Key != "" && keys[a.Key] {
+			return true
+		}
+	}
+	return false
+}
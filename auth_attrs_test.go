@@ -0,0 +1,71 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		attr     slog.Attr
+		expected string
+	}{
+		{name: "user", attr: User("u-42"), expected: `{"user":{"id":"u-42"}}`},
+		{name: "user with roles", attr: User("u-42", "admin", "billing"), expected: `{"user":{"id":"u-42","roles":["admin","billing"]}}`},
+		{name: "tenant", attr: Tenant("acme"), expected: `{"tenant":{"id":"acme"}}`},
+		{name: "session", attr: Session("s-1"), expected: `{"session":{"id":"s-1"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropBuiltins})).Info("m", tt.attr)
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestUser_RolesCopied(t *testing.T) {
+	roles := []string{"admin"}
+	a := User("u-42", roles...)
+	roles[0] = "guest"
+
+	assert.Equal(t, []string{"admin"}, a.Value.Group()[1].Value.Any())
+}
+
+func TestContextWithAuth(t *testing.T) {
+	ctx := ContextWithAuth(nil, User("u-1"), Tenant("acme"))
+	impersonated := ContextWithAuth(ctx, User("u-2", "support"), Session("s-1"))
+
+	assert.Nil(t, AuthAttrs(context.Background()))
+	assert.Equal(t, []slog.Attr{User("u-1"), Tenant("acme")}, AuthAttrs(ctx), "parent is unchanged")
+	assert.Equal(t, []slog.Attr{User("u-2", "support"), Tenant("acme"), Session("s-1")}, AuthAttrs(impersonated))
+}
+
+func TestAuthAttrs_Extractor(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropBuiltins})).
+		WithContextExtractors(NewContextExtractors(AuthAttrs)).
+		WithReplaceAttr(ForGroup([]string{UserKey}, PresetRedact(AuthIDKey)))
+	logger := slog.New(h)
+
+	logger.InfoContext(ContextWithAuth(context.Background(), User("u-42", "admin"), Tenant("acme")), "m", "k", "v")
+	logger.InfoContext(context.Background(), "m", "k", "v")
+
+	assert.Equal(t, `{"user":{"id":"[REDACTED]","roles":["admin"]},"tenant":{"id":"acme"},"k":"v"}`+"\n"+
+		`{"k":"v"}`+"\n", buf.String())
+}
+
+// dropBuiltins removes the time, level and message from the output of slog handlers.
+func dropBuiltins(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+		return slog.Attr{}
+	}
+	return a
+}
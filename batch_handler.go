@@ -0,0 +1,377 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	_ slog.Handler = (*BatchHandler)(nil)
+	_ Closer       = (*BatchHandler)(nil)
+)
+
+// ErrHandlerClosed is returned when a record is handled after the handler was closed.
+var ErrHandlerClosed = errors.New("slogs: handler is closed")
+
+// ErrNilBatchFunc is returned by NewBatchHandlerE when the encoder or the flush function is nil.
+var ErrNilBatchFunc = errors.New("slogs: batch encoder and flush function cannot be nil")
+
+// FlushFunc receives a batch of encoded records, e.g. to send it over the network.
+//
+// The batch must not be retained after FlushFunc returns.
+type FlushFunc func(batch []byte) error
+
+// BatchOptions configures a BatchHandler. The zero value uses the defaults.
+type BatchOptions struct {
+	// MaxBytes is the size at which a batch is handed to the FlushFunc. Defaults to 64 KiB.
+	MaxBytes int
+
+	// MaxPending is the number of full batches that may wait for the FlushFunc.
+	// When it is reached, Handle blocks until a batch is flushed. Defaults to 4.
+	MaxPending int
+
+	// FlushInterval, if positive, flushes a partial batch when nothing else is waiting to be
+	// flushed, bounding how long records stay buffered during idle periods.
+	FlushInterval time.Duration
+
+	// Clock drives FlushInterval. Defaults to DefaultClock.
+	Clock Clock
+
+	// HighWatermark is the buffer occupancy, between 0 and 1, above which the function set
+	// with OnHighWatermark is called. Defaults to 0.8.
+	HighWatermark float64
+}
+
+// BufferStats reports the state of a BatchHandler's buffer.
+type BufferStats struct {
+	// BufferedBytes is the size of the records not yet flushed, including the current batch.
+	BufferedBytes int64
+	// PendingBatches is the number of full batches waiting for the FlushFunc.
+	PendingBatches int64
+	// CapacityBytes is the number of bytes that can be buffered before Handle blocks.
+	CapacityBytes int64
+}
+
+// Occupancy returns BufferedBytes as a fraction of CapacityBytes.
+func (s BufferStats) Occupancy() float64 {
+	if s.CapacityBytes <= 0 {
+		return 0
+	}
+	return float64(s.BufferedBytes) / float64(s.CapacityBytes)
+}
+
+// BatchHandler encodes records into batches of bytes and hands them to a FlushFunc
+// from a background goroutine.
+//
+// Records are encoded by a handler writing to the batch, such as slog.NewJSONHandler. A batch
+// is flushed once it reaches MaxBytes; when the FlushFunc drains batches more slowly than they
+// fill, up to MaxPending full batches are queued and Handle then blocks, applying backpressure
+// to the callers. BufferStats and OnHighWatermark expose this so consumers can be scaled before
+// logging slows down.
+//
+// Close must be called to flush the last batch and stop the background goroutine.
+//
+// Example:
+//
+//	batch := slogs.NewBatchHandler(func(w io.Writer) slog.Handler {
+//		return slog.NewJSONHandler(w, nil)
+//	}, send, &slogs.BatchOptions{MaxBytes: 1 << 20, FlushInterval: time.Second})
+//	defer batch.Close(context.Background())
+type BatchHandler struct {
+	next    slog.Handler
+	batcher *batcher
+}
+
+// batcher is the buffer shared by a BatchHandler and the handlers derived from it.
+type batcher struct {
+	flush         FlushFunc
+	maxBytes      int
+	capacity      int64
+	highWatermark float64
+
+	maxPending int
+
+	mu  sync.Mutex
+	buf []byte
+	// queue holds the full batches waiting for the FlushFunc, oldest first.
+	queue  [][]byte
+	closed bool
+	// space is signaled when a batch leaves the queue or the batcher is closed.
+	space *sync.Cond
+	// wake tells the background goroutine that the queue changed or the batcher was closed.
+	wake chan struct{}
+
+	buffered atomic.Int64
+	pending  atomic.Int64
+
+	onHigh atomic.Pointer[func(BufferStats)]
+	high   atomic.Bool
+
+	stopped chan struct{}
+	errMu   sync.Mutex
+	lastErr error
+}
+
+// NewBatchHandler creates a BatchHandler whose records are encoded by the handler returned
+// by encoder and flushed with flush. opts may be nil.
+//
+// Panics if encoder or flush is nil; see NewBatchHandlerE.
+func NewBatchHandler(encoder func(w io.Writer) slog.Handler, flush FlushFunc, opts *BatchOptions) *BatchHandler {
+	h, err := NewBatchHandlerE(encoder, flush, opts)
+	if err != nil {
+		panic(err.Error())
+	}
+	return h
+}
+
+// NewBatchHandlerE is like NewBatchHandler but returns ErrNilBatchFunc instead of panicking if
+// encoder or flush is nil.
+func NewBatchHandlerE(encoder func(w io.Writer) slog.Handler, flush FlushFunc, opts *BatchOptions) (*BatchHandler, error) {
+	if encoder == nil || flush == nil {
+		return nil, ErrNilBatchFunc
+	}
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = 64 << 10
+	}
+	maxPending := opts.MaxPending
+	if maxPending <= 0 {
+		maxPending = 4
+	}
+	highWatermark := opts.HighWatermark
+	if highWatermark <= 0 {
+		highWatermark = 0.8
+	}
+	clock := opts.Clock
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	b := &batcher{
+		flush:         flush,
+		maxBytes:      maxBytes,
+		capacity:      int64(maxBytes) * int64(maxPending+1),
+		highWatermark: highWatermark,
+		maxPending:    maxPending,
+		wake:          make(chan struct{}, 1),
+		stopped:       make(chan struct{}),
+	}
+	b.space = sync.NewCond(&b.mu)
+
+	var ticker *time.Ticker
+	if opts.FlushInterval > 0 {
+		ticker = clock.NewTicker(opts.FlushInterval)
+	}
+	go b.run(ticker)
+
+	return &BatchHandler{next: encoder(b), batcher: b}, nil
+}
+
+// BufferStats returns the current buffer occupancy. It does not take any lock.
+func (h *BatchHandler) BufferStats() BufferStats {
+	return h.batcher.stats()
+}
+
+// OnHighWatermark sets fn to be called when the buffer occupancy rises above the
+// HighWatermark option. fn is called again only after the occupancy fell below it.
+//
+// fn is called synchronously by the goroutine whose record crossed the watermark, after the
+// record was buffered; it must not block. Passing nil removes the callback.
+func (h *BatchHandler) OnHighWatermark(fn func(BufferStats)) {
+	if fn == nil {
+		h.batcher.onHigh.Store(nil)
+		return
+	}
+	h.batcher.onHigh.Store(&fn)
+}
+
+// Close flushes the buffered records and stops the background goroutine.
+//
+// It returns the last error returned by the FlushFunc, or ctx.Err() if ctx is done before
+// all batches are flushed. Records handled after Close fail with ErrHandlerClosed.
+func (h *BatchHandler) Close(ctx context.Context) error {
+	b := h.batcher
+
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		// Writers waiting for room give up; their records are already in the buffer.
+		b.space.Broadcast()
+	}
+	b.mu.Unlock()
+	b.signal()
+
+	select {
+	case <-b.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	b.errMu.Lock()
+	defer b.errMu.Unlock()
+	return b.lastErr
+}
+
+// Enabled reports whether the encoder handles records at the given level.
+func (h *BatchHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle encodes r into the current batch.
+func (h *BatchHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a BatchHandler sharing the same batches whose encoder has the given attributes.
+func (h *BatchHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a BatchHandler sharing the same batches whose encoder has the given group.
+func (h *BatchHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+// Write appends an encoded record to the current batch, queueing the batch once it is full.
+func (b *batcher) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return 0, ErrHandlerClosed
+	}
+
+	b.buf = append(b.buf, p...)
+	b.buffered.Add(int64(len(p)))
+	for len(b.buf) >= b.maxBytes && !b.closed {
+		if len(b.queue) < b.maxPending {
+			b.queue = append(b.queue, b.buf)
+			b.pending.Add(1)
+			b.buf = nil
+			b.signal()
+			break
+		}
+		// Waits while MaxPending batches are queued, which applies backpressure. Wait
+		// releases b.mu, so the background goroutine is never blocked by a waiting writer.
+		b.space.Wait()
+	}
+	b.mu.Unlock()
+
+	b.checkWatermark()
+	return len(p), nil
+}
+
+// signal wakes the background goroutine up without blocking.
+func (b *batcher) signal() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (b *batcher) run(ticker *time.Ticker) {
+	defer close(b.stopped)
+
+	var tick <-chan time.Time
+	if ticker != nil {
+		tick = ticker.C
+		defer ticker.Stop()
+	}
+
+	for {
+		partial := false
+		select {
+		case <-b.wake:
+		case <-tick:
+			partial = true
+		}
+
+		for {
+			batch, closed := b.next(partial)
+			if batch == nil {
+				if closed {
+					return
+				}
+				break
+			}
+			b.flushBatch(batch)
+		}
+	}
+}
+
+// next removes and returns the oldest queued batch. Once the queue is empty, it returns the
+// partial batch if partial is set or the batcher is closed, and nil otherwise. closed reports
+// whether the batcher is closed.
+func (b *batcher) next(partial bool) (batch []byte, closed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.queue) > 0 {
+		batch = b.queue[0]
+		b.queue[0] = nil
+		b.queue = b.queue[1:]
+		b.pending.Add(-1)
+		b.space.Signal()
+		return batch, b.closed
+	}
+
+	// The partial batch is only flushed once the queue is empty, so records are not reordered.
+	if (partial || b.closed) && len(b.buf) > 0 {
+		batch = b.buf
+		b.buf = nil
+	}
+	return batch, b.closed
+}
+
+func (b *batcher) flushBatch(batch []byte) {
+	if err := b.flush(batch); err != nil {
+		b.errMu.Lock()
+		b.lastErr = err
+		b.errMu.Unlock()
+	}
+
+	b.buffered.Add(-int64(len(batch)))
+	if b.stats().Occupancy() < b.highWatermark {
+		b.high.Store(false)
+	}
+}
+
+func (b *batcher) stats() BufferStats {
+	return BufferStats{
+		BufferedBytes:  b.buffered.Load(),
+		PendingBatches: b.pending.Load(),
+		CapacityBytes:  b.capacity,
+	}
+}
+
+// checkWatermark calls the high watermark callback if the occupancy just rose above it.
+func (b *batcher) checkWatermark() {
+	stats := b.stats()
+	if stats.Occupancy() < b.highWatermark || !b.high.CompareAndSwap(false, true) {
+		return
+	}
+	if fn := b.onHigh.Load(); fn != nil {
+		(*fn)(stats)
+	}
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *BatchHandler) unwrap() slog.Handler {
+	return h.next
+}
@@ -0,0 +1,247 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// msgOnlyEncoder encodes each record as "msg=<message>\n".
+func msgOnlyEncoder(w io.Writer) slog.Handler {
+	return slog.NewTextHandler(w, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+}
+
+// batchRecorder is a FlushFunc that records the batches it receives.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []string
+	err     error
+}
+
+func (r *batchRecorder) flush(batch []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, string(batch))
+	return r.err
+}
+
+func (r *batchRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.batches...)
+}
+
+func handleMsg(t *testing.T, h slog.Handler, msg string) {
+	t.Helper()
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, msg, 0)))
+}
+
+func TestNewBatchHandler_Nil(t *testing.T) {
+	assert.Panics(t, func() { NewBatchHandler(nil, func([]byte) error { return nil }, nil) })
+	assert.Panics(t, func() { NewBatchHandler(msgOnlyEncoder, nil, nil) })
+
+	h, err := NewBatchHandlerE(msgOnlyEncoder, nil, nil)
+	assert.ErrorIs(t, err, ErrNilBatchFunc)
+	assert.Nil(t, h)
+}
+
+func TestBatchHandler_FlushBySize(t *testing.T) {
+	rec := &batchRecorder{}
+	h := NewBatchHandler(msgOnlyEncoder, rec.flush, &BatchOptions{MaxBytes: 12})
+
+	handleMsg(t, h, "a")
+	handleMsg(t, h, "b")
+	handleMsg(t, h.WithAttrs([]slog.Attr{slog.Int("n", 1)}), "c")
+	require.NoError(t, h.Close(context.Background()))
+
+	assert.Equal(t, []string{"msg=a\nmsg=b\n", "msg=c n=1\n"}, rec.get())
+}
+
+func TestBatchHandler_Close(t *testing.T) {
+	errFlush := errors.New("flush failed")
+	rec := &batchRecorder{err: errFlush}
+	h := NewBatchHandler(msgOnlyEncoder, rec.flush, nil)
+
+	handleMsg(t, h, "a")
+	assert.Empty(t, rec.get(), "partial batch is kept until Close")
+
+	assert.ErrorIs(t, h.Close(context.Background()), errFlush)
+	assert.Equal(t, []string{"msg=a\n"}, rec.get())
+	assert.Equal(t, BufferStats{CapacityBytes: 5 * 64 << 10}, h.BufferStats())
+
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "late", 0))
+	assert.ErrorIs(t, err, ErrHandlerClosed)
+	assert.ErrorIs(t, h.Close(context.Background()), errFlush, "Close can be called again")
+}
+
+func TestBatchHandler_FlushInterval(t *testing.T) {
+	clock := newFakeClock()
+	rec := &batchRecorder{}
+	h := NewBatchHandler(msgOnlyEncoder, rec.flush, &BatchOptions{FlushInterval: time.Second, Clock: clock})
+
+	handleMsg(t, h, "a")
+	clock.Advance(time.Second)
+	// The tick is received synchronously; a second one ensures the first was processed.
+	clock.Advance(time.Second)
+	assert.Equal(t, []string{"msg=a\n"}, rec.get())
+
+	clock.Advance(time.Second)
+	assert.Len(t, rec.get(), 1, "empty batches are not flushed")
+
+	require.NoError(t, h.Close(context.Background()))
+}
+
+func TestBatchHandler_HighWatermark(t *testing.T) {
+	release := make(chan struct{})
+	rec := &batchRecorder{}
+	h := NewBatchHandler(msgOnlyEncoder, func(batch []byte) error {
+		<-release
+		return rec.flush(batch)
+	}, &BatchOptions{MaxBytes: 6, MaxPending: 2, HighWatermark: 0.5})
+
+	var calls []BufferStats
+	h.OnHighWatermark(func(s BufferStats) { calls = append(calls, s) })
+
+	handleMsg(t, h, "a")
+	assert.Empty(t, calls)
+	handleMsg(t, h, "b")
+	require.Len(t, calls, 1)
+	assert.Equal(t, int64(12), calls[0].BufferedBytes)
+	assert.Equal(t, int64(18), calls[0].CapacityBytes)
+	assert.InDelta(t, 12.0/18.0, calls[0].Occupancy(), 1e-9)
+
+	handleMsg(t, h, "c")
+	assert.Len(t, calls, 1, "callback fires once per crossing")
+
+	close(release)
+	require.NoError(t, h.Close(context.Background()))
+	assert.Equal(t, "msg=a\nmsg=b\nmsg=c\n", strings.Join(rec.get(), ""))
+	assert.Equal(t, int64(0), h.BufferStats().BufferedBytes)
+}
+
+func TestBatchHandler_Backpressure(t *testing.T) {
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	h := NewBatchHandler(msgOnlyEncoder, func([]byte) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}, &BatchOptions{MaxBytes: 1, MaxPending: 1})
+
+	handleMsg(t, h, "a")
+	<-started // the first batch is being flushed
+	handleMsg(t, h, "b")
+	assert.Equal(t, int64(1), h.BufferStats().PendingBatches)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handleMsg(t, h, "c")
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Handle should block while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-done
+	require.NoError(t, h.Close(context.Background()))
+}
+
+func TestBatchHandler_CloseContextDone(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	h := NewBatchHandler(msgOnlyEncoder, func([]byte) error {
+		<-release
+		return nil
+	}, nil)
+
+	handleMsg(t, h, "a")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, h.Close(ctx), context.Canceled)
+}
+
+func TestBatchHandler_FullQueueWithFlushInterval(t *testing.T) {
+	rec := &batchRecorder{}
+	h := NewBatchHandler(msgOnlyEncoder, rec.flush, &BatchOptions{
+		MaxBytes:      64,
+		MaxPending:    1,
+		FlushInterval: time.Millisecond,
+	})
+
+	const writers, perWriter = 4, 200
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				handleMsg(t, h, "message")
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Handle deadlocked while the queue was full")
+	}
+
+	require.NoError(t, h.Close(context.Background()))
+	assert.Equal(t, writers*perWriter, strings.Count(strings.Join(rec.get(), ""), "msg=message\n"))
+	assert.Equal(t, BufferStats{CapacityBytes: 2 * 64}, h.BufferStats())
+}
+
+func TestBatchHandler_CloseWithBlockedWriter(t *testing.T) {
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	rec := &batchRecorder{}
+	h := NewBatchHandler(msgOnlyEncoder, func(batch []byte) error {
+		started <- struct{}{}
+		<-release
+		return rec.flush(batch)
+	}, &BatchOptions{MaxBytes: 1, MaxPending: 1})
+
+	handleMsg(t, h, "a")
+	<-started
+	handleMsg(t, h, "b")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handleMsg(t, h, "c")
+	}()
+	time.Sleep(10 * time.Millisecond) // lets the writer block on the full queue
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, h.Close(ctx), context.DeadlineExceeded)
+	<-done // the blocked writer is released by Close
+
+	close(release)
+	require.NoError(t, h.Close(context.Background()))
+	assert.Equal(t, []string{"msg=a\n", "msg=b\n", "msg=c\n"}, rec.get())
+}
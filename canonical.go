@@ -0,0 +1,155 @@
+package slogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rockcookies/go-slogs/internal/bufferpool"
+)
+
+// CanonicalTimeFormat is the layout of times in CanonicalJSON output. Times are converted to UTC.
+const CanonicalTimeFormat = time.RFC3339Nano
+
+// CanonicalJSON returns a byte-stable JSON encoding of r, for golden tests, diffing and hashing.
+//
+// Records that are logically identical produce identical bytes regardless of attribute order:
+//   - The record time (omitted if zero), level and message are encoded with the keys
+//     slog.TimeKey, slog.LevelKey and slog.MessageKey, alongside the attributes
+//   - Object keys are sorted at every level; duplicate keys are kept and ordered by value
+//   - LogValuers are resolved, empty attributes and groups are omitted and attributes of
+//     groups with an empty key are inlined, as slog handlers do
+//   - Times are converted to UTC and formatted with CanonicalTimeFormat, durations are
+//     encoded as nanoseconds and errors by their message
+//
+// Other values are encoded with encoding/json, falling back to their fmt representation.
+func CanonicalJSON(r slog.Record) []byte {
+	fields := make([]canonicalField, 0, r.NumAttrs()+3)
+	if !r.Time.IsZero() {
+		fields = append(fields, canonicalField{key: slog.TimeKey, value: canonicalValue(slog.TimeValue(r.Time))})
+	}
+	fields = append(fields,
+		canonicalField{key: slog.LevelKey, value: canonicalValue(slog.StringValue(r.Level.String()))},
+		canonicalField{key: slog.MessageKey, value: canonicalValue(slog.StringValue(r.Message))},
+	)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendCanonicalFields(fields, a)
+		return true
+	})
+
+	return encodeCanonicalObject(fields)
+}
+
+// canonicalField is an object member with its value already encoded.
+type canonicalField struct {
+	key   string
+	value []byte
+}
+
+// appendCanonicalFields encodes a and appends the resulting members to fields.
+func appendCanonicalFields(fields []canonicalField, a slog.Attr) []canonicalField {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		return append(fields, canonicalField{key: a.Key, value: canonicalValue(a.Value)})
+	}
+
+	var members []canonicalField
+	for _, ga := range a.Value.Group() {
+		members = appendCanonicalFields(members, ga)
+	}
+	if len(members) == 0 {
+		return fields
+	}
+	if a.Key == "" {
+		return append(fields, members...)
+	}
+	return append(fields, canonicalField{key: a.Key, value: encodeCanonicalObject(members)})
+}
+
+// encodeCanonicalObject encodes fields as a JSON object with sorted keys.
+func encodeCanonicalObject(fields []canonicalField) []byte {
+	slices.SortFunc(fields, func(a, b canonicalField) int {
+		if c := strings.Compare(a.key, b.key); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.value, b.value)
+	})
+
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	buf.AppendByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			buf.AppendByte(',')
+		}
+		buf.AppendBytes(canonicalString(f.key))
+		buf.AppendByte(':')
+		buf.AppendBytes(f.value)
+	}
+	buf.AppendByte('}')
+
+	return bytes.Clone(buf.Bytes())
+}
+
+// canonicalValue encodes a resolved, non-group value.
+func canonicalValue(v slog.Value) []byte {
+	switch v.Kind() {
+	case slog.KindString:
+		return canonicalString(v.String())
+	case slog.KindInt64:
+		return strconv.AppendInt(nil, v.Int64(), 10)
+	case slog.KindUint64:
+		return strconv.AppendUint(nil, v.Uint64(), 10)
+	case slog.KindFloat64:
+		f := v.Float64()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return canonicalString(fmt.Sprint(f))
+		}
+		return canonicalJSONOrString(f)
+	case slog.KindBool:
+		return strconv.AppendBool(nil, v.Bool())
+	case slog.KindDuration:
+		return strconv.AppendInt(nil, v.Duration().Nanoseconds(), 10)
+	case slog.KindTime:
+		return canonicalString(v.Time().UTC().Format(CanonicalTimeFormat))
+	}
+
+	switch x := v.Any().(type) {
+	case error:
+		return canonicalString(x.Error())
+	default:
+		return canonicalJSONOrString(x)
+	}
+}
+
+// canonicalJSONOrString encodes v with encoding/json, or as its fmt representation if that fails.
+//
+// encoding/json sorts map keys, so maps are canonical too.
+func canonicalJSONOrString(v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return canonicalString(fmt.Sprintf("%+v", v))
+	}
+	return b
+}
+
+// canonicalString encodes s as a JSON string.
+func canonicalString(s string) []byte {
+	b, err := json.Marshal(s)
+	if err != nil {
+		// Marshaling a string cannot fail.
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,108 @@
+package slogs
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type canonicalTestValuer struct{}
+
+func (canonicalTestValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int("b", 2), slog.Int("a", 1))
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name     string
+		time     time.Time
+		attrs    []slog.Attr
+		expected string
+	}{
+		{
+			name:     "builtins",
+			time:     ts,
+			expected: `{"level":"INFO","msg":"hello","time":"2024-01-02T02:04:05.000000006Z"}`,
+		},
+		{
+			name:     "zero time omitted",
+			expected: `{"level":"INFO","msg":"hello"}`,
+		},
+		{
+			name: "values",
+			attrs: []slog.Attr{
+				slog.String("s", "x"),
+				slog.Int("i", -1),
+				slog.Uint64("u", 1),
+				slog.Float64("f", 1.5),
+				slog.Float64("nan", math.NaN()),
+				slog.Bool("b", true),
+				slog.Duration("d", time.Millisecond),
+				slog.Time("t", ts),
+				slog.Any("err", errors.New("boom")),
+				slog.Any("map", map[string]int{"z": 1, "y": 2}),
+				slog.Any("valuer", canonicalTestValuer{}),
+			},
+			expected: `{"b":true,"d":1000000,"err":"boom","f":1.5,"i":-1,"level":"INFO",` +
+				`"map":{"y":2,"z":1},"msg":"hello","nan":"NaN","s":"x","t":"2024-01-02T02:04:05.000000006Z","u":1,` +
+				`"valuer":{"a":1,"b":2}}`,
+		},
+		{
+			name: "groups",
+			attrs: []slog.Attr{
+				slog.Group("req", slog.String("path", "/"), slog.String("method", "GET")),
+				slog.Group("empty"),
+				slog.Group("", slog.String("inline", "yes")),
+				{},
+			},
+			expected: `{"inline":"yes","level":"INFO","msg":"hello","req":{"method":"GET","path":"/"}}`,
+		},
+		{
+			name:     "duplicate keys ordered by value",
+			attrs:    []slog.Attr{slog.Int("k", 2), slog.Int("k", 1)},
+			expected: `{"k":1,"k":2,"level":"INFO","msg":"hello"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := slog.NewRecord(tt.time, slog.LevelInfo, "hello", 0)
+			r.AddAttrs(tt.attrs...)
+
+			assert.Equal(t, tt.expected, string(CanonicalJSON(r)))
+		})
+	}
+}
+
+func TestCanonicalJSON_Unmarshalable(t *testing.T) {
+	r := slog.NewRecord(time.Time{}, slog.LevelInfo, "hello", 0)
+	r.AddAttrs(slog.Any("chan", make(chan int)))
+
+	// Channels cannot be marshaled, so their fmt representation is used.
+	assert.Regexp(t, `^\{"chan":"0x[0-9a-f]+","level":"INFO","msg":"hello"\}$`, string(CanonicalJSON(r)))
+}
+
+func TestCanonicalJSON_OrderIndependent(t *testing.T) {
+	ts := time.Now()
+	attrs := []slog.Attr{
+		slog.String("a", "1"),
+		slog.Group("g", slog.Int("y", 1), slog.Int("x", 2)),
+		slog.Bool("c", false),
+		slog.Int("dup", 1),
+		slog.Int("dup", 2),
+	}
+
+	r1 := slog.NewRecord(ts, slog.LevelWarn, "msg", 0)
+	r1.AddAttrs(attrs...)
+
+	r2 := slog.NewRecord(ts, slog.LevelWarn, "msg", 0)
+	r2.AddAttrs(attrs[4], slog.Group("g", slog.Int("x", 2), slog.Int("y", 1)), attrs[2], attrs[3], attrs[0])
+
+	assert.Equal(t, string(CanonicalJSON(r1)), string(CanonicalJSON(r2)))
+}
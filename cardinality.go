@@ -0,0 +1,96 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// OtherValue replaces the values of an attribute past its cardinality limit, see
+// Handler.WithValueCardinalityLimit.
+const OtherValue = "(other)"
+
+// cardinalityLimit tracks the distinct values seen for a key, see
+// Handler.WithValueCardinalityLimit.
+type cardinalityLimit struct {
+	key    string
+	limit  int
+	window time.Duration
+	clock  Clock
+
+	mu    sync.Mutex
+	seen  map[string]struct{}
+	start time.Time
+}
+
+// WithValueCardinalityLimit returns a new Handler that caps the number of distinct values of
+// the attributes named key, e.g. user-supplied labels, to protect log indexes from cardinality
+// explosions.
+//
+// The first limit distinct values are kept as they are, as are later occurrences of them;
+// other values are replaced by OtherValue. Attributes named key are matched at any group level,
+// and their values are compared as strings. The tracked values are forgotten every window,
+// measured with clock, or DefaultClock if clock is nil, so that the kept values can change over
+// time; a window <= 0 never forgets them. At most limit values are tracked, which bounds the
+// memory used. Handlers derived from the returned Handler share the tracked values.
+//
+// If limit <= 0, h is returned.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithValueCardinalityLimit("tenant", 100, time.Hour, nil)
+func (h *Handler) WithValueCardinalityLimit(key string, limit int, window time.Duration, clock Clock) *Handler {
+	if limit <= 0 {
+		return h
+	}
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	c := &cardinalityLimit{
+		key:    key,
+		limit:  limit,
+		window: window,
+		clock:  clock,
+		seen:   make(map[string]struct{}, limit),
+		start:  clock.Now(),
+	}
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, replaceAttrs(nil, attrs, c.replace)
+	})
+}
+
+// replace replaces the value of a by OtherValue if a is named after the limited key and its
+// value is past the limit.
+func (c *cardinalityLimit) replace(_ []string, a slog.Attr) slog.Attr {
+	if a.Key != c.key {
+		return a
+	}
+	if !c.allow(a.Value.String()) {
+		a.Value = slog.StringValue(OtherValue)
+	}
+	return a
+}
+
+// allow reports whether v is one of the tracked values, tracking it if the limit is not reached.
+func (c *cardinalityLimit) allow(v string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.window > 0 {
+		if now := c.clock.Now(); now.Sub(c.start) >= c.window {
+			clear(c.seen)
+			c.start = now
+		}
+	}
+
+	if _, ok := c.seen[v]; ok {
+		return true
+	}
+	if len(c.seen) < c.limit {
+		c.seen[v] = struct{}{}
+		return true
+	}
+	return false
+}
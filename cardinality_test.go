@@ -0,0 +1,99 @@
+package slogs
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rockcookies/go-slogs/slogstest"
+)
+
+// tenantValues returns the tenant attribute value of each record of next, at any level.
+func tenantValues(next *testHandler) []string {
+	var values []string
+	for _, r := range next.getRecords() {
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "tenant" {
+				values = append(values, a.Value.String())
+			}
+			if a.Value.Kind() == slog.KindGroup {
+				for _, member := range a.Value.Group() {
+					if member.Key == "tenant" {
+						values = append(values, member.Value.String())
+					}
+				}
+			}
+			return true
+		})
+	}
+	return values
+}
+
+func TestHandler_WithValueCardinalityLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l *slog.Logger, clock *slogstest.FakeClock)
+		want []string
+	}{
+		{
+			name: "overflow replaced",
+			log: func(l *slog.Logger, _ *slogstest.FakeClock) {
+				for _, tenant := range []string{"a", "b", "c", "a", "d", "b"} {
+					l.Info("m", "tenant", tenant)
+				}
+			},
+			want: []string{"a", "b", OtherValue, "a", OtherValue, "b"},
+		},
+		{
+			name: "grouped attributes",
+			log: func(l *slog.Logger, _ *slogstest.FakeClock) {
+				l.Info("m", "tenant", "a")
+				l.WithGroup("g").Info("m", "tenant", "b")
+				l.Info("m", slog.Group("h", "tenant", "c"))
+			},
+			want: []string{"a", "b", OtherValue},
+		},
+		{
+			name: "reset after window",
+			log: func(l *slog.Logger, clock *slogstest.FakeClock) {
+				l.Info("m", "tenant", "a")
+				l.Info("m", "tenant", "b")
+				l.Info("m", "tenant", "c")
+				clock.Advance(time.Minute)
+				l.Info("m", "tenant", "c")
+				l.Info("m", "tenant", "a")
+				l.Info("m", "tenant", "b")
+			},
+			want: []string{"a", "b", OtherValue, "c", "a", OtherValue},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			next := newTestHandler(true)
+			h := NewHandler(next).WithValueCardinalityLimit("tenant", 2, time.Minute, clock)
+
+			tt.log(slog.New(h), clock)
+
+			assert.Equal(t, tt.want, tenantValues(next))
+		})
+	}
+}
+
+func TestHandler_WithValueCardinalityLimit_SharedByDerived(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewHandler(next).WithValueCardinalityLimit("tenant", 1, 0, nil)
+
+	slog.New(h).With("x", 1).Info("m", "tenant", "a")
+	slog.New(h).WithGroup("g").Info("m", "tenant", "b")
+
+	assert.Equal(t, []string{"a", OtherValue}, tenantValues(next))
+}
+
+func TestHandler_WithValueCardinalityLimit_NoLimit(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+	assert.Same(t, h, h.WithValueCardinalityLimit("tenant", 0, 0, nil))
+}
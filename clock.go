@@ -6,14 +6,31 @@ import "time"
 // time. This clock uses the system clock for all operations.
 var DefaultClock = systemClock{}
 
-// Clock is a source of time for logged entries.
+// Clock is a source of time for logged entries and for the handlers working
+// on time windows, such as SamplingHandler, GlobalRateLimitHandler,
+// BatchHandler and CountingHandler, which all accept a Clock so that time can
+// be controlled in tests, e.g. with slogstest.FakeClock.
+//
+// Implementations must be safe for concurrent use.
 type Clock interface {
 	// Now returns the current local time.
 	Now() time.Time
 
 	// NewTicker returns *time.Ticker that holds a channel
 	// that delivers "ticks" of a clock.
+	//
+	// Handlers receive from the channel in a background goroutine and call
+	// Stop on the ticker when they are closed. Like time.NewTicker, it may
+	// panic if the duration is not positive.
 	NewTicker(time.Duration) *time.Ticker
+
+	// AfterFunc calls f in its own goroutine once d has elapsed, and returns
+	// a function that stops the call, reporting whether it stopped it before
+	// it happened, like time.AfterFunc and Timer.Stop.
+	//
+	// Handlers use it rather than a ticker to wait once, since a timer that
+	// fired or was stopped holds no resources.
+	AfterFunc(d time.Duration, f func()) (stop func() bool)
 }
 
 // systemClock implements default Clock that uses system time.
@@ -26,3 +43,7 @@ func (systemClock) Now() time.Time {
 func (systemClock) NewTicker(duration time.Duration) *time.Ticker {
 	return time.NewTicker(duration)
 }
+
+func (systemClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
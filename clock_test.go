@@ -4,10 +4,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rockcookies/go-slogs/slogstest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// newFakeClock returns a slogstest.FakeClock starting at 2024-01-01 UTC.
+func newFakeClock() *slogstest.FakeClock {
+	return slogstest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
 func TestSystemClock(t *testing.T) {
 	t.Run("Now returns current time", func(t *testing.T) {
 		clock := systemClock{}
@@ -62,6 +68,27 @@ func TestSystemClock(t *testing.T) {
 			t.Fatal("Expected to receive a tick within 5x duration")
 		}
 	})
+
+	t.Run("AfterFunc calls the function once", func(t *testing.T) {
+		clock := systemClock{}
+
+		fired := make(chan struct{})
+		stop := clock.AfterFunc(time.Millisecond, func() { close(fired) })
+
+		select {
+		case <-fired:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the function to be called within a second")
+		}
+		assert.False(t, stop(), "the timer already fired")
+	})
+
+	t.Run("AfterFunc can be stopped", func(t *testing.T) {
+		clock := systemClock{}
+
+		stop := clock.AfterFunc(time.Hour, func() { t.Error("stopped timer fired") })
+		assert.True(t, stop())
+	})
 }
 
 func TestDefaultClock(t *testing.T) {
@@ -86,6 +113,10 @@ func TestClockInterface(t *testing.T) {
 		var _ Clock = systemClock{}
 		var _ Clock = &systemClock{}
 	})
+
+	t.Run("FakeClock implements Clock interface", func(t *testing.T) {
+		var _ Clock = (*slogstest.FakeClock)(nil)
+	})
 }
 
 // TestClockUsage demonstrates how to use Clock in practice
@@ -0,0 +1,246 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// coalescer suppresses repeated error records and counts them, see Handler.WithErrorCoalescing.
+type coalescer struct {
+	window time.Duration
+	clock  Clock
+
+	mu      sync.Mutex
+	entries map[coalesceKey]*coalesceEntry
+	// nextDue is the earliest end of a window, or zero if there is none.
+	nextDue time.Time
+	// ready holds summaries of windows that ended and are waiting to be emitted.
+	ready  []coalesceSummary
+	closed bool
+
+	stop    chan struct{}
+	stopped chan struct{}
+	errMu   sync.Mutex
+	lastErr error
+}
+
+// coalesceKey identifies the error records that are coalesced together.
+type coalesceKey struct {
+	message string
+	err     string
+}
+
+// coalesceEntry tracks the current window of a key.
+type coalesceEntry struct {
+	// handler handled the first record of the window; the summary is passed on through it.
+	handler    *Handler
+	level      slog.Level
+	errKey     string
+	end        time.Time
+	suppressed int
+}
+
+// coalesceSummary is a summary record and the handler it is passed on through.
+type coalesceSummary struct {
+	handler *Handler
+	record  slog.Record
+}
+
+// WithErrorCoalescing returns a new Handler that coalesces repeated error records.
+//
+// Records at slog.LevelError or above with the same message and error (the first attribute
+// of the record holding an error value) are grouped in windows of the given duration, measured
+// with clock, or DefaultClock if clock is nil. The first record of a window is emitted
+// immediately; the following ones are dropped and counted. Once the window is over, a summary
+// record with the original level and message is passed to the next handler, with the error
+// message, the number of occurrences in the window and the window duration as attributes:
+//
+//	level=ERROR msg="db query failed" error="connection refused" occurrences=240 window=1m0s
+//
+// Summaries bypass the HandleFunc pipeline but are passed to the next handler by the handler
+// that handled the first record of their window, so that the options set on it, such as
+// WithWriteTimeout, apply to them too. They are emitted by a background goroutine driven by a
+// clock ticker firing every window, so a summary is emitted at most one window after its
+// window ended, and before the next record handled after that. Close emits the summaries of the
+// windows still open and stops the goroutine; it must be called once logging is done so the
+// last summaries are not lost. Windows in which the error occurred only once produce no
+// summary, and records without an error attribute are never coalesced. Handlers derived from
+// the returned Handler share its windows. A window <= 0 defaults to one minute.
+//
+// If h already coalesces errors, the background goroutine of its coalescing is stopped, after
+// it emitted the summaries of the windows still open, as by Close.
+func (h *Handler) WithErrorCoalescing(window time.Duration, clock Clock) *Handler {
+	if clock == nil {
+		clock = DefaultClock
+	}
+	if window <= 0 {
+		window = time.Minute
+	}
+	if h.coalescer != nil {
+		h.coalescer.shutdown()
+	}
+
+	h2 := h.Clone()
+	c := &coalescer{
+		window:  window,
+		clock:   clock,
+		entries: make(map[coalesceKey]*coalesceEntry),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	h2.coalescer = c
+	go c.run(clock.NewTicker(window))
+	return h2
+}
+
+func (c *coalescer) run(ticker *time.Ticker) {
+	defer close(c.stopped)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.emit(c.summaries(false))
+		case <-c.stop:
+			c.emit(c.summaries(true))
+			return
+		}
+	}
+}
+
+// emit passes summaries to the next handler, keeping the last error for close.
+func (c *coalescer) emit(summaries []coalesceSummary) {
+	for _, s := range summaries {
+		if err := s.handler.forward(context.Background(), s.record); err != nil {
+			c.errMu.Lock()
+			c.lastErr = err
+			c.errMu.Unlock()
+		}
+	}
+}
+
+// shutdown tells the background goroutine to emit the summaries of all windows and stop,
+// without waiting for it.
+func (c *coalescer) shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.stop)
+	}
+}
+
+// close emits the summaries of all windows and stops the background goroutine.
+func (c *coalescer) close(ctx context.Context) error {
+	c.shutdown()
+
+	select {
+	case <-c.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	return c.lastErr
+}
+
+// observe records an error record handled by h and reports whether it should be emitted.
+func (c *coalescer) observe(h *Handler, level slog.Level, message string, attrs []slog.Attr) bool {
+	errKey, errMsg, ok := findError(attrs)
+	if !ok {
+		return true
+	}
+	key := coalesceKey{message: message, err: errMsg}
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !now.Before(e.end) {
+		if ok && e.suppressed > 0 {
+			c.ready = append(c.ready, c.summary(now, key, e))
+		}
+		e = &coalesceEntry{handler: h, level: level, errKey: errKey, end: now.Add(c.window)}
+		c.entries[key] = e
+		if c.nextDue.IsZero() || e.end.Before(c.nextDue) {
+			c.nextDue = e.end
+		}
+		return true
+	}
+
+	e.suppressed++
+	return false
+}
+
+// summaries removes the windows that ended, or all of them if all is set, and returns the
+// summaries of those with suppressed records.
+func (c *coalescer) summaries(all bool) []coalesceSummary {
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	records := c.ready
+	c.ready = nil
+	if !all && (c.nextDue.IsZero() || now.Before(c.nextDue)) {
+		return records
+	}
+
+	c.nextDue = time.Time{}
+	for key, e := range c.entries {
+		if !all && now.Before(e.end) {
+			if c.nextDue.IsZero() || e.end.Before(c.nextDue) {
+				c.nextDue = e.end
+			}
+			continue
+		}
+
+		delete(c.entries, key)
+		if e.suppressed == 0 {
+			continue
+		}
+
+		records = append(records, c.summary(now, key, e))
+	}
+	return records
+}
+
+// summary builds the summary record of a window.
+func (c *coalescer) summary(now time.Time, key coalesceKey, e *coalesceEntry) coalesceSummary {
+	r := slog.NewRecord(now, e.level, key.message, 0)
+	r.AddAttrs(
+		slog.String(e.errKey, key.err),
+		slog.Int("occurrences", e.suppressed+1),
+		slog.Duration("window", c.window),
+	)
+	return coalesceSummary{handler: e.handler, record: r}
+}
+
+// findError returns the key and message of the first attribute holding an error.
+func findError(attrs []slog.Attr) (key, msg string, ok bool) {
+	for _, a := range attrs {
+		if a.Value.Kind() != slog.KindAny {
+			continue
+		}
+		if err, isErr := a.Value.Any().(error); isErr {
+			return a.Key, err.Error(), true
+		}
+	}
+	return "", "", false
+}
+
+// emitSummaries passes the summaries of ended coalescing windows to the next handler.
+func (h *Handler) emitSummaries() error {
+	var errs []error
+	for _, s := range h.coalescer.summaries(false) {
+		if err := s.handler.forward(context.Background(), s.record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
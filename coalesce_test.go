@@ -0,0 +1,198 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rockcookies/go-slogs/slogstest"
+)
+
+// silentClock is a FakeClock whose tickers never tick, so that coalescing summaries are only
+// emitted by Handle.
+type silentClock struct {
+	*slogstest.FakeClock
+}
+
+func (silentClock) NewTicker(time.Duration) *time.Ticker {
+	return &time.Ticker{C: make(chan time.Time)}
+}
+
+func TestHandler_WithErrorCoalescing(t *testing.T) {
+	var buf bytes.Buffer
+	clock := silentClock{newFakeClock()}
+	h := NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+		WithErrorCoalescing(time.Minute, clock)
+	logger := New(h)
+
+	errRefused := errors.New("connection refused")
+	for i := 0; i < 240; i++ {
+		logger.Error("query failed", "error", errRefused)
+	}
+	logger.Error("query failed", "error", errors.New("timeout"))
+	logger.Warn("slow query", "error", errRefused)
+	logger.Error("no error attr")
+	logger.Error("no error attr")
+
+	assert.Equal(t, ""+
+		"level=ERROR msg=\"query failed\" error=\"connection refused\"\n"+
+		"level=ERROR msg=\"query failed\" error=timeout\n"+
+		"level=WARN msg=\"slow query\" error=\"connection refused\"\n"+
+		"level=ERROR msg=\"no error attr\"\n"+
+		"level=ERROR msg=\"no error attr\"\n",
+		buf.String())
+
+	buf.Reset()
+	clock.Advance(time.Minute)
+	logger.Info("next")
+	assert.Equal(t, ""+
+		"level=ERROR msg=\"query failed\" error=\"connection refused\" occurrences=240 window=1m0s\n"+
+		"level=INFO msg=next\n",
+		buf.String(), "only windows with suppressed records are summarized")
+
+	buf.Reset()
+	logger.Error("query failed", "error", errRefused)
+	assert.Equal(t, "level=ERROR msg=\"query failed\" error=\"connection refused\"\n", buf.String(), "a new window starts")
+}
+
+func TestHandler_WithErrorCoalescing_SummaryOnNextOccurrence(t *testing.T) {
+	next := newTestHandler(true)
+	clock := silentClock{newFakeClock()}
+	logger := New(NewHandler(next).WithErrorCoalescing(time.Second, clock))
+
+	errBoom := errors.New("boom")
+	logger.Error("failed", "err", errBoom)
+	logger.Error("failed", "err", errBoom)
+	logger.Error("failed", "err", errBoom)
+
+	clock.Advance(time.Second)
+	logger.Error("failed", "err", errBoom)
+
+	records := next.getRecords()
+	assert.Equal(t, []string{"failed", "failed", "failed"}, messages(records))
+	assert.True(t, recordHasAttr(records[1], "occurrences", "3"))
+	assert.True(t, recordHasAttr(records[1], "err", "boom"))
+	assert.False(t, recordHasAttr(records[2], "occurrences", "3"))
+}
+
+func TestHandler_WithErrorCoalescing_Shared(t *testing.T) {
+	next := newTestHandler(true)
+	logger := New(NewHandler(next).WithErrorCoalescing(time.Minute, newFakeClock()))
+
+	errBoom := errors.New("boom")
+	logger.Error("failed", "error", errBoom)
+	logger.With("k", "v").Named("child").Error("failed", "error", errBoom)
+
+	assert.Equal(t, 1, next.recordCount(), "derived loggers share the windows")
+}
+
+func TestHandler_WithErrorCoalescing_Periodic(t *testing.T) {
+	next := newTestHandler(true)
+	clock := slogstest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	h := NewHandler(next).WithErrorCoalescing(time.Minute, clock)
+	logger := New(h)
+
+	errBoom := errors.New("boom")
+	for i := 0; i < 3; i++ {
+		logger.Error("failed", "error", errBoom)
+	}
+
+	clock.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return next.recordCount() == 2 }, time.Second, time.Millisecond,
+		"the summary is emitted without another record being handled")
+	assert.True(t, recordHasAttr(next.getRecords()[1], "occurrences", "3"))
+
+	require.NoError(t, h.Close(context.Background()))
+	assert.Equal(t, 2, next.recordCount())
+}
+
+func TestHandler_WithErrorCoalescing_Close(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewHandler(next).WithErrorCoalescing(time.Minute, newFakeClock())
+	logger := New(h)
+
+	errBoom := errors.New("boom")
+	logger.Error("failed", "error", errBoom)
+	logger.Error("failed", "error", errBoom)
+	assert.Equal(t, 1, next.recordCount())
+
+	require.NoError(t, h.Close(context.Background()))
+	records := next.getRecords()
+	require.Len(t, records, 2, "Close emits the summary of the open window")
+	assert.True(t, recordHasAttr(records[1], "occurrences", "2"))
+	require.NoError(t, h.Close(context.Background()))
+}
+
+func TestHandler_WithErrorCoalescing_SummaryError(t *testing.T) {
+	next := newTestHandler(true)
+	errWrite := errors.New("write failed")
+	next.err = errWrite
+	clock := silentClock{newFakeClock()}
+	h := NewHandler(next).WithErrorCoalescing(time.Second, clock)
+
+	errBoom := errors.New("boom")
+	r := slog.NewRecord(time.Now(), slog.LevelError, "failed", 0)
+	r.AddAttrs(slog.Any("error", errBoom))
+	assert.ErrorIs(t, h.Handle(context.Background(), r), errWrite)
+	assert.NoError(t, h.Handle(context.Background(), r), "suppressed records are not forwarded")
+
+	clock.Advance(time.Second)
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "next", 0))
+	assert.ErrorIs(t, err, errWrite)
+	assert.Equal(t, []string{"failed", "failed", "next"}, messages(next.getRecords()),
+		"the record is handled even though its summary failed")
+}
+
+func TestHandler_WithErrorCoalescing_DerivedHandler(t *testing.T) {
+	var deadlines []bool
+	next := deadlineCapturingHandler{testHandler: newTestHandler(true), deadlines: &deadlines}
+	h := NewHandler(next).WithErrorCoalescing(time.Minute, newFakeClock()).WithWriteTimeout(time.Hour)
+	logger := New(h)
+
+	errBoom := errors.New("boom")
+	logger.Error("failed", "error", errBoom)
+	logger.Error("failed", "error", errBoom)
+	require.NoError(t, h.Close(context.Background()))
+
+	assert.Equal(t, []string{"failed", "failed"}, messages(next.getRecords()))
+	assert.Equal(t, []bool{true, true}, deadlines, "the summary goes through the write timeout set afterwards")
+}
+
+// deadlineCapturingHandler records whether the context of each record has a deadline.
+type deadlineCapturingHandler struct {
+	*testHandler
+	deadlines *[]bool
+}
+
+func (h deadlineCapturingHandler) Handle(ctx context.Context, r slog.Record) error {
+	_, ok := ctx.Deadline()
+	*h.deadlines = append(*h.deadlines, ok)
+	return h.testHandler.Handle(ctx, r)
+}
+
+func TestHandler_WithErrorCoalescing_Replaced(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewHandler(next).WithErrorCoalescing(time.Minute, newFakeClock())
+	first := h.coalescer
+
+	errBoom := errors.New("boom")
+	logger := New(h)
+	logger.Error("failed", "error", errBoom)
+	logger.Error("failed", "error", errBoom)
+
+	h2 := h.WithErrorCoalescing(time.Second, newFakeClock())
+	require.NotSame(t, first, h2.coalescer)
+	select {
+	case <-first.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the replaced coalescer was not stopped")
+	}
+	assert.Equal(t, []string{"failed", "failed"}, messages(next.getRecords()), "the summary of the replaced coalescer is emitted")
+	require.NoError(t, h2.Close(context.Background()))
+}
@@ -0,0 +1,64 @@
+package slogs
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ComponentKey is the key of the attribute added by Handler.WithAutoComponent.
+const ComponentKey = "component"
+
+// WithAutoComponent returns a new Handler that, if enabled is true, adds the import path of the
+// package of the logging function as a top-level "component" attribute, e.g.
+// "github.com/acme/app/internal/db", so that records can be attributed without naming loggers.
+//
+// The package is resolved from the caller's program counter, so the attribute is only added to
+// records logged with caller information, see WithCaller; records without it are left
+// unchanged. Functions inlined into their caller are attributed to their own package. The
+// attribute is added before the filters run, and resolutions are cached per program counter.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(slog.NewJSONHandler(os.Stdout, nil)).WithAutoComponent(true)
+//	logger := slogs.New(handler, slogs.WithCaller(true))
+//	logger.Info("connected") // {"msg":"connected","component":"github.com/acme/app/internal/db"}
+func (h *Handler) WithAutoComponent(enabled bool) *Handler {
+	h2 := h.Clone()
+	if enabled {
+		h2.components = &componentCache{}
+	} else {
+		h2.components = nil
+	}
+	return h2
+}
+
+// componentCache maps program counters to the import path of their package.
+type componentCache struct {
+	byPC sync.Map // uintptr -> string
+}
+
+// component returns the import path of the package of the function at pc, or an empty string
+// if it is unknown.
+func (c *componentCache) component(pc uintptr) string {
+	if v, ok := c.byPC.Load(pc); ok {
+		return v.(string)
+	}
+
+	fs := runtime.CallersFrames([]uintptr{pc})
+	f, _ := fs.Next()
+	pkg := packagePath(f.Function)
+	c.byPC.Store(pc, pkg)
+	return pkg
+}
+
+// packagePath returns the import path of the package of the fully qualified function name fn,
+// e.g. "github.com/acme/db" for "github.com/acme/db.(*Store).Get".
+func packagePath(fn string) string {
+	lastSlash := strings.LastIndexByte(fn, '/')
+	dot := strings.IndexByte(fn[lastSlash+1:], '.')
+	if dot < 0 {
+		return ""
+	}
+	return fn[:lastSlash+1+dot]
+}
@@ -0,0 +1,76 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackagePath(t *testing.T) {
+	tests := []struct {
+		fn   string
+		want string
+	}{
+		{"github.com/acme/app/internal/db.(*Store).Get", "github.com/acme/app/internal/db"},
+		{"github.com/acme/app/internal/db.Open.func1", "github.com/acme/app/internal/db"},
+		{"github.com/acme/app.v2/api.Serve", "github.com/acme/app.v2/api"},
+		{"main.main", "main"},
+		{"net/http.(*Server).Serve", "net/http"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fn, func(t *testing.T) {
+			assert.Equal(t, tt.want, packagePath(tt.fn))
+		})
+	}
+}
+
+func TestHandler_WithAutoComponent(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		caller  bool
+		want    string
+	}{
+		{name: "enabled", enabled: true, caller: true, want: `"component":"github.com/rockcookies/go-slogs"`},
+		{name: "without caller", enabled: true, caller: false},
+		{name: "disabled", enabled: false, caller: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, nil)).WithAutoComponent(tt.enabled)
+			logger := New(h, WithCaller(tt.caller))
+
+			logger.Info("m")
+			logger.Info("m")
+
+			if tt.want != "" {
+				assert.Equal(t, 2, bytes.Count(buf.Bytes(), []byte(tt.want)))
+			} else {
+				assert.NotContains(t, buf.String(), ComponentKey)
+			}
+		})
+	}
+}
+
+func TestHandler_WithAutoComponent_Filter(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewHandler(next).WithAutoComponent(true).WithFilter(func(_ context.Context, _ slog.Level, _ string, attrs []slog.Attr) bool {
+		for _, a := range attrs {
+			if a.Key == ComponentKey {
+				return a.Value.String() != "github.com/rockcookies/go-slogs"
+			}
+		}
+		return true
+	})
+
+	New(h, WithCaller(true)).Info("m")
+
+	assert.Zero(t, next.recordCount(), "filters see the component")
+}
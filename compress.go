@@ -0,0 +1,128 @@
+package slogs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// Codec compresses batches of encoded records, see CompressFlush.
+//
+// Codecs other than gzip, such as zstd, can be plugged in by implementing NewWriter with the
+// corresponding package.
+type Codec interface {
+	// NewWriter returns a writer compressing what is written to it into w. Closing it must
+	// write the end of the compressed frame to w without closing w.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+}
+
+// CodecFunc is an adapter to use an ordinary function as a Codec.
+type CodecFunc func(w io.Writer) (io.WriteCloser, error)
+
+// NewWriter calls f(w).
+func (f CodecFunc) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return f(w)
+}
+
+// gzipCodec is the Codec returned by NewGzipCodec. It reuses its writers, which are
+// expensive to allocate.
+type gzipCodec struct {
+	level int
+	pool  sync.Pool
+}
+
+// NewGzipCodec returns a Codec compressing each batch into a gzip member with the given
+// compression level, e.g. gzip.DefaultCompression. Concatenated members form a valid gzip
+// stream, so batches can be written one after another to the same stream.
+//
+// It returns an error if level is invalid.
+func NewGzipCodec(level int) (Codec, error) {
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		return nil, err
+	}
+	return &gzipCodec{level: level}, nil
+}
+
+// NewWriter returns a gzip writer writing a member to w.
+func (c *gzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	if zw, ok := c.pool.Get().(*gzip.Writer); ok {
+		zw.Reset(w)
+		return &gzipWriter{Writer: zw, codec: c}, nil
+	}
+
+	zw, err := gzip.NewWriterLevel(w, c.level)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipWriter{Writer: zw, codec: c}, nil
+}
+
+// gzipWriter returns its gzip.Writer to the codec's pool when closed.
+type gzipWriter struct {
+	*gzip.Writer
+	codec *gzipCodec
+}
+
+// Close writes the end of the gzip member and releases the writer.
+func (w *gzipWriter) Close() error {
+	err := w.Writer.Close()
+	w.codec.pool.Put(w.Writer)
+	return err
+}
+
+// CompressFlush returns a FlushFunc that compresses each batch with codec into a single frame
+// and passes the frame to flush.
+//
+// It is meant to be used with NewBatchHandler, so that batches are compressed as a whole
+// rather than record by record. The frame is fully built before flush is called: if the codec
+// fails, flush is not called and the error is returned, so no partial frame is ever flushed.
+//
+// Example:
+//
+//	codec, err := slogs.NewGzipCodec(gzip.BestSpeed)
+//	if err != nil {
+//		return err
+//	}
+//	batch := slogs.NewBatchHandler(encoder, slogs.CompressFlush(codec, send), nil)
+func CompressFlush(codec Codec, flush FlushFunc) FlushFunc {
+	if codec == nil || flush == nil {
+		panic("slogs: codec and flush function cannot be nil")
+	}
+
+	return func(batch []byte) error {
+		var frame bytes.Buffer
+		zw, err := codec.NewWriter(&frame)
+		if err != nil {
+			return err
+		}
+		if _, err := zw.Write(batch); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		return flush(frame.Bytes())
+	}
+}
+
+// NewCompressingHandler creates a BatchHandler whose records are encoded by the handler
+// returned by encoder, compressed a batch at a time with codec and written to w, e.g. a
+// network stream. opts may be nil.
+//
+// Each batch is written to w with a single Write call. Close flushes the last batch. A failed
+// Write may leave a partial frame in w; the batch is then lost and the error is returned by
+// Close, so w should be reset, e.g. by reconnecting, before writing further frames.
+//
+// Panics if encoder, w or codec is nil.
+func NewCompressingHandler(encoder func(w io.Writer) slog.Handler, w io.Writer, codec Codec, opts *BatchOptions) *BatchHandler {
+	if w == nil {
+		panic("slogs: writer cannot be nil")
+	}
+
+	return NewBatchHandler(encoder, CompressFlush(codec, func(frame []byte) error {
+		_, err := w.Write(frame)
+		return err
+	}), opts)
+}
@@ -0,0 +1,133 @@
+package slogs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gunzip(t *testing.T, data []byte) string {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	out, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestNewGzipCodec(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   int
+		wantErr bool
+	}{
+		{name: "default", level: gzip.DefaultCompression},
+		{name: "best speed", level: gzip.BestSpeed},
+		{name: "invalid", level: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec, err := NewGzipCodec(tt.level)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// Twice, to reuse the pooled writer.
+			for i := 0; i < 2; i++ {
+				var buf bytes.Buffer
+				zw, err := codec.NewWriter(&buf)
+				require.NoError(t, err)
+				_, err = zw.Write([]byte("hello"))
+				require.NoError(t, err)
+				require.NoError(t, zw.Close())
+
+				assert.Equal(t, "hello", gunzip(t, buf.Bytes()))
+			}
+		})
+	}
+}
+
+func TestCompressFlush(t *testing.T) {
+	codec, err := NewGzipCodec(gzip.DefaultCompression)
+	require.NoError(t, err)
+	rec := &batchRecorder{}
+
+	require.NoError(t, CompressFlush(codec, rec.flush)([]byte("msg=a\nmsg=b\n")))
+
+	batches := rec.get()
+	require.Len(t, batches, 1)
+	assert.Equal(t, "msg=a\nmsg=b\n", gunzip(t, []byte(batches[0])))
+}
+
+func TestCompressFlush_CodecError(t *testing.T) {
+	errCodec := errors.New("codec failed")
+	tests := []struct {
+		name  string
+		codec Codec
+	}{
+		{
+			name: "new writer",
+			codec: CodecFunc(func(io.Writer) (io.WriteCloser, error) {
+				return nil, errCodec
+			}),
+		},
+		{
+			name: "close",
+			codec: CodecFunc(func(w io.Writer) (io.WriteCloser, error) {
+				return failingCloser{Writer: w, err: errCodec}, nil
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &batchRecorder{}
+			err := CompressFlush(tt.codec, rec.flush)([]byte("msg=a\n"))
+
+			assert.ErrorIs(t, err, errCodec)
+			assert.Empty(t, rec.get(), "no partial frame is flushed")
+		})
+	}
+}
+
+// failingCloser writes through but fails to close the frame.
+type failingCloser struct {
+	io.Writer
+	err error
+}
+
+func (c failingCloser) Close() error {
+	return c.err
+}
+
+func TestCompressFlush_Nil(t *testing.T) {
+	codec, err := NewGzipCodec(gzip.DefaultCompression)
+	require.NoError(t, err)
+
+	assert.Panics(t, func() { CompressFlush(nil, func([]byte) error { return nil }) })
+	assert.Panics(t, func() { CompressFlush(codec, nil) })
+}
+
+func TestNewCompressingHandler(t *testing.T) {
+	codec, err := NewGzipCodec(gzip.DefaultCompression)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	h := NewCompressingHandler(msgOnlyEncoder, &buf, codec, &BatchOptions{MaxBytes: 12})
+
+	handleMsg(t, h, "a")
+	handleMsg(t, h, "b")
+	handleMsg(t, h, "c")
+	require.NoError(t, h.Close(context.Background()))
+
+	// Two gzip members, read back as a single stream.
+	assert.Equal(t, "msg=a\nmsg=b\nmsg=c\n", gunzip(t, buf.Bytes()))
+}
@@ -98,3 +98,33 @@ func ExtractAppended(ctx context.Context) []slog.Attr {
 	}
 	return nil
 }
+
+// loggerNameKey is the context key for the logger name passed to downstream handlers.
+type loggerNameKey struct{}
+
+// LoggerName returns the logger name chain (e.g. "service.database") of the record being handled.
+//
+// Handler stores the name in the context it passes to the Handle and Enabled methods of the
+// next handler, so handlers wrapped by a named Handler can use it, for example to select an
+// OpenTelemetry instrumentation scope. Returns an empty string if the record was not logged
+// through a named Handler.
+func LoggerName(ctx context.Context) string {
+	if v, ok := ctx.Value(loggerNameKey{}).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// withLoggerName returns ctx carrying name for LoggerName. It returns ctx itself if name is
+// empty or ctx already carries it, so that no context is allocated.
+func withLoggerName(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	} else if LoggerName(ctx) == name {
+		return ctx
+	}
+	return context.WithValue(ctx, loggerNameKey{}, name)
+}
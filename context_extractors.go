@@ -0,0 +1,90 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// ContextExtractor returns the attributes to log from the context of a record, or none.
+type ContextExtractor func(ctx context.Context) []slog.Attr
+
+// ContextExtractors is an ordered list of ContextExtractors, to which independent modules
+// (authentication, tracing, tenancy, ...) can each register their own extractor without
+// coordinating with each other.
+//
+// It is safe for concurrent use: extractors can be registered while records are logged, and
+// apply to the records handled afterwards. The zero value is an empty list ready to use.
+//
+// Example:
+//
+//	extractors := slogs.NewContextExtractors()
+//	auth.RegisterLogAttrs(extractors)   // calls extractors.Register
+//	tenancy.RegisterLogAttrs(extractors)
+//	logger := slogs.New(handler, slogs.WithContextExtractors(extractors))
+type ContextExtractors struct {
+	mu  sync.RWMutex
+	fns []ContextExtractor
+}
+
+// NewContextExtractors creates a ContextExtractors holding fns, in order. nil functions are ignored.
+func NewContextExtractors(fns ...ContextExtractor) *ContextExtractors {
+	e := &ContextExtractors{}
+	for _, fn := range fns {
+		e.Register(fn)
+	}
+	return e
+}
+
+// Register appends fn to the list, so that its attributes come after those of the extractors
+// registered before it. A nil fn is ignored.
+func (e *ContextExtractors) Register(fn ContextExtractor) {
+	if fn == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	// The slice is replaced rather than appended to in place, so extract can iterate over its
+	// snapshot without holding the lock.
+	e.fns = append(slices.Clip(e.fns), fn)
+}
+
+// extract returns the attributes of all extractors for ctx, in registration order.
+func (e *ContextExtractors) extract(ctx context.Context) []slog.Attr {
+	e.mu.RLock()
+	fns := e.fns
+	e.mu.RUnlock()
+
+	var attrs []slog.Attr
+	for _, fn := range fns {
+		attrs = append(attrs, fn(ctx)...)
+	}
+	return attrs
+}
+
+// WithContextExtractors returns a new Handler that adds the attributes returned by the
+// extractors of e for the context of each record, at the root level and ahead of the other
+// attributes, in the order the extractors were registered.
+//
+// The extractors run after the HandleFunc, so later middlewares such as WithRenameKeys see
+// their attributes. e is consulted on every Handle, so extractors registered later apply to
+// the handler already created. Calling WithContextExtractors again replaces e rather than
+// adding to it, which lets a logger override the list of its parent; a nil e removes it.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithContextExtractors(slogs.NewContextExtractors(
+//		func(ctx context.Context) []slog.Attr {
+//			if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+//				return []slog.Attr{slog.String("request_id", id)}
+//			}
+//			return nil
+//		},
+//	))
+func (h *Handler) WithContextExtractors(e *ContextExtractors) *Handler {
+	h2 := h.Clone()
+	h2.extractors = e
+	return h2
+}
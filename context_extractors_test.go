@@ -0,0 +1,103 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// staticExtractor returns a ContextExtractor always returning a single attribute.
+func staticExtractor(key, value string) ContextExtractor {
+	return func(context.Context) []slog.Attr {
+		return []slog.Attr{slog.String(key, value)}
+	}
+}
+
+func TestHandler_WithContextExtractors(t *testing.T) {
+	type tenantKey struct{}
+	tenant := func(ctx context.Context) []slog.Attr {
+		if v, ok := ctx.Value(tenantKey{}).(string); ok {
+			return []slog.Attr{slog.String("tenant", v)}
+		}
+		return nil
+	}
+	tenantCtx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	tests := []struct {
+		name       string
+		extractors []ContextExtractor
+		ctx        context.Context
+		expected   string
+	}{
+		{
+			name:       "registration order",
+			extractors: []ContextExtractor{staticExtractor("b", "1"), staticExtractor("a", "2")},
+			ctx:        context.Background(),
+			expected:   "level=INFO msg=m b=1 a=2 k=v\n",
+		},
+		{
+			name:       "context value",
+			extractors: []ContextExtractor{tenant, staticExtractor("a", "1")},
+			ctx:        tenantCtx,
+			expected:   "level=INFO msg=m tenant=acme a=1 k=v\n",
+		},
+		{
+			name:       "context value missing",
+			extractors: []ContextExtractor{tenant},
+			ctx:        context.Background(),
+			expected:   "level=INFO msg=m k=v\n",
+		},
+		{
+			name:       "nil extractors ignored",
+			extractors: []ContextExtractor{nil, staticExtractor("a", "1"), nil},
+			ctx:        context.Background(),
+			expected:   "level=INFO msg=m a=1 k=v\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+				WithContextExtractors(NewContextExtractors(tt.extractors...))
+
+			slog.New(h).InfoContext(tt.ctx, "m", "k", "v")
+
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestHandler_WithContextExtractors_LateRegistration(t *testing.T) {
+	buf := &bytes.Buffer{}
+	extractors := NewContextExtractors(staticExtractor("auth", "user"))
+	logger := slog.New(NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+		WithContextExtractors(extractors).
+		WithRenameKeys(map[string]string{"trace": "trace_id"}))
+
+	extractors.Register(staticExtractor("trace", "t-1"))
+	logger.Info("m")
+
+	assert.Equal(t, "level=INFO msg=m auth=user trace_id=t-1\n", buf.String())
+}
+
+func TestWithContextExtractors_PerLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+		WithContextExtractors(NewContextExtractors(staticExtractor("shared", "1")))
+
+	parent := New(h)
+	child := parent.WithOptions(WithContextExtractors(NewContextExtractors(staticExtractor("own", "2"))))
+	muted := parent.WithOptions(WithContextExtractors(nil))
+
+	parent.Info("parent")
+	child.Info("child")
+	muted.Info("muted")
+
+	assert.Equal(t, "level=INFO msg=parent shared=1\n"+
+		"level=INFO msg=child own=2\n"+
+		"level=INFO msg=muted\n", buf.String())
+}
@@ -0,0 +1,92 @@
+package slogs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// contextKey pairs a context key with the attribute name it is logged under.
+type contextKey struct {
+	key  any
+	name string
+}
+
+// WithContextKeys returns a new Handler that copies well-known context values into every record.
+//
+// keys maps a context key to the attribute name used for its value. On each Handle, every key
+// present in the context (ctx.Value(key) != nil) is added at the root level of the record,
+// ahead of any attributes added via Prepend. Attributes are emitted in order of their names so
+// output is stable regardless of map iteration order.
+//
+// Values implementing fmt.Stringer are logged using their String method, unless slog already
+// knows how to render them (strings, numbers, times, errors, LogValuers, ...).
+//
+// This is a declarative alternative to a custom HandleFunc for the common case of
+// "log this context value if it is there".
+//
+// Example:
+//
+//	type requestIDKey struct{}
+//	handler := slogs.NewHandler(next).WithContextKeys(map[any]string{
+//		requestIDKey{}: "request_id",
+//	})
+func (h *Handler) WithContextKeys(keys map[any]string) *Handler {
+	if len(keys) == 0 {
+		return h
+	}
+
+	ordered := make([]contextKey, 0, len(keys))
+	for k, name := range keys {
+		ordered = append(ordered, contextKey{key: k, name: name})
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].name < ordered[j].name
+	})
+
+	return h.use(func(ctx context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		var found []slog.Attr
+		for _, ck := range ordered {
+			v := ctx.Value(ck.key)
+			if v == nil {
+				continue
+			}
+			found = append(found, slog.Attr{Key: ck.name, Value: contextValue(v)})
+		}
+
+		if len(found) == 0 {
+			return rm, attrs
+		}
+		return rm, append(found, attrs...)
+	})
+}
+
+// contextValue converts a raw context value into a slog.Value.
+func contextValue(v any) slog.Value {
+	sv := slog.AnyValue(v)
+	if sv.Kind() != slog.KindAny {
+		return sv
+	}
+
+	// Errors are rendered by the sinks already; only fall back to String for other types.
+	if _, ok := v.(error); ok {
+		return sv
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return stringerValue(s)
+	}
+	return sv
+}
+
+// stringerValue calls s.String, recovering from panics such as those caused by nil receivers
+// so that a misbehaving context value cannot crash the logging call.
+func stringerValue(s fmt.Stringer) (v slog.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			v = slog.StringValue(fmt.Sprintf("!PANIC: %v", r))
+		}
+	}()
+	return slog.StringValue(s.String())
+}
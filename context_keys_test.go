@@ -0,0 +1,125 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKeyRequestID struct{}
+
+type ctxKeyTenant struct{}
+
+type ctxKeyUser struct{}
+
+type testUser struct {
+	name string
+}
+
+func (u *testUser) String() string {
+	return "user:" + u.name
+}
+
+func TestHandler_WithContextKeys(t *testing.T) {
+	keys := map[any]string{
+		ctxKeyRequestID{}: "request_id",
+		ctxKeyTenant{}:    "tenant",
+		ctxKeyUser{}:      "user",
+	}
+
+	tests := []struct {
+		name    string
+		ctx     func() context.Context
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "copies present keys",
+			ctx: func() context.Context {
+				return context.WithValue(context.Background(), ctxKeyRequestID{}, "abc-123")
+			},
+			want:    []string{`"request_id":"abc-123"`},
+			notWant: []string{"tenant", "user"},
+		},
+		{
+			name: "orders attributes by name",
+			ctx: func() context.Context {
+				ctx := context.WithValue(context.Background(), ctxKeyTenant{}, "acme")
+				return context.WithValue(ctx, ctxKeyRequestID{}, "abc-123")
+			},
+			want: []string{`"request_id":"abc-123","tenant":"acme","k":"v"`},
+		},
+		{
+			name: "uses Stringer for unknown types",
+			ctx: func() context.Context {
+				return context.WithValue(context.Background(), ctxKeyUser{}, &testUser{name: "alice"})
+			},
+			want: []string{`"user":"user:alice"`},
+		},
+		{
+			name: "recovers from panicking Stringer",
+			ctx: func() context.Context {
+				return context.WithValue(context.Background(), ctxKeyUser{}, (*testUser)(nil))
+			},
+			want: []string{`"user":"!PANIC:`},
+		},
+		{
+			name: "keeps native kinds",
+			ctx: func() context.Context {
+				return context.WithValue(context.Background(), ctxKeyTenant{}, 42)
+			},
+			want: []string{`"tenant":42`},
+		},
+		{
+			name: "keeps errors as errors",
+			ctx: func() context.Context {
+				return context.WithValue(context.Background(), ctxKeyTenant{}, errors.New("boom"))
+			},
+			want: []string{`"tenant":"boom"`},
+		},
+		{
+			name:    "no keys present",
+			ctx:     context.Background,
+			notWant: []string{"request_id", "tenant", "user"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, nil)).WithContextKeys(keys)
+			logger := New(h)
+
+			logger.InfoContext(tt.ctx(), "test", "k", "v")
+
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, buf.String(), notWant)
+			}
+		})
+	}
+}
+
+func TestHandler_WithContextKeys_AfterPrepend(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(buf, nil)).
+		WithContextKeys(map[any]string{ctxKeyRequestID{}: "request_id"})
+	logger := New(h).WithGroup("g")
+
+	ctx := context.WithValue(context.Background(), ctxKeyRequestID{}, "abc")
+	ctx = Prepend(ctx, "p", 1)
+	logger.InfoContext(ctx, "test", "k", "v")
+
+	assert.Contains(t, buf.String(), `"request_id":"abc","p":1,"g":{"k":"v"}`)
+}
+
+func TestHandler_WithContextKeys_Empty(t *testing.T) {
+	h := NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, h, h.WithContextKeys(nil))
+}
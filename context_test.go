@@ -5,6 +5,7 @@ import (
 	"context"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -82,3 +83,76 @@ func TestAppend_Multiple(t *testing.T) {
 
 	assert.Len(t, attrs, 2)
 }
+
+// nameCapturingHandler records the logger name found in the context of each Enabled and Handle call.
+type nameCapturingHandler struct {
+	slog.Handler
+	names *[]string
+}
+
+func (h nameCapturingHandler) Enabled(ctx context.Context, _ slog.Level) bool {
+	*h.names = append(*h.names, LoggerName(ctx))
+	return true
+}
+
+func (h nameCapturingHandler) Handle(ctx context.Context, r slog.Record) error {
+	*h.names = append(*h.names, LoggerName(ctx))
+	return nil
+}
+
+func TestLoggerName(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  func(next slog.Handler) *Handler
+		ctx      context.Context
+		expected string
+		// enabled is the name seen by Enabled, if it differs from expected.
+		enabled string
+	}{
+		{
+			name:     "unnamed",
+			handler:  func(next slog.Handler) *Handler { return NewHandler(next) },
+			ctx:      context.Background(),
+			expected: "",
+		},
+		{
+			name: "name chain",
+			handler: func(next slog.Handler) *Handler {
+				return NewHandler(next).Named("service").Named("database")
+			},
+			ctx:      context.Background(),
+			expected: "service.database",
+		},
+		{
+			name: "dynamic name",
+			handler: func(next slog.Handler) *Handler {
+				return NewHandler(next).Named("service").WithNameFunc(func(ctx context.Context) string {
+					name, _ := ctx.Value(ctxKeyName{}).(string)
+					return name
+				})
+			},
+			ctx:      context.WithValue(context.Background(), ctxKeyName{}, "tenant"),
+			expected: "tenant",
+			enabled:  "service",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			next := nameCapturingHandler{Handler: slog.NewTextHandler(&bytes.Buffer{}, nil), names: &names}
+
+			h := tt.handler(next)
+			assert.True(t, h.Enabled(tt.ctx, slog.LevelInfo))
+			err := h.Handle(tt.ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0))
+			assert.NoError(t, err)
+			enabled := tt.expected
+			if tt.enabled != "" {
+				enabled = tt.enabled
+			}
+			assert.Equal(t, []string{enabled, tt.expected}, names, "Enabled gets the static name and Handle the resolved one")
+		})
+	}
+
+	assert.Empty(t, LoggerName(context.Background()))
+}
@@ -0,0 +1,83 @@
+package slogs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// CorrelationIDKey is the attribute key used by EnsureCorrelationID when none is given.
+const CorrelationIDKey = "correlation_id"
+
+// correlationIDSize is the number of random bytes of a generated correlation ID.
+const correlationIDSize = 16
+
+// correlationIDKey is the context key storing the correlation ID logged under key.
+type correlationIDKey struct{ key string }
+
+// EnsureCorrelationID returns the correlation ID logged under key by records using ctx,
+// generating one if there is none yet, together with a context carrying it. This is the
+// "get or create request ID" pattern of servers and message consumers, which reuse the ID of an
+// upstream caller when there is one and pass it on to downstream calls.
+//
+// The ID is looked up in the attributes added to ctx by Prepend, then in the context value
+// stored by a previous call. If neither holds a non-empty value, a random 128-bit ID is
+// generated and hex-encoded. Unless it was found among the prepended attributes, the ID is
+// prepended to ctx, so that it appears on all the records logged with the returned context. An
+// empty key means CorrelationIDKey.
+//
+// Example:
+//
+//	ctx, id := slogs.EnsureCorrelationID(r.Context(), "request_id")
+//	w.Header().Set("X-Request-ID", id)
+//	logger.InfoContext(ctx, "handling request") // {"request_id":"3f2b...","msg":"handling request"}
+//
+// Panics if no random ID can be generated, which only happens if the operating system's random
+// number generator fails.
+func EnsureCorrelationID(ctx context.Context, key string) (context.Context, string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if key == "" {
+		key = CorrelationIDKey
+	}
+
+	if id := prependedCorrelationID(ctx, key); id != "" {
+		return ctx, id
+	}
+
+	id, ok := ctx.Value(correlationIDKey{key}).(string)
+	if !ok || id == "" {
+		var err error
+		if id, err = newCorrelationID(); err != nil {
+			panic("slogs: cannot generate correlation ID: " + err.Error())
+		}
+		ctx = context.WithValue(ctx, correlationIDKey{key}, id)
+	}
+	return Prepend(ctx, slog.String(key, id)), id
+}
+
+// prependedCorrelationID returns the value of the last attribute prepended to ctx with key as a
+// string, or an empty string if there is none or it is a group.
+func prependedCorrelationID(ctx context.Context, key string) string {
+	attrs := ExtractPrepended(ctx)
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Key == key {
+			if v := attrs[i].Value.Resolve(); v.Kind() != slog.KindGroup {
+				return v.String()
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// newCorrelationID returns a random hex-encoded correlation ID.
+func newCorrelationID() (string, error) {
+	b := make([]byte, correlationIDSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
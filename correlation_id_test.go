@@ -0,0 +1,108 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureCorrelationID(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    func() context.Context
+		key    string
+		wantID string
+	}{
+		{
+			name:   "prepended attribute",
+			ctx:    func() context.Context { return Prepend(context.Background(), "request_id", "abc-123") },
+			key:    "request_id",
+			wantID: "abc-123",
+		},
+		{
+			name: "last prepended attribute",
+			ctx: func() context.Context {
+				return Prepend(Prepend(context.Background(), "request_id", "a"), "request_id", "b")
+			},
+			key:    "request_id",
+			wantID: "b",
+		},
+		{
+			name:   "non-string prepended attribute",
+			ctx:    func() context.Context { return Prepend(context.Background(), "request_id", 42) },
+			key:    "request_id",
+			wantID: "42",
+		},
+		{
+			name: "context value",
+			ctx: func() context.Context {
+				return context.WithValue(context.Background(), correlationIDKey{"request_id"}, "from-value")
+			},
+			key:    "request_id",
+			wantID: "from-value",
+		},
+		{
+			name:   "default key",
+			ctx:    func() context.Context { return Prepend(context.Background(), CorrelationIDKey, "abc-123") },
+			wantID: "abc-123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, id := EnsureCorrelationID(tt.ctx(), tt.key)
+			assert.Equal(t, tt.wantID, id)
+
+			key := tt.key
+			if key == "" {
+				key = CorrelationIDKey
+			}
+			assert.Equal(t, id, prependedCorrelationID(ctx, key))
+		})
+	}
+}
+
+func TestEnsureCorrelationID_Generate(t *testing.T) {
+	ctx, id := EnsureCorrelationID(context.Background(), "request_id")
+
+	decoded, err := hex.DecodeString(id)
+	require.NoError(t, err)
+	assert.Len(t, decoded, correlationIDSize)
+
+	t.Run("reused by later calls", func(t *testing.T) {
+		ctx2, id2 := EnsureCorrelationID(ctx, "request_id")
+		assert.Equal(t, id, id2)
+		assert.Equal(t, ctx, ctx2, "the context should be returned unchanged")
+	})
+
+	t.Run("distinct per context", func(t *testing.T) {
+		_, other := EnsureCorrelationID(context.Background(), "request_id")
+		assert.NotEqual(t, id, other)
+	})
+
+	t.Run("distinct per key", func(t *testing.T) {
+		_, other := EnsureCorrelationID(ctx, "trace_id")
+		assert.NotEqual(t, id, other)
+	})
+
+	t.Run("nil context", func(t *testing.T) {
+		ctx, id := EnsureCorrelationID(nil, "")
+		assert.NotEmpty(t, id)
+		assert.Equal(t, id, prependedCorrelationID(ctx, CorrelationIDKey))
+	})
+}
+
+func TestEnsureCorrelationID_Logged(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+
+	ctx, id := EnsureCorrelationID(context.Background(), "request_id")
+	logger.InfoContext(ctx, "handling request")
+
+	assert.Equal(t, `{"level":"INFO","msg":"handling request","request_id":"`+id+`"}`+"\n", buf.String())
+}
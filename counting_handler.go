@@ -0,0 +1,222 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	_ slog.Handler = (*CountingHandler)(nil)
+	_ Closer       = (*CountingHandler)(nil)
+)
+
+// CountingHandler aggregates records into periodic counts instead of emitting them.
+//
+// It is meant for very frequent events, such as cache hits, where a line per event is too
+// costly but the rate still matters. Each record increments the counter of its key; every
+// interval, one summary record per key is passed to the next handler and the counters are
+// reset. Individual records are never emitted. This differs from SamplingHandler, which
+// emits a subset of the records.
+//
+// A summary record has the key as message, the highest level of the counted records, and the
+// attributes "count" and "interval". The attributes and groups of the counted records are not
+// kept.
+//
+// Close must be called to emit the last counts and stop the background goroutine.
+//
+// Example:
+//
+//	counting := slogs.NewCountingHandler(slog.NewJSONHandler(os.Stdout, nil), nil, nil, time.Minute)
+//	defer counting.Close(context.Background())
+//	cacheLog := slogs.New(slogs.NewHandler(counting))
+//	cacheLog.Info("cache hit") // {"msg":"cache hit","count":12873,"interval":60000000000}
+type CountingHandler struct {
+	next     slog.Handler
+	counters *counters
+}
+
+// counters holds the counts shared by a CountingHandler and the handlers derived from it.
+type counters struct {
+	next     slog.Handler
+	clock    Clock
+	keyFn    func(r slog.Record) string
+	interval time.Duration
+
+	mu     sync.Mutex
+	counts map[string]*count
+	closed bool
+
+	stop    chan struct{}
+	stopped chan struct{}
+	errMu   sync.Mutex
+	lastErr error
+}
+
+// count is the number of records of a key and their highest level.
+type count struct {
+	n     int
+	level slog.Level
+}
+
+// NewCountingHandler creates a CountingHandler passing summary records to next every interval.
+//
+// keyFn returns the key a record is counted under; a nil keyFn counts records by message. The
+// interval is measured with clock, or DefaultClock if clock is nil, and defaults to one minute
+// if it is <= 0.
+//
+// Panics if next is nil.
+func NewCountingHandler(next slog.Handler, clock Clock, keyFn func(r slog.Record) string, interval time.Duration) *CountingHandler {
+	if next == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+	if clock == nil {
+		clock = DefaultClock
+	}
+	if keyFn == nil {
+		keyFn = func(r slog.Record) string { return r.Message }
+	}
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	c := &counters{
+		next:     next,
+		clock:    clock,
+		keyFn:    keyFn,
+		interval: interval,
+		counts:   make(map[string]*count),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go c.run(clock.NewTicker(interval))
+
+	return &CountingHandler{next: next, counters: c}
+}
+
+// Close emits the summary records of the current counts and stops the background goroutine.
+//
+// It returns the last error returned by the next handler, or ctx.Err() if ctx is done before
+// the summary records are emitted. Records handled after Close fail with ErrHandlerClosed.
+func (h *CountingHandler) Close(ctx context.Context) error {
+	c := h.counters
+
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.stop)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	return c.lastErr
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *CountingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle increments the counter of r's key.
+func (h *CountingHandler) Handle(_ context.Context, r slog.Record) error {
+	c := h.counters
+	key := c.keyFn(r)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return ErrHandlerClosed
+	}
+
+	cnt, ok := c.counts[key]
+	if !ok {
+		cnt = &count{level: r.Level}
+		c.counts[key] = cnt
+	}
+	cnt.n++
+	if r.Level > cnt.level {
+		cnt.level = r.Level
+	}
+	return nil
+}
+
+// WithAttrs returns a CountingHandler sharing the same counters. The attributes are only used
+// to decide whether the handler is enabled, since summary records do not carry them.
+func (h *CountingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a CountingHandler sharing the same counters. The group is only used to
+// decide whether the handler is enabled, since summary records do not carry it.
+func (h *CountingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+func (c *counters) run(ticker *time.Ticker) {
+	defer close(c.stopped)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.emit()
+		case <-c.stop:
+			c.emit()
+			return
+		}
+	}
+}
+
+// emit passes one summary record per key to the next handler, in key order, and resets the counters.
+func (c *counters) emit() {
+	c.mu.Lock()
+	counts := c.counts
+	c.counts = make(map[string]*count, len(counts))
+	c.mu.Unlock()
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ctx := context.Background()
+	now := c.clock.Now()
+	for _, key := range keys {
+		cnt := counts[key]
+		if !c.next.Enabled(ctx, cnt.level) {
+			continue
+		}
+
+		r := slog.NewRecord(now, cnt.level, key, 0)
+		r.AddAttrs(slog.Int("count", cnt.n), slog.Duration("interval", c.interval))
+		if err := c.next.Handle(ctx, r); err != nil {
+			c.errMu.Lock()
+			c.lastErr = err
+			c.errMu.Unlock()
+		}
+	}
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *CountingHandler) unwrap() slog.Handler {
+	return h.counters.next
+}
@@ -0,0 +1,145 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-slogs/slogstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// summary is the message, level and count of a summary record.
+type summary struct {
+	msg   string
+	level slog.Level
+	count int64
+}
+
+func summaries(records []slog.Record) []summary {
+	var out []summary
+	for _, r := range records {
+		s := summary{msg: r.Message, level: r.Level}
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "count" {
+				s.count = a.Value.Int64()
+			}
+			return true
+		})
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestCountingHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		keyFn func(r slog.Record) string
+		log   func(l *Logger)
+		want  []summary
+	}{
+		{
+			name: "counts by message",
+			log: func(l *Logger) {
+				l.Info("hit")
+				l.Info("miss")
+				l.Info("hit")
+				l.With("k", "v").Info("hit")
+			},
+			want: []summary{
+				{msg: "hit", level: slog.LevelInfo, count: 3},
+				{msg: "miss", level: slog.LevelInfo, count: 1},
+			},
+		},
+		{
+			name: "keeps the highest level",
+			log: func(l *Logger) {
+				l.Info("evict")
+				l.Warn("evict")
+				l.Info("evict")
+			},
+			want: []summary{{msg: "evict", level: slog.LevelWarn, count: 3}},
+		},
+		{
+			name: "custom key",
+			keyFn: func(r slog.Record) string {
+				key := "unknown"
+				r.Attrs(func(a slog.Attr) bool {
+					if a.Key == "cache" {
+						key = "cache " + a.Value.String()
+					}
+					return true
+				})
+				return key
+			},
+			log: func(l *Logger) {
+				l.Info("hit", "cache", "users")
+				l.Info("miss", "cache", "users")
+				l.Info("hit", "cache", "orders")
+			},
+			want: []summary{
+				{msg: "cache orders", level: slog.LevelInfo, count: 1},
+				{msg: "cache users", level: slog.LevelInfo, count: 2},
+			},
+		},
+		{
+			name: "nothing logged",
+			log:  func(*Logger) {},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			h := NewCountingHandler(next, newFakeClock(), tt.keyFn, time.Second)
+
+			tt.log(New(NewHandler(h)))
+			assert.Zero(t, next.recordCount())
+
+			require.NoError(t, h.Close(context.Background()))
+			assert.Equal(t, tt.want, summaries(next.getRecords()))
+		})
+	}
+}
+
+func TestCountingHandler_Interval(t *testing.T) {
+	clock := slogstest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	next := newTestHandler(true)
+	h := NewCountingHandler(next, clock, nil, time.Minute)
+	logger := New(NewHandler(h))
+
+	logger.Info("hit")
+	logger.Info("hit")
+	clock.Advance(time.Minute)
+	// The second tick is received once the first summary is emitted; it emits nothing.
+	clock.Advance(time.Minute)
+	require.Equal(t, []summary{{msg: "hit", level: slog.LevelInfo, count: 2}}, summaries(next.getRecords()))
+
+	logger.Info("hit")
+	require.NoError(t, h.Close(context.Background()))
+
+	records := next.getRecords()
+	assert.Equal(t, []summary{
+		{msg: "hit", level: slog.LevelInfo, count: 2},
+		{msg: "hit", level: slog.LevelInfo, count: 1},
+	}, summaries(records))
+	assert.True(t, recordHasAttr(records[0], "interval", time.Minute.String()))
+}
+
+func TestCountingHandler_Closed(t *testing.T) {
+	h := NewCountingHandler(newTestHandler(true), newFakeClock(), nil, time.Second)
+	require.NoError(t, h.Close(context.Background()))
+	require.NoError(t, h.Close(context.Background()))
+
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "late", 0))
+	assert.ErrorIs(t, err, ErrHandlerClosed)
+}
+
+func TestNewCountingHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewCountingHandler(nil, nil, nil, time.Second)
+	})
+}
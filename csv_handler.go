@@ -0,0 +1,202 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ slog.Handler = (*CSVHandler)(nil)
+
+// CSVHandler writes records as CSV rows, e.g. for analysis in a spreadsheet.
+//
+// The first row written is a header holding the column names. Each record is then written as
+// one row, quoted per RFC 4180, with a cell per column:
+//   - "time", "level" and "msg" (or "message") hold the record time, level and message
+//   - any other column holds the value of the attribute with that key, qualified by its groups
+//     and joined with periods, e.g. "http.status"
+//
+// Cells of missing attributes are left empty. Attributes matching no column are ignored,
+// unless a catch-all column is configured with WithCatchAll.
+//
+// Example:
+//
+//	h := slogs.NewCSVHandler(f, []string{"time", "level", "msg", "user", "http.status"})
+//	logger := slogs.New(slogs.NewHandler(h))
+//	logger.Info("login", "user", "alice")
+//	// time,level,msg,user,http.status
+//	// 2024-05-01T10:00:00Z,INFO,login,alice,
+type CSVHandler struct {
+	out      *csvWriter
+	columns  []string
+	catchAll string
+	prefix   string
+	attrs    []slog.Attr
+}
+
+// csvWriter serializes the writes of a CSVHandler and the handlers derived from it, and
+// records whether the header was written.
+type csvWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	header bool
+}
+
+// NewCSVHandler creates a CSVHandler writing the given columns to w.
+//
+// Panics if w is nil.
+func NewCSVHandler(w io.Writer, columns []string) *CSVHandler {
+	if w == nil {
+		panic("slogs: writer cannot be nil")
+	}
+
+	return &CSVHandler{out: &csvWriter{w: w}, columns: slices.Clone(columns)}
+}
+
+// WithCatchAll returns a CSVHandler writing to the same writer with an additional last column
+// named column, holding the attributes that match no other column as space-separated
+// key=value pairs. An empty column removes the catch-all column.
+//
+// It must be called before the first record is written, since the header is written only once.
+func (h *CSVHandler) WithCatchAll(column string) *CSVHandler {
+	h2 := *h
+	h2.catchAll = column
+	return &h2
+}
+
+// Enabled reports true: the level of the rows is left to the handlers wrapping the CSVHandler.
+func (h *CSVHandler) Enabled(_ context.Context, _ slog.Level) bool {
+	return true
+}
+
+// Handle writes r as a CSV row, preceded by the header row for the first record, with a
+// single Write call.
+func (h *CSVHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := make(map[string]string, len(h.columns))
+	var extra []string
+	add := func(key, value string) {
+		if slices.Contains(h.columns, key) {
+			fields[key] = value
+		} else if h.catchAll != "" {
+			extra = append(extra, key+"="+value)
+		}
+	}
+	for _, a := range h.attrs {
+		add(a.Key, csvValue(a.Value))
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		for _, a := range flattenCSV(h.prefix, a, nil) {
+			add(a.Key, csvValue(a.Value))
+		}
+		return true
+	})
+
+	row := make([]string, 0, len(h.columns)+1)
+	for _, column := range h.columns {
+		switch column {
+		case slog.TimeKey:
+			if r.Time.IsZero() {
+				row = append(row, "")
+			} else {
+				row = append(row, r.Time.Format(time.RFC3339Nano))
+			}
+		case slog.LevelKey:
+			row = append(row, r.Level.String())
+		case slog.MessageKey, "message":
+			row = append(row, r.Message)
+		default:
+			row = append(row, fields[column])
+		}
+	}
+	if h.catchAll != "" {
+		row = append(row, strings.Join(extra, " "))
+	}
+
+	h.out.mu.Lock()
+	defer h.out.mu.Unlock()
+
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	if !h.out.header {
+		header := h.columns
+		if h.catchAll != "" {
+			header = append(slices.Clip(header), h.catchAll)
+		}
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+	if err := cw.Write(row); err != nil {
+		return err
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	if _, err := h.out.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	h.out.header = true
+	return nil
+}
+
+// WithAttrs returns a CSVHandler writing to the same writer that also reads attrs.
+func (h *CSVHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		h2.attrs = flattenCSV(h.prefix, a, h2.attrs)
+	}
+	return &h2
+}
+
+// WithGroup returns a CSVHandler writing to the same writer that qualifies the keys of the
+// attributes added afterwards with name.
+func (h *CSVHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+// flattenCSV appends to dst the leaf attributes of a, with keys qualified by prefix and the
+// groups containing them.
+func flattenCSV(prefix string, a slog.Attr, dst []slog.Attr) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		if a.Key == "" {
+			return dst
+		}
+		return append(dst, slog.Attr{Key: prefix + a.Key, Value: a.Value})
+	}
+
+	if a.Key != "" {
+		prefix += a.Key + "."
+	}
+	for _, member := range a.Value.Group() {
+		dst = flattenCSV(prefix, member, dst)
+	}
+	return dst
+}
+
+// csvValue returns the cell text of v, formatting times like the time column.
+func csvValue(v slog.Value) string {
+	if v.Kind() == slog.KindTime {
+		return v.Time().Format(time.RFC3339Nano)
+	}
+	return v.String()
+}
@@ -0,0 +1,79 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVHandler(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		handler  func(h *CSVHandler) slog.Handler
+		attrs    []slog.Attr
+		expected string
+	}{
+		{
+			name:     "columns",
+			handler:  func(h *CSVHandler) slog.Handler { return h },
+			attrs:    []slog.Attr{slog.String("user", "alice"), slog.Int("ignored", 1)},
+			expected: "time,level,msg,user,http.status\n2024-05-01T10:00:00Z,INFO,\"login, retry\",alice,\n",
+		},
+		{
+			name:     "groups and quoting",
+			handler:  func(h *CSVHandler) slog.Handler { return h.WithAttrs([]slog.Attr{slog.String("user", `bob "b"`)}) },
+			attrs:    []slog.Attr{slog.Group("http", slog.Int("status", 200))},
+			expected: "time,level,msg,user,http.status\n2024-05-01T10:00:00Z,INFO,\"login, retry\",\"bob \"\"b\"\"\",200\n",
+		},
+		{
+			name: "WithGroup",
+			handler: func(h *CSVHandler) slog.Handler {
+				return h.WithGroup("http").WithAttrs([]slog.Attr{slog.Int("status", 404)})
+			},
+			attrs:    []slog.Attr{slog.String("user", "carol")},
+			expected: "time,level,msg,user,http.status\n2024-05-01T10:00:00Z,INFO,\"login, retry\",,404\n",
+		},
+		{
+			name:     "catch-all",
+			handler:  func(h *CSVHandler) slog.Handler { return h.WithCatchAll("extra") },
+			attrs:    []slog.Attr{slog.String("user", "alice"), slog.Int("a", 1), slog.Group("g", slog.Bool("b", true))},
+			expected: "time,level,msg,user,http.status,extra\n2024-05-01T10:00:00Z,INFO,\"login, retry\",alice,,a=1 g.b=true\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := tt.handler(NewCSVHandler(buf, []string{"time", "level", "msg", "user", "http.status"}))
+
+			r := slog.NewRecord(ts, slog.LevelInfo, "login, retry", 0)
+			r.AddAttrs(tt.attrs...)
+			require.NoError(t, h.Handle(context.Background(), r))
+
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestCSVHandler_HeaderOnce(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(NewCSVHandler(buf, []string{"message", "n"}))
+
+	logger.Info("first", "n", 1)
+	logger.With("n", 2).Warn("second")
+
+	assert.Equal(t, "message,n\nfirst,1\nsecond,2\n", buf.String())
+}
+
+func TestNewCSVHandler_NilWriter(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: writer cannot be nil", func() {
+		NewCSVHandler(nil, nil)
+	})
+}
@@ -0,0 +1,292 @@
+package slogs
+
+import (
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	_ slog.Handler = (*DedupHandler)(nil)
+	_ Closer       = (*DedupHandler)(nil)
+)
+
+// RepeatedKey is the key of the attribute holding the number of occurrences of a record
+// collapsed by a DedupHandler.
+const RepeatedKey = "repeated"
+
+// DedupHandler suppresses identical records repeated within a window, such as the "waiting for
+// resource" record of a reconcile loop.
+//
+// Time is divided into consecutive windows of a fixed duration. The first record of a kind in a
+// window is held back and the identical ones that follow are counted; when the window closes,
+// a single record is passed to the next handler for each kind, with a RepeatedKey attribute
+// holding the number of occurrences if there was more than one. Records are thus delayed by up
+// to a window, and emitted in the order their kind was first seen. Like the other attributes of
+// a record, RepeatedKey is nested in the groups of the handler the record was logged with.
+//
+// Records are identical if they have the same level, message and attributes, whatever the order
+// of the attributes, and were logged through handlers with the same attributes and groups. With
+// DedupOptions.MessageOnly, the attributes of the records are ignored, which is cheaper; the
+// attributes of the first record are kept.
+//
+// Close must be called to emit the records of the current window and stop the background
+// goroutine.
+//
+// Example:
+//
+//	dedup := slogs.NewDedupHandler(slog.NewJSONHandler(os.Stdout, nil), time.Minute, nil)
+//	defer dedup.Close(context.Background())
+//	logger := slogs.New(slogs.NewHandler(dedup))
+//	logger.Info("waiting for resource") // {"msg":"waiting for resource","repeated":12}
+type DedupHandler struct {
+	next slog.Handler
+	// scope encodes the WithAttrs and WithGroup calls of the handler.
+	scope string
+	state *dedupState
+}
+
+// DedupOptions configures a DedupHandler.
+type DedupOptions struct {
+	// MessageOnly compares records on their level and message only, ignoring their attributes.
+	MessageOnly bool
+	// Clock drives the windows. Defaults to DefaultClock.
+	Clock Clock
+}
+
+// dedupState holds the window shared by a DedupHandler and the handlers derived from it.
+type dedupState struct {
+	messageOnly bool
+
+	mu sync.Mutex
+	// entries are keyed by the full encoding of the records, so that distinct records never
+	// share an entry, see key.
+	entries map[string]*dedupEntry
+	order   []*dedupEntry
+	closed  bool
+
+	stop    chan struct{}
+	stopped chan struct{}
+	errMu   sync.Mutex
+	lastErr error
+}
+
+// dedupEntry is the first record of a kind in the current window and its number of occurrences.
+type dedupEntry struct {
+	ctx    context.Context
+	next   slog.Handler
+	record slog.Record
+	n      int
+}
+
+// NewDedupHandler creates a DedupHandler collapsing identical records within windows of the
+// given duration, which defaults to one minute if it is <= 0. opts may be nil.
+//
+// Panics if next is nil; see NewDedupHandlerE.
+func NewDedupHandler(next slog.Handler, window time.Duration, opts *DedupOptions) *DedupHandler {
+	h, err := NewDedupHandlerE(next, window, opts)
+	if err != nil {
+		panic(err.Error())
+	}
+	return h
+}
+
+// NewDedupHandlerE is like NewDedupHandler but returns ErrNilNextHandler instead of panicking
+// if next is nil.
+func NewDedupHandlerE(next slog.Handler, window time.Duration, opts *DedupOptions) (*DedupHandler, error) {
+	if next == nil {
+		return nil, ErrNilNextHandler
+	}
+	if opts == nil {
+		opts = &DedupOptions{}
+	}
+	clock := opts.Clock
+	if clock == nil {
+		clock = DefaultClock
+	}
+	if window <= 0 {
+		window = time.Minute
+	}
+
+	s := &dedupState{
+		messageOnly: opts.MessageOnly,
+		entries:     make(map[string]*dedupEntry),
+		stop:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	go s.run(clock.NewTicker(window))
+
+	return &DedupHandler{next: next, state: s}, nil
+}
+
+// Close emits the records of the current window and stops the background goroutine.
+//
+// It returns the last error returned by the next handler, or ctx.Err() if ctx is done before
+// the records are emitted. Records handled after Close fail with ErrHandlerClosed.
+func (h *DedupHandler) Close(ctx context.Context) error {
+	s := h.state
+
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.stop)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	return s.lastErr
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *DedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle holds r back until the end of the window, or counts it if an identical record was
+// already handled in the window.
+func (h *DedupHandler) Handle(ctx context.Context, r slog.Record) error {
+	s := h.state
+	key := s.key(h.scope, r)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrHandlerClosed
+	}
+
+	if e, ok := s.entries[key]; ok {
+		e.n++
+		return nil
+	}
+
+	// The record is emitted after the call returns, so it is cloned and ctx is detached from
+	// its cancellation.
+	e := &dedupEntry{ctx: context.WithoutCancel(ctx), next: h.next, record: r.Clone(), n: 1}
+	s.entries[key] = e
+	s.order = append(s.order, e)
+	return nil
+}
+
+// WithAttrs returns a DedupHandler sharing the same window whose next handler has the given
+// attributes. Its records are never collapsed with those of h.
+func (h *DedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	h2.scope = string(appendDedupAttrs(append([]byte(h.scope), 'a'), attrs))
+	return &h2
+}
+
+// WithGroup returns a DedupHandler sharing the same window whose next handler has the given
+// group. Its records are never collapsed with those of h.
+func (h *DedupHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	h2.scope = string(appendDedupString(append([]byte(h.scope), 'g'), name))
+	return &h2
+}
+
+// key encodes scope with the level, message and, unless only messages are compared, the
+// attributes of r. Records have the same key if and only if they are identical.
+func (s *dedupState) key(scope string, r slog.Record) string {
+	b := append([]byte(scope), 'r')
+	b = binary.BigEndian.AppendUint64(b, uint64(r.Level))
+	b = appendDedupString(b, r.Message)
+
+	if !s.messageOnly {
+		attrs := make([]slog.Attr, 0, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			attrs = append(attrs, a)
+			return true
+		})
+		b = appendDedupAttrs(b, attrs)
+	}
+	return string(b)
+}
+
+func (s *dedupState) run(ticker *time.Ticker) {
+	defer close(s.stopped)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.emit()
+		case <-s.stop:
+			s.emit()
+			return
+		}
+	}
+}
+
+// emit passes the records of the current window to their next handlers and starts a new window.
+func (s *dedupState) emit() {
+	s.mu.Lock()
+	order := s.order
+	s.entries = make(map[string]*dedupEntry, len(s.entries))
+	s.order = nil
+	s.mu.Unlock()
+
+	for _, e := range order {
+		r := e.record
+		if e.n > 1 {
+			r.AddAttrs(slog.Int(RepeatedKey, e.n))
+		}
+		if err := e.next.Handle(e.ctx, r); err != nil {
+			s.errMu.Lock()
+			s.lastErr = err
+			s.errMu.Unlock()
+		}
+	}
+}
+
+// appendDedupString appends s to b, prefixed with its length so that the encoding of
+// consecutive strings is unambiguous.
+func appendDedupString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// appendDedupAttrs appends the resolved attrs, sorted by key and recursing into groups, to b.
+func appendDedupAttrs(b []byte, attrs []slog.Attr) []byte {
+	attrs = append([]slog.Attr(nil), attrs...)
+	sort.SliceStable(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+
+	b = binary.AppendUvarint(b, uint64(len(attrs)))
+	for _, a := range attrs {
+		v := a.Value.Resolve()
+		b = appendDedupString(b, a.Key)
+		b = append(b, byte(v.Kind()))
+		if v.Kind() == slog.KindGroup {
+			b = appendDedupAttrs(b, v.Group())
+			continue
+		}
+		b = appendDedupString(b, v.String())
+	}
+	return b
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *DedupHandler) unwrap() slog.Handler {
+	return h.next
+}
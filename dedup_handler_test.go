@@ -0,0 +1,188 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-slogs/slogstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		messageOnly bool
+		log         func(l *slog.Logger)
+		want        []string
+	}{
+		{
+			name: "collapses identical records",
+			log: func(l *slog.Logger) {
+				l.Info("waiting for resource", "name", "db")
+				l.Info("waiting for resource", "name", "db")
+				l.Info("waiting for resource", "name", "db")
+			},
+			want: []string{`{"level":"INFO","msg":"waiting for resource","name":"db","repeated":3}`},
+		},
+		{
+			name: "single record unchanged",
+			log:  func(l *slog.Logger) { l.Info("started") },
+			want: []string{`{"level":"INFO","msg":"started"}`},
+		},
+		{
+			name: "attribute order ignored",
+			log: func(l *slog.Logger) {
+				l.Info("m", "a", 1, "b", 2)
+				l.Info("m", "b", 2, "a", 1)
+			},
+			want: []string{`{"level":"INFO","msg":"m","a":1,"b":2,"repeated":2}`},
+		},
+		{
+			name: "different attributes kept apart",
+			log: func(l *slog.Logger) {
+				l.Info("m", "name", "db")
+				l.Info("m", "name", "cache")
+				l.Info("m", "name", "db")
+			},
+			want: []string{
+				`{"level":"INFO","msg":"m","name":"db","repeated":2}`,
+				`{"level":"INFO","msg":"m","name":"cache"}`,
+			},
+		},
+		{
+			name: "different levels kept apart",
+			log: func(l *slog.Logger) {
+				l.Info("m")
+				l.Warn("m")
+			},
+			want: []string{`{"level":"INFO","msg":"m"}`, `{"level":"WARN","msg":"m"}`},
+		},
+		{
+			name: "nested groups compared",
+			log: func(l *slog.Logger) {
+				l.Info("m", slog.Group("g", "a", 1))
+				l.Info("m", slog.Group("g", "a", 2))
+				l.Info("m", slog.Group("g", "a", 1))
+			},
+			want: []string{
+				`{"level":"INFO","msg":"m","g":{"a":1},"repeated":2}`,
+				`{"level":"INFO","msg":"m","g":{"a":2}}`,
+			},
+		},
+		{
+			name: "records whose encodings concatenate alike kept apart",
+			log: func(l *slog.Logger) {
+				l.Info("m", "ab", "c")
+				l.Info("m", "a", "bc")
+				l.Info("m", slog.Group("g", "a", 1), "b", 2)
+				l.Info("m", slog.Group("g", "a", 1, "b", 2))
+			},
+			want: []string{
+				`{"level":"INFO","msg":"m","ab":"c"}`,
+				`{"level":"INFO","msg":"m","a":"bc"}`,
+				`{"level":"INFO","msg":"m","g":{"a":1},"b":2}`,
+				`{"level":"INFO","msg":"m","g":{"a":1,"b":2}}`,
+			},
+		},
+		{
+			name:        "message only",
+			messageOnly: true,
+			log: func(l *slog.Logger) {
+				l.Info("m", "name", "db")
+				l.Info("m", "name", "cache")
+			},
+			want: []string{`{"level":"INFO","msg":"m","name":"db","repeated":2}`},
+		},
+		{
+			name: "derived handlers",
+			log: func(l *slog.Logger) {
+				l.With("k", "v").Info("m")
+				l.With("k", "v").Info("m")
+				l.With("k", "w").Info("m")
+				l.WithGroup("g").Info("m", "a", 1)
+				l.WithGroup("g").Info("m", "a", 1)
+				l.Info("m", "a", 1)
+			},
+			want: []string{
+				`{"level":"INFO","msg":"m","k":"v","repeated":2}`,
+				`{"level":"INFO","msg":"m","k":"w"}`,
+				`{"level":"INFO","msg":"m","g":{"a":1,"repeated":2}}`,
+				`{"level":"INFO","msg":"m","a":1}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			next := slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})
+			clock := slogstest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			h := NewDedupHandler(next, time.Minute, &DedupOptions{MessageOnly: tt.messageOnly, Clock: clock})
+
+			tt.log(slog.New(h))
+			assert.Empty(t, buf.String(), "records should be held until the window closes")
+
+			require.NoError(t, h.Close(context.Background()))
+			assert.Equal(t, tt.want, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"))
+		})
+	}
+}
+
+func TestDedupHandler_Window(t *testing.T) {
+	clock := slogstest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	next := newTestHandler(true)
+	h := NewDedupHandler(next, time.Minute, &DedupOptions{Clock: clock})
+	logger := slog.New(h)
+
+	logger.Info("waiting")
+	logger.Info("waiting")
+	logger.Info("waiting")
+	clock.Advance(time.Minute)
+	// The second tick is received once the first window is emitted; it emits nothing.
+	clock.Advance(time.Minute)
+
+	records := next.getRecords()
+	require.Len(t, records, 1)
+	assert.True(t, recordHasAttr(records[0], RepeatedKey, "3"))
+
+	logger.Info("waiting")
+	require.NoError(t, h.Close(context.Background()))
+
+	records = next.getRecords()
+	require.Len(t, records, 2)
+	assert.Equal(t, 0, records[1].NumAttrs(), "a record alone in its window has no repeated attribute")
+}
+
+func TestDedupHandler_Error(t *testing.T) {
+	next := newTestHandler(true)
+	next.err = errors.New("write failed")
+	h := NewDedupHandler(next, time.Minute, &DedupOptions{Clock: newFakeClock()})
+
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "m", 0)))
+	assert.ErrorIs(t, h.Close(context.Background()), next.err)
+}
+
+func TestDedupHandler_Closed(t *testing.T) {
+	h := NewDedupHandler(newTestHandler(true), time.Second, &DedupOptions{Clock: newFakeClock()})
+	require.NoError(t, h.Close(context.Background()))
+	require.NoError(t, h.Close(context.Background()))
+
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "late", 0))
+	assert.ErrorIs(t, err, ErrHandlerClosed)
+}
+
+func TestNewDedupHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewDedupHandler(nil, time.Second, nil)
+	})
+
+	h, err := NewDedupHandlerE(nil, time.Second, nil)
+	assert.ErrorIs(t, err, ErrNilNextHandler)
+	assert.Nil(t, h)
+}
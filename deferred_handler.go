@@ -0,0 +1,254 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+var _ slog.Handler = (*DeferredHandler)(nil)
+
+// DeferredHandler buffers the records of a request and only emits them if the request fails.
+//
+// Records logged with a context prepared by Begin are held in memory until Commit flushes
+// them to the next handler (typically because the request ended in an error) or Discard
+// drops them (the happy path). Buffers are discarded automatically when the context passed
+// to Begin is canceled, so abandoned requests cannot leak memory; call Commit before
+// canceling the request context if the logs should be kept.
+//
+// Records logged with a context that was not prepared by Begin are passed through unchanged.
+//
+// Example:
+//
+//	deferred := slogs.NewDeferredHandler(slog.NewJSONHandler(os.Stdout, nil), 1000)
+//	logger := slogs.New(slogs.NewHandler(deferred))
+//
+//	func serve(ctx context.Context) {
+//		ctx = deferred.Begin(ctx)
+//		if err := process(ctx); err != nil {
+//			deferred.Commit(ctx)
+//			return
+//		}
+//		deferred.Discard(ctx)
+//	}
+type DeferredHandler struct {
+	next     slog.Handler
+	key      deferredKey
+	limit    int
+	reporter DropReporter
+}
+
+// deferredKey identifies the buffers of one DeferredHandler tree in a context.
+type deferredKey struct {
+	id *int
+}
+
+// deferredEntry is a buffered record together with the handler and context it was logged with.
+type deferredEntry struct {
+	next   slog.Handler
+	ctx    context.Context
+	record slog.Record
+}
+
+// deferredBuffer holds the records of a single request.
+type deferredBuffer struct {
+	mu        sync.Mutex
+	entries   []deferredEntry
+	committed bool
+	discarded bool
+	// stop unregisters the discarding of the buffer when the Begin context is canceled. It is
+	// nil if the context was already canceled.
+	stop func() bool
+}
+
+// NewDeferredHandler creates a DeferredHandler that forwards committed records to next.
+//
+// limit bounds the number of records buffered per request; when it is exceeded the oldest
+// records are dropped. A limit <= 0 means unbounded.
+//
+// Panics if next is nil; see NewDeferredHandlerE.
+func NewDeferredHandler(next slog.Handler, limit int) *DeferredHandler {
+	h, err := NewDeferredHandlerE(next, limit)
+	if err != nil {
+		panic(err.Error())
+	}
+	return h
+}
+
+// NewDeferredHandlerE is like NewDeferredHandler but returns ErrNilNextHandler instead of
+// panicking if next is nil.
+func NewDeferredHandlerE(next slog.Handler, limit int) (*DeferredHandler, error) {
+	if next == nil {
+		return nil, ErrNilNextHandler
+	}
+
+	return &DeferredHandler{
+		next:  next,
+		key:   deferredKey{id: new(int)},
+		limit: limit,
+	}, nil
+}
+
+// WithDropReporter returns a DeferredHandler sharing the same buffers that reports every record
+// dropped because a buffer reached its limit to reporter with ReasonBufferFull. Records dropped
+// by Discard are not reported. A nil reporter disables reporting.
+func (h *DeferredHandler) WithDropReporter(reporter DropReporter) *DeferredHandler {
+	h2 := *h
+	h2.reporter = reporter
+	return &h2
+}
+
+// Begin returns a context whose records are buffered until Commit or Discard is called.
+//
+// The buffer is discarded automatically when ctx is canceled, or right away if ctx is already
+// canceled.
+func (h *DeferredHandler) Begin(ctx context.Context) context.Context {
+	buf := &deferredBuffer{}
+	if ctx.Err() != nil {
+		buf.discarded = true
+		return context.WithValue(ctx, h.key, buf)
+	}
+
+	// discard may run in another goroutine as soon as it is registered, so stop is assigned
+	// under the lock discard takes.
+	buf.mu.Lock()
+	buf.stop = context.AfterFunc(ctx, buf.discard)
+	buf.mu.Unlock()
+	return context.WithValue(ctx, h.key, buf)
+}
+
+// Commit flushes the records buffered for ctx to the next handler, in the order they were
+// logged. Records logged with ctx after Commit are passed through directly.
+//
+// Errors returned by the next handler are joined. Commit is a no-op if ctx was not prepared
+// by Begin or its buffer was already discarded.
+func (h *DeferredHandler) Commit(ctx context.Context) error {
+	buf := h.buffer(ctx)
+	if buf == nil {
+		return nil
+	}
+
+	buf.mu.Lock()
+	if buf.discarded || buf.committed {
+		buf.mu.Unlock()
+		return nil
+	}
+	buf.committed = true
+	entries := buf.entries
+	buf.entries = nil
+	buf.stopDiscard()
+	buf.mu.Unlock()
+
+	var errs []error
+	for _, e := range entries {
+		if err := e.next.Handle(e.ctx, e.record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Discard drops the records buffered for ctx. Records logged with ctx after Discard are dropped too.
+func (h *DeferredHandler) Discard(ctx context.Context) {
+	if buf := h.buffer(ctx); buf != nil {
+		buf.discard()
+	}
+}
+
+// Pending returns the number of records currently buffered for ctx.
+func (h *DeferredHandler) Pending(ctx context.Context) int {
+	buf := h.buffer(ctx)
+	if buf == nil {
+		return 0
+	}
+
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+	return len(buf.entries)
+}
+
+func (h *DeferredHandler) buffer(ctx context.Context) *deferredBuffer {
+	buf, _ := ctx.Value(h.key).(*deferredBuffer)
+	return buf
+}
+
+func (b *deferredBuffer) discard() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.committed {
+		return
+	}
+	b.discarded = true
+	b.entries = nil
+	b.stopDiscard()
+}
+
+// stopDiscard unregisters the discarding of b when the Begin context is canceled. b.mu must be held.
+func (b *deferredBuffer) stopDiscard() {
+	if b.stop != nil {
+		b.stop()
+	}
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *DeferredHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle buffers r if ctx was prepared by Begin, and passes it to the next handler otherwise.
+func (h *DeferredHandler) Handle(ctx context.Context, r slog.Record) error {
+	buf := h.buffer(ctx)
+	if buf == nil {
+		return h.next.Handle(ctx, r)
+	}
+
+	buf.mu.Lock()
+	switch {
+	case buf.committed:
+		buf.mu.Unlock()
+		return h.next.Handle(ctx, r)
+	case buf.discarded:
+		buf.mu.Unlock()
+		return nil
+	}
+
+	full := h.limit > 0 && len(buf.entries) >= h.limit
+	var droppedLevel slog.Level
+	if full {
+		droppedLevel = buf.entries[0].record.Level
+		buf.entries = append(buf.entries[:0], buf.entries[1:]...)
+	}
+	// The record must outlive the call, so it is cloned.
+	buf.entries = append(buf.entries, deferredEntry{next: h.next, ctx: ctx, record: r.Clone()})
+	buf.mu.Unlock()
+
+	if full && h.reporter != nil {
+		h.reporter.Dropped(droppedLevel, ReasonBufferFull, 1)
+	}
+	return nil
+}
+
+// WithAttrs returns a DeferredHandler sharing the same buffers whose next handler has the given attributes.
+func (h *DeferredHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a DeferredHandler sharing the same buffers whose next handler has the given group.
+func (h *DeferredHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *DeferredHandler) unwrap() slog.Handler {
+	return h.next
+}
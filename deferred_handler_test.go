@@ -0,0 +1,193 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferredHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		finish    func(h *DeferredHandler, ctx context.Context)
+		wantMsgs  []string
+		afterMsgs []string
+	}{
+		{
+			name:      "commit flushes in order and passes later records through",
+			finish:    func(h *DeferredHandler, ctx context.Context) { require.NoError(t, h.Commit(ctx)) },
+			wantMsgs:  []string{"one", "two", "three"},
+			afterMsgs: []string{"one", "two", "three", "after"},
+		},
+		{
+			name:      "discard drops buffered and later records",
+			finish:    func(h *DeferredHandler, ctx context.Context) { h.Discard(ctx) },
+			wantMsgs:  nil,
+			afterMsgs: nil,
+		},
+		{
+			name: "commit after discard is a no-op",
+			finish: func(h *DeferredHandler, ctx context.Context) {
+				h.Discard(ctx)
+				require.NoError(t, h.Commit(ctx))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			h := NewDeferredHandler(next, 0)
+			logger := New(NewHandler(h))
+
+			ctx := h.Begin(context.Background())
+			logger.InfoContext(ctx, "one")
+			logger.WarnContext(ctx, "two")
+			logger.ErrorContext(ctx, "three")
+
+			assert.Equal(t, 0, next.recordCount(), "records must be held until commit")
+			assert.Equal(t, 3, h.Pending(ctx))
+
+			tt.finish(h, ctx)
+			assert.Equal(t, tt.wantMsgs, messages(next.getRecords()))
+
+			logger.InfoContext(ctx, "after")
+			assert.Equal(t, tt.afterMsgs, messages(next.getRecords()))
+			assert.Equal(t, 0, h.Pending(ctx))
+		})
+	}
+}
+
+func messages(records []slog.Record) []string {
+	var msgs []string
+	for _, r := range records {
+		msgs = append(msgs, r.Message)
+	}
+	return msgs
+}
+
+func TestDeferredHandler_PassThroughWithoutBegin(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewDeferredHandler(next, 0)
+
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "direct", 0)))
+	assert.Equal(t, []string{"direct"}, messages(next.getRecords()))
+	assert.NoError(t, h.Commit(context.Background()))
+	assert.Equal(t, 0, h.Pending(context.Background()))
+}
+
+func TestDeferredHandler_AutoDiscardOnCancel(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewDeferredHandler(next, 0)
+
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := h.Begin(parent)
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "lost", 0)))
+
+	cancel()
+	assert.Eventually(t, func() bool { return h.Pending(ctx) == 0 }, time.Second, time.Millisecond)
+
+	require.NoError(t, h.Commit(ctx))
+	assert.Equal(t, 0, next.recordCount())
+}
+
+func TestDeferredHandler_BeginCanceledContext(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewDeferredHandler(next, 0)
+
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx := h.Begin(parent)
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "lost", 0)))
+
+	assert.Equal(t, 0, h.Pending(ctx))
+	require.NoError(t, h.Commit(ctx))
+	assert.Equal(t, 0, next.recordCount())
+}
+
+func TestDeferredHandler_CommitBeforeCancel(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewDeferredHandler(next, 0)
+
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := h.Begin(parent)
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "kept", 0)))
+
+	require.NoError(t, h.Commit(ctx))
+	cancel()
+	assert.Equal(t, []string{"kept"}, messages(next.getRecords()))
+}
+
+func TestDeferredHandler_Limit(t *testing.T) {
+	next := newTestHandler(true)
+	reporter := newDropCounter()
+	h := NewDeferredHandler(next, 2).WithDropReporter(reporter)
+
+	ctx := h.Begin(context.Background())
+	levels := []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelInfo, slog.LevelWarn}
+	for i, msg := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), levels[i], msg, 0)))
+	}
+
+	require.NoError(t, h.Commit(ctx))
+	assert.Equal(t, []string{"c", "d"}, messages(next.getRecords()))
+	assert.Equal(t, map[string]int{"DEBUG buffer_full": 1, "INFO buffer_full": 1}, reporter.get())
+
+	ctx = h.Begin(context.Background())
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "discarded", 0)))
+	h.Discard(ctx)
+	assert.Equal(t, map[string]int{"DEBUG buffer_full": 1, "INFO buffer_full": 1}, reporter.get(), "discarded records are not reported")
+}
+
+func TestDeferredHandler_IsolatesRequests(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewDeferredHandler(next, 0)
+
+	failed := h.Begin(context.Background())
+	succeeded := h.Begin(context.Background())
+	require.NoError(t, h.Handle(failed, slog.NewRecord(time.Now(), slog.LevelInfo, "failed", 0)))
+	require.NoError(t, h.Handle(succeeded, slog.NewRecord(time.Now(), slog.LevelInfo, "succeeded", 0)))
+
+	h.Discard(succeeded)
+	require.NoError(t, h.Commit(failed))
+	assert.Equal(t, []string{"failed"}, messages(next.getRecords()))
+}
+
+func TestDeferredHandler_CommitJoinsErrors(t *testing.T) {
+	next := newTestHandler(true)
+	next.err = errors.New("sink down")
+	h := NewDeferredHandler(next, 0)
+
+	ctx := h.Begin(context.Background())
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "m", 0)))
+
+	assert.ErrorContains(t, h.Commit(ctx), "sink down")
+}
+
+func TestDeferredHandler_WithAttrsAndGroup(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewDeferredHandler(slog.NewJSONHandler(buf, nil), 0)
+	logger := slog.New(h).With("a", 1).WithGroup("g")
+
+	ctx := h.Begin(context.Background())
+	logger.InfoContext(ctx, "m", "k", "v")
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, h.Commit(ctx))
+	assert.Contains(t, buf.String(), `"a":1,"g":{"k":"v"}`)
+	assert.Same(t, h, h.WithGroup(""))
+}
+
+func TestNewDeferredHandler_NilPanic(t *testing.T) {
+	assert.Panics(t, func() { NewDeferredHandler(nil, 0) })
+
+	h, err := NewDeferredHandlerE(nil, 0)
+	assert.ErrorIs(t, err, ErrNilNextHandler)
+	assert.Nil(t, h)
+}
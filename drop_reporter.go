@@ -0,0 +1,44 @@
+package slogs
+
+import "log/slog"
+
+// Reasons passed to a DropReporter by the handlers of this package.
+const (
+	// ReasonBufferFull is reported when a record is dropped because a buffer is full.
+	ReasonBufferFull = "buffer_full"
+	// ReasonSampled is reported when a record is dropped by sampling.
+	ReasonSampled = "sampled"
+	// ReasonRateLimited is reported when a record is dropped because a rate limit was exceeded.
+	ReasonRateLimited = "rate_limited"
+	// ReasonConcurrencyLimit is reported when a record is dropped because too many records
+	// were being handled concurrently.
+	ReasonConcurrencyLimit = "concurrency_limit"
+	// ReasonReplayFailed is reported when a spilled record is dropped because the primary
+	// handler failed to handle it too many times.
+	ReasonReplayFailed = "replay_failed"
+)
+
+// DropReporter is notified of the records dropped by the volume-control handlers of this
+// package, so that a single implementation, e.g. a Prometheus counter labeled by level and
+// reason, can count drops across the whole pipeline. It is accepted by:
+//   - AsyncHandler.WithDropReporter and DeferredHandler.WithDropReporter, with ReasonBufferFull
+//   - SamplingHandler.WithDropReporter and TraceSamplingHandler.WithDropReporter, with ReasonSampled
+//   - GlobalRateLimitHandler.WithDropReporter, with ReasonRateLimited
+//   - Handler.WithMaxConcurrentHandles, with ReasonConcurrencyLimit
+//   - SpillOptions.DropReporter, with ReasonReplayFailed
+//
+// Dropped is called synchronously from Handle and must be safe for concurrent use; it should
+// not block.
+type DropReporter interface {
+	// Dropped reports that n records at the given level were dropped for reason, one of the
+	// Reason constants.
+	Dropped(level slog.Level, reason string, n int)
+}
+
+// DropReporterFunc is an adapter to use an ordinary function as a DropReporter.
+type DropReporterFunc func(level slog.Level, reason string, n int)
+
+// Dropped calls f(level, reason, n).
+func (f DropReporterFunc) Dropped(level slog.Level, reason string, n int) {
+	f(level, reason, n)
+}
@@ -0,0 +1,51 @@
+package slogs
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dropCounter is a DropReporter counting drops by level and reason.
+type dropCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newDropCounter() *dropCounter {
+	return &dropCounter{counts: make(map[string]int)}
+}
+
+func (c *dropCounter) Dropped(level slog.Level, reason string, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[level.String()+" "+reason] += n
+}
+
+func (c *dropCounter) get() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[string]int, len(c.counts))
+	for k, n := range c.counts {
+		counts[k] = n
+	}
+	return counts
+}
+
+func TestDropReporterFunc(t *testing.T) {
+	var gotLevel slog.Level
+	var gotReason string
+	var gotN int
+	var r DropReporter = DropReporterFunc(func(level slog.Level, reason string, n int) {
+		gotLevel, gotReason, gotN = level, reason, n
+	})
+
+	r.Dropped(slog.LevelWarn, ReasonBufferFull, 3)
+
+	assert.Equal(t, slog.LevelWarn, gotLevel)
+	assert.Equal(t, ReasonBufferFull, gotReason)
+	assert.Equal(t, 3, gotN)
+}
@@ -0,0 +1,68 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Embed is a base type for domain-specific loggers, such as an AuditLogger with its own
+// logging methods. It is obtained from Logger.Wrap.
+//
+// Its logging methods report the caller of the method that called them, so that a record
+// logged by AuditLogger.Access points to the business code calling Access rather than to
+// Access itself.
+//
+// Example:
+//
+//	type AuditLogger struct {
+//		slogs.Embed
+//	}
+//
+//	func NewAuditLogger(l *slogs.Logger) *AuditLogger {
+//		return &AuditLogger{Embed: l.Named("audit").Wrap()}
+//	}
+//
+//	func (a *AuditLogger) Access(ctx context.Context, user, resource string) {
+//		a.LogAttrs(ctx, slog.LevelInfo, "access", slog.String("user", user), slog.String("resource", resource))
+//	}
+//
+// The zero Embed is not usable.
+type Embed struct {
+	// logger skips the frame of the domain logger method.
+	logger *Logger
+}
+
+// Wrap returns an Embed logging through l, to be embedded in a domain-specific logger.
+//
+// Methods of the domain logger calling Embed directly report their own caller. Methods
+// calling Embed through a helper must add the helper frames with WithCallerSkip on l.
+func (l *Logger) Wrap() Embed {
+	return Embed{logger: l.WithOptions(WithCallerSkip(1))}
+}
+
+// Logger returns the Logger e was created from, e.g. to derive loggers with With or Named.
+func (e Embed) Logger() *Logger {
+	return e.logger.WithOptions(WithCallerSkip(-1))
+}
+
+// Enabled reports whether records at the given level are emitted.
+//
+// If ctx is nil, context.Background() is used.
+func (e Embed) Enabled(ctx context.Context, level slog.Level) bool {
+	return e.logger.Enabled(ctx, level)
+}
+
+// Log emits a record at the given level with the given key-value pairs, reporting the caller
+// of the method calling Log.
+//
+// If ctx is nil, context.Background() is used.
+func (e Embed) Log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	e.logger.log(ctx, level, msg, args...)
+}
+
+// LogAttrs is a more efficient version of Log accepting only attributes.
+//
+// If ctx is nil, context.Background() is used.
+func (e Embed) LogAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	e.logger.logAttrs(ctx, level, msg, attrs...)
+}
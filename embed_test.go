@@ -0,0 +1,100 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditLogger is a domain logger built on Embed, as documented.
+type auditLogger struct {
+	Embed
+}
+
+func (a *auditLogger) Access(ctx context.Context, user string) {
+	a.LogAttrs(ctx, slog.LevelInfo, "access", slog.String("user", user))
+}
+
+func (a *auditLogger) Denied(ctx context.Context, user string) {
+	a.Log(ctx, slog.LevelWarn, "denied", "user", user)
+}
+
+// callerFunction returns the name of the function calling it.
+func callerFunction(t *testing.T) string {
+	t.Helper()
+	pc, _, _, ok := runtime.Caller(1)
+	require.True(t, ok)
+	return runtime.FuncForPC(pc).Name()
+}
+
+func TestLogger_Wrap(t *testing.T) {
+	tests := []struct {
+		name    string
+		log     func(a *auditLogger)
+		wantMsg string
+	}{
+		{
+			name:    "LogAttrs",
+			log:     func(a *auditLogger) { a.Access(context.Background(), "alice") },
+			wantMsg: "[audit] access",
+		},
+		{
+			name:    "Log",
+			log:     func(a *auditLogger) { a.Denied(nil, "bob") },
+			wantMsg: "[audit] denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true}))
+			a := &auditLogger{Embed: New(h, WithCaller(true)).Named("audit").Wrap()}
+
+			tt.log(a)
+
+			var got struct {
+				Msg    string `json:"msg"`
+				Source struct {
+					Function string `json:"function"`
+				} `json:"source"`
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tt.wantMsg, got.Msg)
+			// The caller is the closure in the test table, not the auditLogger method.
+			assert.Contains(t, got.Source.Function, "TestLogger_Wrap.func")
+			assert.NotContains(t, got.Source.Function, "auditLogger")
+		})
+	}
+}
+
+func TestEmbed_Logger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true}))
+	e := New(h, WithCaller(true)).Wrap()
+
+	e.Logger().Info("direct")
+	want := callerFunction(t)
+
+	var got struct {
+		Source struct {
+			Function string `json:"function"`
+		} `json:"source"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, want, got.Source.Function)
+}
+
+func TestEmbed_Enabled(t *testing.T) {
+	h := NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	e := New(h).Wrap()
+
+	assert.True(t, e.Enabled(context.Background(), slog.LevelInfo))
+	assert.False(t, e.Enabled(context.Background(), slog.LevelDebug))
+}
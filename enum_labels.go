@@ -0,0 +1,101 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"math"
+	"reflect"
+	"time"
+)
+
+// EnumLabelOptions configures Handler.WithEnumLabels.
+type EnumLabelOptions struct {
+	// ValueKey, if set, is the key of an attribute added next to each labeled attribute to keep
+	// its numeric value, e.g. "status_code".
+	ValueKey string
+	// Fallback, if set, is the label of the values missing from the labels, e.g. "unknown".
+	// Otherwise they are left unchanged.
+	Fallback string
+}
+
+// WithEnumLabels returns a new Handler that replaces the integer values of the attributes with
+// the given key by their labels, so that status codes and enums logged as numbers are readable,
+// e.g. "state":"running" rather than "state":2. opts may be nil.
+//
+// The key is matched at every level, including inside groups. Values of any signed or unsigned
+// integer type are replaced, including named types such as `type State int` logged as is;
+// other values are left unchanged. Call WithEnumLabels once per key to label several keys.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithEnumLabels("state", map[int64]string{
+//		0: "pending",
+//		1: "running",
+//		2: "done",
+//	}, &slogs.EnumLabelOptions{ValueKey: "state_code", Fallback: "unknown"})
+//	slog.New(handler).Info("job", "state", 1) // {"msg":"job","state":"running","state_code":1}
+func (h *Handler) WithEnumLabels(key string, labels map[int64]string, opts *EnumLabelOptions) *Handler {
+	if opts == nil {
+		opts = &EnumLabelOptions{}
+	}
+	labels = maps.Clone(labels)
+	valueKey, fallback := opts.ValueKey, opts.Fallback
+
+	var label func(attrs []slog.Attr) []slog.Attr
+	label = func(attrs []slog.Attr) []slog.Attr {
+		out := make([]slog.Attr, 0, len(attrs))
+		for _, a := range attrs {
+			a.Value = a.Value.Resolve()
+			if a.Value.Kind() == slog.KindGroup {
+				a.Value = slog.GroupValue(label(a.Value.Group())...)
+				out = append(out, a)
+				continue
+			}
+
+			if a.Key == key {
+				if n, ok := enumValue(a.Value); ok {
+					text, found := labels[n]
+					if !found {
+						text = fallback
+					}
+					if text != "" {
+						out = append(out, slog.String(a.Key, text))
+						if valueKey != "" {
+							out = append(out, slog.Int64(valueKey, n))
+						}
+						continue
+					}
+				}
+			}
+			out = append(out, a)
+		}
+		return out
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, label(attrs)
+	})
+}
+
+// enumValue returns the resolved value v as an int64 if it is an integer that fits in one.
+func enumValue(v slog.Value) (int64, bool) {
+	switch v.Kind() {
+	case slog.KindInt64:
+		return v.Int64(), true
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return int64(u), true
+		}
+	case slog.KindAny:
+		switch rv := reflect.ValueOf(v.Any()); rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int(), true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if u := rv.Uint(); u <= math.MaxInt64 {
+				return int64(u), true
+			}
+		}
+	}
+	return 0, false
+}
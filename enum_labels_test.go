@@ -0,0 +1,120 @@
+package slogs
+
+import (
+	"bytes"
+	"log/slog"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jobState is an enum logged as is.
+type jobState uint8
+
+func TestHandler_WithEnumLabels(t *testing.T) {
+	states := map[int64]string{0: "pending", 1: "running", 2: "done"}
+
+	tests := []struct {
+		name     string
+		opts     *EnumLabelOptions
+		log      func(l *slog.Logger)
+		expected string
+	}{
+		{
+			name:     "labeled",
+			log:      func(l *slog.Logger) { l.Info("m", "state", 1) },
+			expected: `{"level":"INFO","msg":"m","state":"running"}`,
+		},
+		{
+			name:     "unlabeled left unchanged",
+			log:      func(l *slog.Logger) { l.Info("m", "state", 7) },
+			expected: `{"level":"INFO","msg":"m","state":7}`,
+		},
+		{
+			name:     "unlabeled with fallback",
+			opts:     &EnumLabelOptions{Fallback: "unknown"},
+			log:      func(l *slog.Logger) { l.Info("m", "state", 7) },
+			expected: `{"level":"INFO","msg":"m","state":"unknown"}`,
+		},
+		{
+			name:     "value kept",
+			opts:     &EnumLabelOptions{ValueKey: "state_code", Fallback: "unknown"},
+			log:      func(l *slog.Logger) { l.Info("m", "state", 2, "n", 1) },
+			expected: `{"level":"INFO","msg":"m","state":"done","state_code":2,"n":1}`,
+		},
+		{
+			name:     "unlabeled value kept",
+			opts:     &EnumLabelOptions{ValueKey: "state_code", Fallback: "unknown"},
+			log:      func(l *slog.Logger) { l.Info("m", "state", -1) },
+			expected: `{"level":"INFO","msg":"m","state":"unknown","state_code":-1}`,
+		},
+		{
+			name:     "named integer type",
+			log:      func(l *slog.Logger) { l.Info("m", "state", jobState(2)) },
+			expected: `{"level":"INFO","msg":"m","state":"done"}`,
+		},
+		{
+			name:     "unsigned",
+			log:      func(l *slog.Logger) { l.Info("m", "state", uint64(1)) },
+			expected: `{"level":"INFO","msg":"m","state":"running"}`,
+		},
+		{
+			name:     "unsigned overflowing int64",
+			opts:     &EnumLabelOptions{Fallback: "unknown"},
+			log:      func(l *slog.Logger) { l.Info("m", "state", uint64(math.MaxUint64)) },
+			expected: `{"level":"INFO","msg":"m","state":18446744073709551615}`,
+		},
+		{
+			name:     "non-integer unchanged",
+			opts:     &EnumLabelOptions{Fallback: "unknown"},
+			log:      func(l *slog.Logger) { l.Info("m", "state", "running", "other", 1) },
+			expected: `{"level":"INFO","msg":"m","state":"running","other":1}`,
+		},
+		{
+			name:     "nested groups",
+			opts:     &EnumLabelOptions{ValueKey: "state_code"},
+			log:      func(l *slog.Logger) { l.WithGroup("g").Info("m", slog.Group("job", "state", 0)) },
+			expected: `{"level":"INFO","msg":"m","g":{"job":{"state":"pending","state_code":0}}}`,
+		},
+		{
+			name:     "attributes from With",
+			log:      func(l *slog.Logger) { l.With("state", 2).Info("m") },
+			expected: `{"level":"INFO","msg":"m","state":"done"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+				WithEnumLabels("state", states, tt.opts)
+
+			tt.log(slog.New(h))
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestHandler_WithEnumLabels_MultipleKeys(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+		WithEnumLabels("state", map[int64]string{1: "running"}, nil).
+		WithEnumLabels("status", map[int64]string{200: "OK", 404: "Not Found"}, &EnumLabelOptions{ValueKey: "status_code"})
+
+	slog.New(h).Info("m", "state", 1, "status", 404)
+
+	assert.Equal(t, `{"level":"INFO","msg":"m","state":"running","status":"Not Found","status_code":404}`+"\n", buf.String())
+}
+
+func TestHandler_WithEnumLabels_LabelsCopied(t *testing.T) {
+	var buf bytes.Buffer
+	labels := map[int64]string{1: "running"}
+	h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithEnumLabels("state", labels, nil)
+	labels[1] = "changed"
+
+	slog.New(h).Info("m", "state", 1)
+
+	assert.Equal(t, `{"level":"INFO","msg":"m","state":"running"}`+"\n", buf.String())
+}
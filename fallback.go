@@ -0,0 +1,74 @@
+package slogs
+
+import (
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rockcookies/go-slogs/internal/bufferpool"
+)
+
+const (
+	// fallbackLimit is the maximum number of fallback lines written per fallbackInterval.
+	fallbackLimit = 10
+	// fallbackInterval is the window over which fallbackLimit applies.
+	fallbackInterval = time.Second
+)
+
+// fallbackWriter writes a minimal text line for records whose handler failed.
+//
+// Output is rate limited so that a persistently failing sink cannot flood the fallback
+// destination; lines over the limit are counted and reported once the window rolls over.
+type fallbackWriter struct {
+	w io.Writer
+
+	mu          sync.Mutex
+	windowStart time.Time
+	written     int
+	suppressed  int
+}
+
+func newFallbackWriter(w io.Writer) *fallbackWriter {
+	return &fallbackWriter{w: w}
+}
+
+// write reports that r could not be handled because of err.
+func (f *fallbackWriter) write(now time.Time, r slog.Record, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	if now.Sub(f.windowStart) >= fallbackInterval {
+		if f.suppressed > 0 {
+			buf.AppendString(now.Format(time.RFC3339))
+			buf.AppendString(" WARN slogs: suppressed ")
+			buf.AppendInt(int64(f.suppressed))
+			buf.AppendString(" fallback lines\n")
+		}
+		f.windowStart = now
+		f.written = 0
+		f.suppressed = 0
+	}
+
+	if f.written >= fallbackLimit {
+		f.suppressed++
+		return
+	}
+	f.written++
+
+	buf.AppendString(r.Time.Format(time.RFC3339))
+	buf.AppendByte(' ')
+	buf.AppendString(r.Level.String())
+	buf.AppendByte(' ')
+	buf.AppendString(strconv.Quote(r.Message))
+	buf.AppendString(" handler_error=")
+	buf.AppendString(strconv.Quote(err.Error()))
+	buf.AppendByte('\n')
+
+	// There is nowhere left to report a failure of the last-resort writer.
+	_, _ = f.w.Write(buf.Bytes())
+}
@@ -0,0 +1,117 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallbackWriter_Write(t *testing.T) {
+	buf := &bytes.Buffer{}
+	f := newFallbackWriter(buf)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := slog.NewRecord(now, slog.LevelError, "disk full", 0)
+	f.write(now, r, errors.New("sink down"))
+
+	assert.Equal(t, "2024-01-02T03:04:05Z ERROR \"disk full\" handler_error=\"sink down\"\n", buf.String())
+}
+
+func TestFallbackWriter_RateLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		writes         int
+		advance        time.Duration
+		wantLines      int
+		wantSuppressed string
+	}{
+		{name: "under limit", writes: fallbackLimit, wantLines: fallbackLimit},
+		{name: "over limit is suppressed", writes: fallbackLimit + 5, wantLines: fallbackLimit},
+		{
+			name:           "suppressed count reported in next window",
+			writes:         fallbackLimit + 5,
+			advance:        fallbackInterval,
+			wantLines:      fallbackLimit + 2,
+			wantSuppressed: "suppressed 5 fallback lines",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			f := newFallbackWriter(buf)
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			r := slog.NewRecord(now, slog.LevelInfo, "msg", 0)
+
+			for i := 0; i < tt.writes; i++ {
+				f.write(now, r, errors.New("fail"))
+			}
+			if tt.advance > 0 {
+				f.write(now.Add(tt.advance), r, errors.New("fail"))
+			}
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			assert.Len(t, lines, tt.wantLines)
+			if tt.wantSuppressed != "" {
+				assert.Contains(t, buf.String(), tt.wantSuppressed)
+			}
+		})
+	}
+}
+
+func TestWithFallbackWriter(t *testing.T) {
+	tests := []struct {
+		name       string
+		handlerErr error
+		want       []string
+	}{
+		{
+			name:       "writes on handler error",
+			handlerErr: errors.New("sink down"),
+			want: []string{
+				`INFO "lost" handler_error="sink down"`,
+				`WARN "lost 2"`,
+				`ERROR "lost attrs"`,
+			},
+		},
+		{
+			name:       "no output when handler succeeds",
+			handlerErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			next.err = tt.handlerErr
+
+			buf := &bytes.Buffer{}
+			logger := New(NewHandler(next), WithFallbackWriter(buf))
+
+			logger.Info("lost", "k", "v")
+			logger.Sugar().Warnf("lost %d", 2)
+			logger.LogAttrs(context.Background(), slog.LevelError, "lost attrs")
+
+			if len(tt.want) == 0 {
+				assert.Empty(t, buf.String())
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestWithStderrFallback(t *testing.T) {
+	logger := New(NewHandler(newTestHandler(true)), WithStderrFallback(true))
+	assert.NotNil(t, logger.fallback)
+
+	logger = logger.WithOptions(WithStderrFallback(false))
+	assert.Nil(t, logger.fallback)
+}
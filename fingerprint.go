@@ -0,0 +1,92 @@
+package slogs
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FingerprintKey is the key of the attribute added by Handler.WithFingerprint.
+const FingerprintKey = "_fingerprint"
+
+// messageTemplateKey is the context key for the Sprintf template of the record being handled.
+type messageTemplateKey struct{}
+
+// MessageTemplate returns the Sprintf template of the record being handled, e.g.
+// "user %s not found", or an empty string if the record was not logged with one of the
+// formatting methods of SugaredLogger, such as Infof.
+//
+// It lets handlers group records by call site even though their messages embed variable values.
+func MessageTemplate(ctx context.Context) string {
+	if v, ok := ctx.Value(messageTemplateKey{}).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// WithFingerprint returns a new Handler that adds a FingerprintKey attribute to every record,
+// so that alerting systems grouping by fingerprint group records of the same kind together.
+//
+// fn computes the fingerprint from the processed record. A nil fn uses the default
+// fingerprint: a 64-bit FNV-1a hash, in hexadecimal, of the message template and of the
+// types of the errors in the attributes. The template is MessageTemplate for records logged
+// with the formatting methods of SugaredLogger, such as Errorf, and the message otherwise; the
+// other attribute values are ignored. Records logged from the same call site with errors of
+// the same types thus share a fingerprint, whatever IDs their messages embed.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithFingerprint(nil)
+//	sugar := slogs.New(handler).Sugar()
+//	sugar.Errorf("order %d failed", id) // same _fingerprint for every order
+func (h *Handler) WithFingerprint(fn func(r slog.Record) string) *Handler {
+	return h.use(func(ctx context.Context, _ *HandlerContext, rt time.Time, rl slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		var fp string
+		if fn != nil {
+			r := slog.NewRecord(rt, rl, rm, 0)
+			r.AddAttrs(attrs...)
+			fp = fn(r)
+		} else {
+			template := MessageTemplate(ctx)
+			if template == "" {
+				template = rm
+			}
+			fp = defaultFingerprint(template, attrs)
+		}
+
+		return rm, append(attrs, slog.String(FingerprintKey, fp))
+	})
+}
+
+// defaultFingerprint hashes template and the sorted types of the errors in attrs.
+func defaultFingerprint(template string, attrs []slog.Attr) string {
+	types := errorTypes(nil, attrs)
+	sort.Strings(types)
+
+	hash := fnv.New64a()
+	hash.Write([]byte(template))
+	for _, t := range types {
+		hash.Write([]byte{0})
+		hash.Write([]byte(t))
+	}
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+// errorTypes appends the types of the errors in attrs, recursing into groups, to types.
+func errorTypes(types []string, attrs []slog.Attr) []string {
+	for _, a := range attrs {
+		switch v := a.Value; v.Kind() {
+		case slog.KindGroup:
+			types = errorTypes(types, v.Group())
+		case slog.KindAny:
+			if err, ok := v.Any().(error); ok {
+				types = append(types, fmt.Sprintf("%T", err))
+			}
+		}
+	}
+	return types
+}
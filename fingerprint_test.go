@@ -0,0 +1,126 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fingerprintOf returns the fingerprint attribute of r.
+func fingerprintOf(t *testing.T, r slog.Record) string {
+	t.Helper()
+	var fp string
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == FingerprintKey {
+			fp = a.Value.String()
+		}
+		return true
+	})
+	require.NotEmpty(t, fp)
+	return fp
+}
+
+func TestHandler_WithFingerprint(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "/x", Err: fs.ErrNotExist}
+
+	tests := []struct {
+		name string
+		a, b func(l *Logger)
+		same bool
+	}{
+		{
+			name: "same template with different values",
+			a:    func(l *Logger) { l.Sugar().Errorf("order %d failed", 1) },
+			b:    func(l *Logger) { l.Sugar().Errorf("order %d failed", 2) },
+			same: true,
+		},
+		{
+			name: "different templates",
+			a:    func(l *Logger) { l.Sugar().Errorf("order %d failed", 1) },
+			b:    func(l *Logger) { l.Sugar().Errorf("payment %d failed", 1) },
+			same: false,
+		},
+		{
+			name: "other attributes are ignored",
+			a:    func(l *Logger) { l.Error("failed", "id", 1, "err", errors.New("a")) },
+			b:    func(l *Logger) { l.Error("failed", "id", 2, "err", errors.New("b")) },
+			same: true,
+		},
+		{
+			name: "error types are included",
+			a:    func(l *Logger) { l.Error("failed", "err", errors.New("a")) },
+			b:    func(l *Logger) { l.Error("failed", "err", pathErr) },
+			same: false,
+		},
+		{
+			name: "errors in groups",
+			a:    func(l *Logger) { l.Error("failed", slog.Group("cause", "err", pathErr)) },
+			b:    func(l *Logger) { l.WithGroup("cause").Error("failed", "err", pathErr) },
+			same: true,
+		},
+		{
+			name: "plain messages differ",
+			a:    func(l *Logger) { l.Error("order 1 failed") },
+			b:    func(l *Logger) { l.Error("order 2 failed") },
+			same: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			logger := New(NewHandler(next).WithFingerprint(nil))
+
+			tt.a(logger)
+			tt.b(logger)
+
+			records := next.getRecords()
+			require.Len(t, records, 2)
+			if tt.same {
+				assert.Equal(t, fingerprintOf(t, records[0]), fingerprintOf(t, records[1]))
+			} else {
+				assert.NotEqual(t, fingerprintOf(t, records[0]), fingerprintOf(t, records[1]))
+			}
+		})
+	}
+}
+
+func TestHandler_WithFingerprint_Stable(t *testing.T) {
+	next := newTestHandler(true)
+	New(NewHandler(next).WithFingerprint(nil)).Sugar().Errorf("order %d failed", 42)
+
+	// The default fingerprint must not change across releases, or alerts would regroup.
+	assert.Equal(t, defaultFingerprint("order %d failed", nil), fingerprintOf(t, next.getRecords()[0]))
+	assert.Equal(t, "56d91174c1fad1e9", defaultFingerprint("order %d failed", nil))
+}
+
+func TestHandler_WithFingerprint_Custom(t *testing.T) {
+	next := newTestHandler(true)
+	fn := func(r slog.Record) string { return r.Level.String() + ":" + r.Message }
+	New(NewHandler(next).WithFingerprint(fn)).Warn("disk full", "pct", 99)
+
+	assert.Equal(t, "WARN:disk full", fingerprintOf(t, next.getRecords()[0]))
+}
+
+func TestMessageTemplate(t *testing.T) {
+	var got []string
+	capture := NewHandlerWithOptions(newTestHandler(true), &HandlerOptions{
+		HandleFunc: func(ctx context.Context, hc *HandlerContext, rt time.Time, rl slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+			got = append(got, MessageTemplate(ctx))
+			return rm, attrs
+		},
+	})
+	sugar := New(capture).Sugar()
+
+	sugar.Infof("user %s", "alice")
+	sugar.Infof("no args")
+	sugar.Info("sprint", 1)
+
+	assert.Equal(t, []string{"user %s", "", ""}, got)
+}
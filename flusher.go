@@ -0,0 +1,142 @@
+package slogs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+var (
+	_ Closer    = (*PeriodicFlusher)(nil)
+	_ io.Writer = (*BufferedWriter)(nil)
+)
+
+// BufferedWriter is a bufio.Writer guarded by a mutex, so that Flush can be called from a
+// PeriodicFlusher while handlers write to it. A bare bufio.Writer is not safe for concurrent use.
+//
+// Example:
+//
+//	buffered := slogs.NewBufferedWriter(file, 0)
+//	logger := slogs.New(slogs.NewHandler(slog.NewJSONHandler(buffered, nil)))
+//	registry.Register(slogs.NewPeriodicFlusher(buffered.Flush, nil, time.Second))
+type BufferedWriter struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+// NewBufferedWriter creates a BufferedWriter writing to w through a buffer of the given size,
+// or of bufio's default size if size <= 0.
+func NewBufferedWriter(w io.Writer, size int) *BufferedWriter {
+	if size <= 0 {
+		return &BufferedWriter{w: bufio.NewWriter(w)}
+	}
+	return &BufferedWriter{w: bufio.NewWriterSize(w, size)}
+}
+
+// Write writes p to the buffer, writing the buffer to the underlying writer when it is full.
+func (b *BufferedWriter) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.w.Write(p)
+}
+
+// Flush writes the buffered data to the underlying writer.
+func (b *BufferedWriter) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.w.Flush()
+}
+
+// PeriodicFlusher calls a flush function at a fixed interval, bounding how long output can
+// stay in a buffered writer such as a BufferedWriter during idle periods.
+//
+// It starts when created and stops when closed; register it with a Registry to tie it to the
+// application's shutdown. The flush function is called from a background goroutine, so it
+// must be safe to call concurrently with writes to the buffered writer; use a BufferedWriter
+// rather than a bare bufio.Writer.
+//
+// Example:
+//
+//	buffered := slogs.NewBufferedWriter(file, 0)
+//	flusher := slogs.NewPeriodicFlusher(buffered.Flush, nil, time.Second)
+//	registry.Register(flusher)
+type PeriodicFlusher struct {
+	flush func() error
+
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewPeriodicFlusher creates a PeriodicFlusher that calls flush every interval, measured
+// with the ticker of clock, or DefaultClock if clock is nil.
+//
+// Panics if flush is nil.
+func NewPeriodicFlusher(flush func() error, clock Clock, interval time.Duration) *PeriodicFlusher {
+	if flush == nil {
+		panic("slogs: flush function cannot be nil")
+	}
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	f := &PeriodicFlusher{
+		flush:   flush,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go f.run(clock.NewTicker(interval))
+	return f
+}
+
+func (f *PeriodicFlusher) run(ticker *time.Ticker) {
+	defer close(f.stopped)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := f.flush(); err != nil {
+				f.mu.Lock()
+				f.lastErr = err
+				f.mu.Unlock()
+			}
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// Close stops the periodic flushing and flushes one last time.
+//
+// It returns the error of the final flush joined with the last error returned by a periodic
+// flush, if any, or ctx.Err() if ctx is done before the background goroutine stops.
+// Subsequent calls return nil.
+func (f *PeriodicFlusher) Close(ctx context.Context) error {
+	first := false
+	f.once.Do(func() {
+		first = true
+		close(f.done)
+	})
+	if !first {
+		return nil
+	}
+
+	select {
+	case <-f.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	f.mu.Lock()
+	lastErr := f.lastErr
+	f.mu.Unlock()
+
+	return errors.Join(lastErr, f.flush())
+}
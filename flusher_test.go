@@ -0,0 +1,121 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPeriodicFlusher_NilFlush(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: flush function cannot be nil", func() {
+		NewPeriodicFlusher(nil, nil, time.Second)
+	})
+}
+
+func TestPeriodicFlusher_FlushesEachInterval(t *testing.T) {
+	clock := newFakeClock()
+	flushed := make(chan struct{}, 1)
+	var calls atomic.Int32
+	f := NewPeriodicFlusher(func() error {
+		calls.Add(1)
+		flushed <- struct{}{}
+		return nil
+	}, clock, time.Second)
+
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		<-flushed
+		assert.Equal(t, int32(i), calls.Load())
+	}
+
+	require.NoError(t, f.Close(context.Background()))
+	assert.Equal(t, int32(4), calls.Load(), "Close flushes one last time")
+
+	require.NoError(t, f.Close(context.Background()))
+	assert.Equal(t, int32(4), calls.Load(), "second Close is a no-op")
+}
+
+func TestPeriodicFlusher_Errors(t *testing.T) {
+	clock := newFakeClock()
+	errPeriodic := errors.New("periodic")
+	errFinal := errors.New("final")
+
+	flushed := make(chan struct{})
+	var calls atomic.Int32
+	f := NewPeriodicFlusher(func() error {
+		if calls.Add(1) == 1 {
+			defer close(flushed)
+			return errPeriodic
+		}
+		return errFinal
+	}, clock, time.Second)
+
+	clock.Advance(time.Second)
+	<-flushed
+
+	err := f.Close(context.Background())
+	assert.ErrorIs(t, err, errPeriodic)
+	assert.ErrorIs(t, err, errFinal)
+}
+
+func TestPeriodicFlusher_CloseContextDone(t *testing.T) {
+	clock := newFakeClock()
+	block := make(chan struct{})
+	started := make(chan struct{})
+	f := NewPeriodicFlusher(func() error {
+		close(started)
+		<-block
+		return nil
+	}, clock, time.Second)
+	defer close(block)
+
+	go clock.Advance(time.Second)
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, f.Close(ctx), context.Canceled)
+}
+
+func TestBufferedWriter(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "default size", size: 0},
+		{name: "small buffer", size: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &lockedBuffer{}
+			w := NewBufferedWriter(out, tt.size)
+
+			// Flushing while writing is safe.
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 50; j++ {
+						_, err := fmt.Fprintf(w, "line %d\n", j)
+						assert.NoError(t, err)
+						assert.NoError(t, w.Flush())
+					}
+				}()
+			}
+			wg.Wait()
+
+			require.NoError(t, w.Flush())
+			assert.Equal(t, 200, strings.Count(out.String(), "\n"))
+		})
+	}
+}
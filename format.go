@@ -0,0 +1,103 @@
+package slogs
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// Format selects the encoding of a terminal handler, the handler that writes records out.
+type Format int
+
+const (
+	// FormatText encodes records with slog.TextHandler.
+	FormatText Format = iota
+	// FormatJSON encodes records with slog.JSONHandler.
+	FormatJSON
+	// FormatPrettyJSON encodes records as indented multi-line JSON, see NewPrettyJSONHandler.
+	FormatPrettyJSON
+)
+
+// ErrNoTerminal is returned by Logger.SetFormat when the logger was not created with NewWithFormat.
+var ErrNoTerminal = errors.New("slogs: logger has no swappable terminal handler")
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	case FormatPrettyJSON:
+		return "pretty_json"
+	default:
+		return "unknown"
+	}
+}
+
+// NewFormatHandler creates a terminal handler that writes records to w in the given format.
+//
+// opts is passed to the slog handler and may be nil. Unknown formats fall back to FormatText.
+func NewFormatHandler(format Format, w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	switch format {
+	case FormatJSON:
+		return slog.NewJSONHandler(w, opts)
+	case FormatPrettyJSON:
+		return NewPrettyJSONHandler(w, "  ", opts)
+	default:
+		return slog.NewTextHandler(w, opts)
+	}
+}
+
+// terminal tracks the swappable terminal handler of a logger tree built by NewWithFormat.
+type terminal struct {
+	handler *SwappableHandler
+	opts    *slog.HandlerOptions
+
+	mu     sync.Mutex
+	w      io.Writer
+	format Format
+}
+
+// NewWithFormat creates a Logger writing to w in the given format whose format can be changed
+// later with SetFormat.
+//
+// opts is passed to the slog handler of every format and may be nil. Loggers derived from the
+// returned Logger share its terminal handler, so SetFormat on any of them switches all of them.
+//
+// Example:
+//
+//	logger := slogs.NewWithFormat(slogs.FormatText, os.Stderr, nil)
+//	// On SIGHUP, switch to production output:
+//	err := logger.SetFormat(slogs.FormatJSON, os.Stdout)
+func NewWithFormat(format Format, w io.Writer, opts *slog.HandlerOptions, options ...Option) *Logger {
+	swappable := NewSwappableHandler(NewFormatHandler(format, w, opts))
+
+	l := New(NewHandler(swappable), options...)
+	l.terminal = &terminal{handler: swappable, opts: opts, w: w, format: format}
+	return l
+}
+
+// SetFormat atomically switches the terminal handler of the logger tree to the given format,
+// writing to w, or to the current writer if w is nil.
+//
+// Every Logger sharing the terminal, including those derived with With, WithGroup and
+// WithOptions, switches at once. Returns ErrNoTerminal if the logger was not created
+// with NewWithFormat.
+func (l *Logger) SetFormat(format Format, w io.Writer) error {
+	t := l.terminal
+	if t == nil {
+		return ErrNoTerminal
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if w != nil {
+		t.w = w
+	}
+	t.format = format
+	t.handler.Swap(NewFormatHandler(format, t.w, t.opts))
+	return nil
+}
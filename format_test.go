@@ -0,0 +1,85 @@
+package slogs
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dropTime removes the time attribute so output can be compared exactly.
+func dropTime(_ []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
+
+func TestFormat_String(t *testing.T) {
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{FormatText, "text"},
+		{FormatJSON, "json"},
+		{FormatPrettyJSON, "pretty_json"},
+		{Format(42), "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.format.String())
+		})
+	}
+}
+
+func TestNewFormatHandler(t *testing.T) {
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{FormatText, "level=INFO msg=hello k=v\n"},
+		{FormatJSON, `{"level":"INFO","msg":"hello","k":"v"}` + "\n"},
+		{FormatPrettyJSON, "{\n  \"level\": \"INFO\",\n  \"msg\": \"hello\",\n  \"k\": \"v\"\n}\n"},
+		{Format(42), "level=INFO msg=hello k=v\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			slog.New(NewFormatHandler(tt.format, &buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).Info("hello", "k", "v")
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestLogger_SetFormat(t *testing.T) {
+	var console, prod bytes.Buffer
+	logger := NewWithFormat(FormatText, &console, &slog.HandlerOptions{ReplaceAttr: dropTime})
+	child := logger.With("app", "api").WithGroup("req")
+
+	child.Info("before", "method", "GET")
+
+	require.NoError(t, child.SetFormat(FormatJSON, &prod))
+	logger.Info("after")
+	child.Info("after", "method", "GET")
+
+	assert.Equal(t, "level=INFO msg=before app=api req.method=GET\n", console.String())
+	assert.Equal(t,
+		`{"level":"INFO","msg":"after"}`+"\n"+
+			`{"level":"INFO","msg":"after","app":"api","req":{"method":"GET"}}`+"\n",
+		prod.String())
+
+	// A nil writer keeps the current one.
+	prod.Reset()
+	require.NoError(t, logger.SetFormat(FormatText, nil))
+	logger.Info("text again")
+	assert.Equal(t, "level=INFO msg=\"text again\"\n", prod.String())
+}
+
+func TestLogger_SetFormat_NoTerminal(t *testing.T) {
+	logger := New(NewHandler(newTestHandler(true)))
+	assert.ErrorIs(t, logger.SetFormat(FormatJSON, &bytes.Buffer{}), ErrNoTerminal)
+}
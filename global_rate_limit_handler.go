@@ -0,0 +1,270 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ slog.Handler = (*GlobalRateLimitHandler)(nil)
+
+// GlobalRateLimitHandler caps the rate of records passed to the next handler, over all records.
+//
+// It is a safety valve protecting a fragile downstream, such as shared logging infrastructure,
+// from any log storm: a single token bucket, refilled at perSecond tokens per second and holding
+// up to burst tokens, is shared by all records regardless of their level or message. Records
+// arriving when the bucket is empty are dropped, or wait for a token with WithWait.
+//
+// Example:
+//
+//	limited := slogs.NewGlobalRateLimitHandler(slog.NewJSONHandler(conn, nil), nil, 1000, 100).
+//		WithDropReporter(reporter)
+//	logger := slogs.New(slogs.NewHandler(limited))
+type GlobalRateLimitHandler struct {
+	next     slog.Handler
+	bucket   *tokenBucket
+	reporter DropReporter
+	wait     time.Duration
+}
+
+// tokenBucket is the rate limiter shared by a GlobalRateLimitHandler and the handlers derived from it.
+//
+// If window is set, it is a fixed-window counter instead: the bucket is refilled to burst at
+// the start of each window and perSecond is unused.
+type tokenBucket struct {
+	clock     Clock
+	perSecond float64
+	burst     float64
+	window    time.Duration
+
+	mu     sync.Mutex
+	tokens float64
+	// last is when the bucket was last refilled, or the start of the current window.
+	last time.Time
+
+	dropped atomic.Uint64
+}
+
+// NewGlobalRateLimitHandler creates a GlobalRateLimitHandler passing at most perSecond records
+// per second to next, with bursts of up to burst records. The bucket starts full. Time is
+// measured with clock, or DefaultClock if clock is nil.
+//
+// A burst < 1 is treated as 1 and a perSecond <= 0 lets only the initial burst through.
+//
+// Panics if next is nil; see NewGlobalRateLimitHandlerE.
+func NewGlobalRateLimitHandler(next slog.Handler, clock Clock, perSecond float64, burst int) *GlobalRateLimitHandler {
+	h, err := NewGlobalRateLimitHandlerE(next, clock, perSecond, burst)
+	if err != nil {
+		panic(err.Error())
+	}
+	return h
+}
+
+// NewGlobalRateLimitHandlerE is like NewGlobalRateLimitHandler but returns ErrNilNextHandler
+// instead of panicking if next is nil.
+func NewGlobalRateLimitHandlerE(next slog.Handler, clock Clock, perSecond float64, burst int) (*GlobalRateLimitHandler, error) {
+	if next == nil {
+		return nil, ErrNilNextHandler
+	}
+	if clock == nil {
+		clock = DefaultClock
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	if perSecond < 0 {
+		perSecond = 0
+	}
+
+	b := &tokenBucket{
+		clock:     clock,
+		perSecond: perSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		last:      clock.Now(),
+	}
+	return &GlobalRateLimitHandler{next: next, bucket: b}, nil
+}
+
+// NewRateLimitHandler creates a GlobalRateLimitHandler passing at most limit records per period
+// per to next, over all records.
+//
+// It is a fixed-window counter: time is divided into consecutive windows of length per, measured
+// with clock, or DefaultClock if clock is nil, starting when the handler is created. The first
+// limit records of a window pass and the following ones are dropped, or wait for a following
+// window with WithWait; the count starts over with each window. A limit < 1 is treated as 1 and
+// a per <= 0 lets only the first limit records through.
+//
+// Panics if next is nil; see NewRateLimitHandlerE.
+//
+// Example:
+//
+//	limited := slogs.NewRateLimitHandler(slog.NewJSONHandler(os.Stdout, nil), nil, 100, time.Second)
+//	logger := slogs.New(slogs.NewHandler(limited))
+//	// ...
+//	metrics.Set("log_records_dropped", limited.Dropped())
+func NewRateLimitHandler(next slog.Handler, clock Clock, limit int, per time.Duration) *GlobalRateLimitHandler {
+	h, err := NewRateLimitHandlerE(next, clock, limit, per)
+	if err != nil {
+		panic(err.Error())
+	}
+	return h
+}
+
+// NewRateLimitHandlerE is like NewRateLimitHandler but returns ErrNilNextHandler instead of
+// panicking if next is nil.
+func NewRateLimitHandlerE(next slog.Handler, clock Clock, limit int, per time.Duration) (*GlobalRateLimitHandler, error) {
+	h, err := NewGlobalRateLimitHandlerE(next, clock, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+	h.bucket.window = max(per, 0)
+	return h, nil
+}
+
+// Dropped returns the number of records dropped by the handler and the handlers sharing its
+// bucket since they were created, so that the loss can be monitored. Records whose wait was
+// interrupted by their context are counted too.
+func (h *GlobalRateLimitHandler) Dropped() uint64 {
+	return h.bucket.dropped.Load()
+}
+
+// WithDropReporter returns a GlobalRateLimitHandler sharing the same bucket that reports every
+// dropped record to reporter with ReasonRateLimited. A nil reporter disables reporting.
+func (h *GlobalRateLimitHandler) WithDropReporter(reporter DropReporter) *GlobalRateLimitHandler {
+	h2 := *h
+	h2.reporter = reporter
+	return &h2
+}
+
+// WithWait returns a GlobalRateLimitHandler sharing the same bucket that blocks records over
+// the limit until a token is available, if that takes at most timeout, instead of dropping
+// them. Records that would wait longer are dropped right away.
+//
+// Waiting records hold their token even if their context is canceled, in which case the record
+// is dropped as if it had exceeded the limit and Handle returns the context error. A timeout <= 0 drops records over the limit, the default.
+func (h *GlobalRateLimitHandler) WithWait(timeout time.Duration) *GlobalRateLimitHandler {
+	h2 := *h
+	h2.wait = max(timeout, 0)
+	return &h2
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *GlobalRateLimitHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes r to the next handler if the rate limit allows it and drops it otherwise.
+func (h *GlobalRateLimitHandler) Handle(ctx context.Context, r slog.Record) error {
+	delay, ok := h.bucket.reserve(h.wait)
+	if !ok {
+		h.drop(r.Level)
+		return nil
+	}
+
+	if delay > 0 {
+		ready := make(chan struct{})
+		stop := h.bucket.clock.AfterFunc(delay, func() { close(ready) })
+
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			stop()
+			h.drop(r.Level)
+			return ctx.Err()
+		}
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// drop counts a dropped record and reports it.
+func (h *GlobalRateLimitHandler) drop(level slog.Level) {
+	h.bucket.dropped.Add(1)
+	if h.reporter != nil {
+		h.reporter.Dropped(level, ReasonRateLimited, 1)
+	}
+}
+
+// WithAttrs returns a GlobalRateLimitHandler sharing the same bucket whose next handler has the given attributes.
+func (h *GlobalRateLimitHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a GlobalRateLimitHandler sharing the same bucket whose next handler has the given group.
+func (h *GlobalRateLimitHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+// reserve takes a token and returns how long to wait before it is available. It reports false,
+// without taking a token, if the wait would exceed maxWait.
+func (b *tokenBucket) reserve(maxWait time.Duration) (time.Duration, bool) {
+	now := b.clock.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.window > 0 {
+		return b.reserveWindow(now, maxWait)
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.perSecond)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	if maxWait <= 0 || b.perSecond == 0 {
+		return 0, false
+	}
+
+	delay := time.Duration((1 - b.tokens) / b.perSecond * float64(time.Second))
+	if delay > maxWait {
+		return 0, false
+	}
+	b.tokens--
+	return delay, true
+}
+
+// reserveWindow is reserve for a fixed-window counter. Records waiting for a following window
+// take its slots in advance, leaving the count of the current window below zero. b.mu must be held.
+func (b *tokenBucket) reserveWindow(now time.Time, maxWait time.Duration) (time.Duration, bool) {
+	if n := now.Sub(b.last) / b.window; n > 0 {
+		b.tokens = min(b.burst, b.tokens+float64(n)*b.burst)
+		b.last = b.last.Add(n * b.window)
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	if maxWait <= 0 {
+		return 0, false
+	}
+
+	// The record gets a slot in the window after those already taken in advance.
+	ahead := time.Duration(-b.tokens/b.burst) + 1
+	delay := b.last.Add(ahead * b.window).Sub(now)
+	if delay > maxWait {
+		return 0, false
+	}
+	b.tokens--
+	return delay, true
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *GlobalRateLimitHandler) unwrap() slog.Handler {
+	return h.next
+}
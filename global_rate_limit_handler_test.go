@@ -0,0 +1,238 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rockcookies/go-slogs/slogstest"
+)
+
+func TestNewGlobalRateLimitHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewGlobalRateLimitHandler(nil, nil, 1, 1)
+	})
+
+	h, err := NewGlobalRateLimitHandlerE(nil, nil, 1, 1)
+	assert.ErrorIs(t, err, ErrNilNextHandler)
+	assert.Nil(t, h)
+
+	h, err = NewRateLimitHandlerE(nil, nil, 1, time.Second)
+	assert.ErrorIs(t, err, ErrNilNextHandler)
+	assert.Nil(t, h)
+}
+
+func TestGlobalRateLimitHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		perSecond float64
+		burst     int
+		// steps are the number of records handled, each after advancing the clock by interval.
+		steps    []int
+		interval time.Duration
+		expected int
+	}{
+		{name: "burst", perSecond: 1, burst: 5, steps: []int{10}, expected: 5},
+		{name: "refill", perSecond: 10, burst: 2, steps: []int{5, 5, 5}, interval: 100 * time.Millisecond, expected: 4},
+		{name: "refill capped at burst", perSecond: 10, burst: 2, steps: []int{2, 5}, interval: time.Hour, expected: 4},
+		{name: "zero rate", perSecond: 0, burst: 3, steps: []int{5, 5}, interval: time.Hour, expected: 3},
+		{name: "burst below one", perSecond: 1, burst: 0, steps: []int{5}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			clock := newFakeClock()
+			h := NewGlobalRateLimitHandler(next, clock, tt.perSecond, tt.burst)
+
+			for _, n := range tt.steps {
+				clock.Advance(tt.interval)
+				for i := 0; i < n; i++ {
+					require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)))
+				}
+			}
+
+			assert.Equal(t, tt.expected, next.recordCount())
+		})
+	}
+}
+
+func TestGlobalRateLimitHandler_SharedBucket(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewGlobalRateLimitHandler(next, newFakeClock(), 1, 2)
+	logger := New(NewHandler(h))
+
+	logger.Info("a")
+	logger.With("k", "v").Warn("b")
+	logger.WithGroup("g").Error("c")
+
+	assert.Equal(t, []string{"a", "b"}, messages(next.getRecords()))
+}
+
+func TestNewRateLimitHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		per   time.Duration
+		// steps are the number of records handled, each after advancing the clock by interval.
+		steps       []int
+		interval    time.Duration
+		expected    int
+		wantDropped uint64
+	}{
+		{name: "limit", limit: 3, per: time.Second, steps: []int{5}, expected: 3, wantDropped: 2},
+		{name: "next window", limit: 3, per: time.Second, steps: []int{5, 5}, interval: time.Second, expected: 6, wantDropped: 4},
+		{name: "no refill within the window", limit: 4, per: time.Second, steps: []int{3, 3}, interval: 400 * time.Millisecond, expected: 4, wantDropped: 2},
+		{name: "reset each window", limit: 4, per: time.Second, steps: []int{4, 4}, interval: 500 * time.Millisecond, expected: 8, wantDropped: 0},
+		{name: "idle windows do not accumulate", limit: 2, per: time.Second, steps: []int{0, 5}, interval: time.Hour, expected: 2, wantDropped: 3},
+		{name: "zero period", limit: 2, per: 0, steps: []int{3, 3}, interval: time.Hour, expected: 2, wantDropped: 4},
+		{name: "limit below one", limit: 0, per: time.Second, steps: []int{3}, expected: 1, wantDropped: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			clock := newFakeClock()
+			h := NewRateLimitHandler(next, clock, tt.limit, tt.per)
+
+			for _, n := range tt.steps {
+				clock.Advance(tt.interval)
+				for i := 0; i < n; i++ {
+					require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)))
+				}
+			}
+
+			assert.Equal(t, tt.expected, next.recordCount())
+			assert.Equal(t, tt.wantDropped, h.Dropped())
+		})
+	}
+}
+
+func TestNewRateLimitHandler_FixedWindow(t *testing.T) {
+	const limit = 3
+	next := newTestHandler(true)
+	clock := slogstest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	h := NewRateLimitHandler(next, clock, limit, time.Minute)
+
+	handle := func(msg string) {
+		require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, msg, 0)))
+	}
+
+	clock.Advance(59 * time.Second)
+	for i := 0; i < limit+1; i++ {
+		handle("first window")
+	}
+	assert.Equal(t, limit, next.recordCount(), "record limit+1 of a window is dropped")
+	assert.Equal(t, uint64(1), h.Dropped())
+
+	clock.Advance(time.Second)
+	handle("second window")
+	assert.Equal(t, limit+1, next.recordCount(), "the count resets at the start of each window")
+}
+
+func TestNewRateLimitHandler_Wait(t *testing.T) {
+	clock := newFakeClock()
+	b := NewRateLimitHandler(newTestHandler(true), clock, 2, time.Second).bucket
+
+	clock.Advance(250 * time.Millisecond)
+	delays := make([]time.Duration, 0, 5)
+	for i := 0; i < 5; i++ {
+		delay, ok := b.reserve(2 * time.Second)
+		require.True(t, ok)
+		delays = append(delays, delay)
+	}
+	assert.Equal(t, []time.Duration{0, 0, 750 * time.Millisecond, 750 * time.Millisecond, 1750 * time.Millisecond}, delays,
+		"waiting records take the slots of the following windows in order")
+
+	_, ok := b.reserve(time.Second)
+	assert.False(t, ok, "the next free slot is more than the maximum wait away")
+}
+
+func TestGlobalRateLimitHandler_Dropped(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewGlobalRateLimitHandler(next, newFakeClock(), 0, 1)
+	derived := h.WithAttrs([]slog.Attr{slog.String("k", "v")}).WithGroup("g").(*GlobalRateLimitHandler)
+
+	for _, handler := range []*GlobalRateLimitHandler{h, derived, h.WithWait(time.Second)} {
+		require.NoError(t, handler.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)))
+	}
+
+	assert.Equal(t, 1, next.recordCount())
+	assert.Equal(t, uint64(2), h.Dropped(), "the counter is shared by derived handlers")
+	assert.Equal(t, uint64(2), derived.Dropped())
+}
+
+func TestGlobalRateLimitHandler_WithDropReporter(t *testing.T) {
+	next := newTestHandler(true)
+	reporter := newDropCounter()
+	h := NewGlobalRateLimitHandler(next, newFakeClock(), 1, 1).WithDropReporter(reporter)
+	logger := New(NewHandler(h))
+
+	logger.Info("a")
+	logger.Info("b")
+	logger.Warn("c")
+
+	assert.Equal(t, 1, next.recordCount())
+	assert.Equal(t, map[string]int{"INFO rate_limited": 1, "WARN rate_limited": 1}, reporter.get())
+}
+
+// timerSignalingClock is a FakeClock signaling each timer it creates, so that tests advance it
+// once a record waits.
+type timerSignalingClock struct {
+	*slogstest.FakeClock
+	created chan time.Duration
+}
+
+func (c timerSignalingClock) AfterFunc(d time.Duration, f func()) func() bool {
+	stop := c.FakeClock.AfterFunc(d, f)
+	c.created <- d
+	return stop
+}
+
+func TestGlobalRateLimitHandler_WithWait(t *testing.T) {
+	next := newTestHandler(true)
+	reporter := newDropCounter()
+	clock := timerSignalingClock{FakeClock: newFakeClock(), created: make(chan time.Duration, 1)}
+	h := NewGlobalRateLimitHandler(next, clock, 10, 1).WithWait(150 * time.Millisecond).WithDropReporter(reporter)
+	ctx := context.Background()
+
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "first", 0)))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "waiting", 0))
+	}()
+
+	// The second record waits 100ms for its token; a third one would wait 200ms and is dropped.
+	assert.Equal(t, 100*time.Millisecond, <-clock.created)
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "dropped", 0)))
+	assert.Equal(t, 1, next.recordCount())
+
+	clock.Advance(100 * time.Millisecond)
+	require.NoError(t, <-done)
+
+	assert.Equal(t, []string{"first", "waiting"}, messages(next.getRecords()))
+	assert.Equal(t, map[string]int{"INFO rate_limited": 1}, reporter.get())
+
+	// The timer of the waiting record is gone, so advancing the clock further does not block.
+	clock.Advance(time.Second)
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "later", 0)))
+	assert.Equal(t, 3, next.recordCount())
+}
+
+func TestGlobalRateLimitHandler_WithWait_Canceled(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewGlobalRateLimitHandler(next, newFakeClock(), 1, 1).WithWait(time.Minute)
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "first", 0)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "canceled", 0))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, next.recordCount())
+}
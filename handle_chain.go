@@ -0,0 +1,49 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// ChainHandleFunc returns a HandleFunc that applies funcs in order, each receiving the message
+// and attributes returned by the previous one, so that transformations such as redaction,
+// enrichment and renaming can be written as separate functions. nil functions are ignored.
+//
+// DefaultHandleFunc can appear anywhere in the chain: the functions before it see the raw
+// attributes of the record, and the functions after it also see the attributes of the context
+// and of WithAttrs, nested in the groups of WithGroup. It should appear exactly once when the
+// chain is used as HandlerOptions.HandleFunc, since neither the context attributes nor the
+// groups are applied otherwise, and they would be applied twice if it appeared twice.
+//
+// Example:
+//
+//	handler := slogs.NewHandlerWithOptions(next, &slogs.HandlerOptions{
+//		HandleFunc: slogs.ChainHandleFunc(dropDebugAttrs, slogs.DefaultHandleFunc, addHostname),
+//	})
+func ChainHandleFunc(funcs ...HandleFunc) HandleFunc {
+	funcs = slices.DeleteFunc(slices.Clone(funcs), func(fn HandleFunc) bool { return fn == nil })
+
+	return func(ctx context.Context, hc *HandlerContext, rt time.Time, rl slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		for _, fn := range funcs {
+			rm, attrs = fn(ctx, hc, rt, rl, rm, attrs)
+		}
+		return rm, attrs
+	}
+}
+
+// WithHandleFunc returns a new Handler that passes the message and attributes of every record
+// through funcs, in order, after its HandleFunc and the functions of previous calls, as by
+// ChainHandleFunc. The functions thus see the attributes of the context and of WithAttrs,
+// nested in the groups of WithGroup. nil functions are ignored.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithHandleFunc(redact, enrich, rename)
+func (h *Handler) WithHandleFunc(funcs ...HandleFunc) *Handler {
+	if !slices.ContainsFunc(funcs, func(fn HandleFunc) bool { return fn != nil }) {
+		return h
+	}
+	return h.use(ChainHandleFunc(funcs...))
+}
@@ -0,0 +1,96 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// appendStep returns a HandleFunc appending name to the message and an attribute recording how
+// many attributes it received.
+func appendStep(name string) HandleFunc {
+	return func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm + " " + name, append(attrs, slog.String(name, strconv.Itoa(len(attrs))))
+	}
+}
+
+func TestChainHandleFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		funcs    []HandleFunc
+		expected string
+	}{
+		{
+			name:     "order",
+			funcs:    []HandleFunc{appendStep("a"), appendStep("b"), appendStep("c"), DefaultHandleFunc},
+			expected: `{"level":"INFO","msg":"m a b c","ctx":"x","k":"v","g":{"n":1,"a":"1","b":"2","c":"3"}}`,
+		},
+		{
+			name:     "default first",
+			funcs:    []HandleFunc{DefaultHandleFunc, appendStep("a")},
+			expected: `{"level":"INFO","msg":"m a","ctx":"x","k":"v","g":{"n":1},"a":"3"}`,
+		},
+		{
+			name:     "default in the middle",
+			funcs:    []HandleFunc{appendStep("a"), DefaultHandleFunc, appendStep("b")},
+			expected: `{"level":"INFO","msg":"m a b","ctx":"x","k":"v","g":{"n":1,"a":"1"},"b":"3"}`,
+		},
+		{
+			name:     "nil ignored",
+			funcs:    []HandleFunc{nil, DefaultHandleFunc, nil},
+			expected: `{"level":"INFO","msg":"m","ctx":"x","k":"v","g":{"n":1}}`,
+		},
+		{
+			name:     "without default",
+			funcs:    []HandleFunc{appendStep("a")},
+			expected: `{"level":"INFO","msg":"m a","n":1,"a":"1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandlerWithOptions(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime}), &HandlerOptions{
+				HandleFunc: ChainHandleFunc(tt.funcs...),
+			})
+
+			ctx := Prepend(context.Background(), "ctx", "x")
+			slog.New(h).With("k", "v").WithGroup("g").InfoContext(ctx, "m", "n", 1)
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestChainHandleFunc_Empty(t *testing.T) {
+	attrs := []slog.Attr{slog.Int("n", 1)}
+	rm, got := ChainHandleFunc()(context.Background(), &HandlerContext{}, time.Now(), slog.LevelInfo, "m", attrs)
+
+	assert.Equal(t, "m", rm)
+	assert.Equal(t, attrs, got)
+}
+
+func TestHandler_WithHandleFunc(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+		WithHandleFunc(appendStep("a"), nil, appendStep("b")).
+		WithHandleFunc(appendStep("c"))
+
+	ctx := Prepend(context.Background(), "ctx", "x")
+	slog.New(h).With("k", "v").InfoContext(ctx, "m", "n", 1)
+
+	// The functions run after DefaultHandleFunc, in the order they were added.
+	assert.Equal(t, `{"level":"INFO","msg":"m a b c","ctx":"x","k":"v","n":1,"a":"3","b":"4","c":"5"}`+"\n", buf.String())
+}
+
+func TestHandler_WithHandleFunc_NoFuncs(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+
+	assert.Same(t, h, h.WithHandleFunc())
+	assert.Same(t, h, h.WithHandleFunc(nil))
+}
@@ -0,0 +1,88 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// handleLimit bounds the number of concurrent calls to the next handler of a Handler, see
+// Handler.WithMaxConcurrentHandles.
+type handleLimit struct {
+	sem      chan struct{}
+	timeout  time.Duration
+	reporter DropReporter
+}
+
+// WithMaxConcurrentHandles returns a new Handler that lets at most n records be handled by the
+// next handler at the same time.
+//
+// It bounds the resources held by logging under load when the next handler makes synchronous
+// network calls. Handle waits for a slot; if timeout > 0 and no slot is freed within timeout,
+// the record is dropped and reported to reporter with ReasonConcurrencyLimit, and Handle
+// returns nil. With a timeout <= 0, Handle waits as long as needed. If the caller's context is
+// done while waiting, Handle returns its error.
+//
+// The limit is shared with the handlers derived from the returned Handler. reporter may be nil.
+// An n <= 0 removes the limit.
+func (h *Handler) WithMaxConcurrentHandles(n int, timeout time.Duration, reporter DropReporter) *Handler {
+	h2 := h.Clone()
+	if n <= 0 {
+		h2.handleLimit = nil
+		return h2
+	}
+
+	h2.handleLimit = &handleLimit{sem: make(chan struct{}, n), timeout: timeout, reporter: reporter}
+	return h2
+}
+
+// forward passes r to the next handler, applying the concurrency limit and the write timeout
+// if they are set.
+func (h *Handler) forward(ctx context.Context, r slog.Record) error {
+	hl := h.handleLimit
+	if hl == nil {
+		return h.forwardWithTimeout(ctx, r)
+	}
+
+	acquired, err := hl.acquire(ctx)
+	if !acquired {
+		if err == nil && hl.reporter != nil {
+			hl.reporter.Dropped(r.Level, ReasonConcurrencyLimit, 1)
+		}
+		return err
+	}
+	defer hl.release()
+
+	return h.forwardWithTimeout(ctx, r)
+}
+
+// acquire waits for a slot. It reports false with a nil error if the timeout expired, and
+// false with the context error if ctx is done first.
+func (hl *handleLimit) acquire(ctx context.Context) (bool, error) {
+	select {
+	case hl.sem <- struct{}{}:
+		return true, nil
+	default:
+	}
+
+	var expired <-chan time.Time
+	if hl.timeout > 0 {
+		timer := time.NewTimer(hl.timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case hl.sem <- struct{}{}:
+		return true, nil
+	case <-expired:
+		return false, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire.
+func (hl *handleLimit) release() {
+	<-hl.sem
+}
@@ -0,0 +1,130 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedHandler blocks every Handle call until release is closed and tracks how many calls
+// run at the same time.
+type gatedHandler struct {
+	release chan struct{}
+	entered chan struct{}
+
+	current atomic.Int32
+	peak    atomic.Int32
+	handled atomic.Int32
+}
+
+func newGatedHandler() *gatedHandler {
+	return &gatedHandler{release: make(chan struct{}), entered: make(chan struct{}, 100)}
+}
+
+func (h *gatedHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *gatedHandler) Handle(context.Context, slog.Record) error {
+	n := h.current.Add(1)
+	for {
+		peak := h.peak.Load()
+		if n <= peak || h.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	h.entered <- struct{}{}
+
+	<-h.release
+	h.current.Add(-1)
+	h.handled.Add(1)
+	return nil
+}
+
+func (h *gatedHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *gatedHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestHandler_WithMaxConcurrentHandles(t *testing.T) {
+	next := newGatedHandler()
+	logger := New(NewHandler(next).WithMaxConcurrentHandles(2, 0, nil))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info("m")
+		}()
+	}
+
+	<-next.entered
+	<-next.entered
+	select {
+	case <-next.entered:
+		t.Fatal("more than 2 records handled concurrently")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(next.release)
+	wg.Wait()
+	assert.Equal(t, int32(2), next.peak.Load())
+	assert.Equal(t, int32(5), next.handled.Load())
+}
+
+func TestHandler_WithMaxConcurrentHandles_DropOnTimeout(t *testing.T) {
+	next := newGatedHandler()
+	drops := newDropCounter()
+	h := NewHandler(next).WithMaxConcurrentHandles(1, 10*time.Millisecond, drops)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		New(h).Info("holds the slot")
+	}()
+	<-next.entered
+
+	// Derived handlers share the limit.
+	err := h.WithAttrs([]slog.Attr{slog.Int("k", 1)}).Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelWarn, "dropped", 0))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"WARN " + ReasonConcurrencyLimit: 1}, drops.get())
+
+	close(next.release)
+	<-done
+	assert.Equal(t, int32(1), next.handled.Load())
+}
+
+func TestHandler_WithMaxConcurrentHandles_CallerContext(t *testing.T) {
+	next := newGatedHandler()
+	drops := newDropCounter()
+	h := NewHandler(next).WithMaxConcurrentHandles(1, 0, drops)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		New(h).Info("holds the slot")
+	}()
+	<-next.entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "canceled", 0))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, drops.get())
+
+	close(next.release)
+	<-done
+}
+
+func TestHandler_WithMaxConcurrentHandles_Disabled(t *testing.T) {
+	next := newGatedHandler()
+	close(next.release)
+	h := NewHandler(next).WithMaxConcurrentHandles(1, 0, nil).WithMaxConcurrentHandles(0, 0, nil)
+
+	assert.Nil(t, h.handleLimit)
+	New(h).Info("m")
+	assert.Equal(t, int32(1), next.handled.Load())
+}
@@ -2,7 +2,10 @@ package slogs
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"reflect"
 	"slices"
 	"time"
 )
@@ -17,14 +20,76 @@ import (
 //   - Transform or filter attributes
 //   - Modify log messages
 //   - Add custom formatting
-//   - Implement security features like sensitive data masking
+//   - Implement security features like sensitive data masking, see RedactHandleFunc
 type HandleFunc func(ctx context.Context, hc *HandlerContext, rt time.Time, rl slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr)
 
+// FilterFunc reports whether a record should be passed on to the next handler.
+//
+// Returning false drops the record entirely.
+type FilterFunc func(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr) bool
+
+// FilterStage selects when a FilterFunc runs relative to the HandleFunc pipeline.
+type FilterStage int
+
+const (
+	// FilterAfterHandle runs the filter on the processed record: the message carries the
+	// logger name and attrs include context attributes and groups. This is the default.
+	FilterAfterHandle FilterStage = iota
+	// FilterBeforeHandle runs the filter on the raw record attributes, before any processing.
+	// It is cheaper for dropped records because the HandleFunc pipeline is skipped.
+	FilterBeforeHandle
+)
+
+// ErrNilNextHandler is returned by NewHandlerE, NewHandlerWithOptionsE and the E variants of
+// the other handler constructors when the next handler is nil.
+var ErrNilNextHandler = errors.New("slogs: next handler cannot be nil")
+
+// ErrInvalidOptions is wrapped by the errors returned by HandlerOptions.Validate.
+var ErrInvalidOptions = errors.New("slogs: invalid handler options")
+
 // HandlerOptions configures the behavior of a Handler.
 type HandlerOptions struct {
 	// HandleFunc is the function that processes log records.
-	// If nil, DefaultHandleFunc is used.
+	// If nil, DefaultHandleFunc is used. Several functions can be combined with ChainHandleFunc.
 	HandleFunc HandleFunc
+
+	// HandleFuncs, if not empty, are combined as by ChainHandleFunc and used instead of
+	// HandleFunc, which must then be nil. Unlike a chain built with ChainHandleFunc, they are
+	// checked by Validate: none of them may be nil, and DefaultHandleFunc may appear at most once.
+	HandleFuncs []HandleFunc
+}
+
+// Validate reports the misconfigurations of o, so that they are caught at startup rather
+// than when the first record is logged. The returned error wraps ErrInvalidOptions and lists
+// every problem found. A nil o is valid.
+func (o *HandlerOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	var errs []error
+	if o.HandleFunc != nil && len(o.HandleFuncs) > 0 {
+		errs = append(errs, fmt.Errorf("%w: HandleFunc and HandleFuncs are both set", ErrInvalidOptions))
+	}
+	defaults := 0
+	for i, fn := range o.HandleFuncs {
+		switch {
+		case fn == nil:
+			errs = append(errs, fmt.Errorf("%w: HandleFuncs[%d] is nil", ErrInvalidOptions, i))
+		case isDefaultHandleFunc(fn):
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		errs = append(errs, fmt.Errorf("%w: DefaultHandleFunc appears %d times in HandleFuncs", ErrInvalidOptions, defaults))
+	}
+	return errors.Join(errs...)
+}
+
+// isDefaultHandleFunc reports whether fn is DefaultHandleFunc itself, rather than a function
+// calling it.
+func isDefaultHandleFunc(fn HandleFunc) bool {
+	return reflect.ValueOf(fn).Pointer() == reflect.ValueOf(DefaultHandleFunc).Pointer()
 }
 
 // Handler is a middleware slog.Handler that manages attribute groups and context attributes.
@@ -40,6 +105,43 @@ type Handler struct {
 	handle  HandleFunc
 	level   slog.Leveler
 	context *HandlerContext
+
+	// clamp, if set, restricts the levels of the handled records to a range.
+	clamp *levelClamp
+
+	// middlewares run in order after handle, each receiving the output of the previous one.
+	middlewares []HandleFunc
+
+	// preFilters and filters must all accept a record for it to be handled.
+	preFilters []FilterFunc
+	filters    []FilterFunc
+
+	// coalescer, if set, suppresses repeated error records.
+	coalescer *coalescer
+
+	// stackOnce, if set, adds a stack trace to the first occurrence of each error.
+	stackOnce *stackOnce
+
+	// writeTimeout, if set, bounds the time spent in the next handler.
+	writeTimeout *writeTimeout
+
+	// handleLimit, if set, bounds the number of concurrent calls to the next handler.
+	handleLimit *handleLimit
+
+	// callerWhen, if set, decides whether the processed record keeps its caller information.
+	callerWhen func(ctx context.Context, level slog.Level, attrs []slog.Attr) bool
+
+	// source, if set, renders the caller as attributes instead of leaving it to next.
+	source *sourceRendering
+
+	// components, if set, resolves the component attribute from the caller.
+	components *componentCache
+
+	// dedupAttrs, if set, makes WithAttrs replace attributes of the same key and group level.
+	dedupAttrs bool
+
+	// extractors, if set, adds the attributes extracted from the context of each record.
+	extractors *ContextExtractors
 }
 
 // HandlerContext holds the state for a handler instance.
@@ -47,14 +149,24 @@ type Handler struct {
 // It maintains the chain of logger names and the linked list of attribute groups
 // that will be applied to log records.
 type HandlerContext struct {
+	// Name is the logger's name chain, with names joined by periods (e.g. "service.database").
 	Name string
 
+	// NameFunc, if set, derives the logger name from the context of each record.
+	// A non-empty result takes precedence over Name; an empty result falls back to Name.
+	// Handler calls it once per record: the HandleFuncs receive a HandlerContext whose Name
+	// holds the result and whose NameFunc is nil.
+	NameFunc func(ctx context.Context) string
+
 	// Attrs is the linked list of attribute groups.
 	// Newest groups are at the head, forming a chain to the oldest.
 	Attrs *GroupOrAttrs
 }
 
-var _ slog.Handler = (*Handler)(nil)
+var (
+	_ slog.Handler = (*Handler)(nil)
+	_ Closer       = (*Handler)(nil)
+)
 
 // NewMiddleware creates a Handler middleware constructor compatible with slogmulti.
 //
@@ -81,17 +193,22 @@ func NewMiddleware(options *HandlerOptions) func(slog.Handler) slog.Handler {
 // The Handler wraps the provided next handler and uses DefaultHandleFunc for processing.
 // This is a convenience function equivalent to NewHandlerWithOptions(next, nil).
 //
-// Panics if next is nil.
+// Panics if next is nil; see NewHandlerE.
 func NewHandler(next slog.Handler) *Handler {
 	return NewHandlerWithOptions(next, nil)
 }
 
+// NewHandlerE is like NewHandler but returns ErrNilNextHandler instead of panicking if next is nil.
+func NewHandlerE(next slog.Handler) (*Handler, error) {
+	return NewHandlerWithOptionsE(next, nil)
+}
+
 // NewHandlerWithOptions creates a Handler with custom options.
 //
 // The Handler wraps the next handler in the chain and applies the specified options.
 // If opts is nil, default options are used. If opts.handleFunc is nil, DefaultHandleFunc is used.
 //
-// Panics if next is nil.
+// Panics if next is nil or opts is invalid; see NewHandlerWithOptionsE.
 //
 // Example:
 //
@@ -104,8 +221,28 @@ func NewHandler(next slog.Handler) *Handler {
 //	}
 //	handler := slogs.NewHandlerWithOptions(baseHandler, opts)
 func NewHandlerWithOptions(next slog.Handler, opts *HandlerOptions) *Handler {
+	h, err := NewHandlerWithOptionsE(next, opts)
+	if err != nil {
+		panic(err.Error())
+	}
+	return h
+}
+
+// NewHandlerWithOptionsE is like NewHandlerWithOptions but returns an error instead of
+// panicking: ErrNilNextHandler if next is nil, or the error returned by opts.Validate.
+//
+// Example:
+//
+//	handler, err := slogs.NewHandlerWithOptionsE(baseHandler, opts)
+//	if err != nil {
+//		return fmt.Errorf("configure logging: %w", err)
+//	}
+func NewHandlerWithOptionsE(next slog.Handler, opts *HandlerOptions) (*Handler, error) {
 	if next == nil {
-		panic("slogs: next handler cannot be nil")
+		return nil, ErrNilNextHandler
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
 	if opts == nil {
@@ -113,6 +250,9 @@ func NewHandlerWithOptions(next slog.Handler, opts *HandlerOptions) *Handler {
 	}
 
 	handlerFunc := opts.HandleFunc
+	if len(opts.HandleFuncs) > 0 {
+		handlerFunc = ChainHandleFunc(opts.HandleFuncs...)
+	}
 	if handlerFunc == nil {
 		handlerFunc = DefaultHandleFunc
 	}
@@ -121,15 +261,20 @@ func NewHandlerWithOptions(next slog.Handler, opts *HandlerOptions) *Handler {
 		next:    next,
 		handle:  handlerFunc,
 		context: &HandlerContext{},
-	}
+	}, nil
 }
 
 // Enabled reports whether the handler handles records at the given level.
 //
 // This respects both the handler's own level setting (if configured via WithLevel)
 // and the next handler's level settings. The record is enabled only if both this
-// handler and the next handler would handle it.
+// handler and the next handler would handle it. If the handler has a static name, set with
+// Named, the context passed to the next handler carries it, see LoggerName. To keep Enabled
+// cheap, the NameFunc of WithNameFunc is only called by Handle.
 func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if !h.clamp.contains(level) {
+		return false
+	}
 	if h.level != nil {
 		// If the incoming level is less than the configured minimum level, disable it
 		if level < h.level.Level() {
@@ -137,15 +282,23 @@ func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
 		}
 	}
 
-	return h.next.Enabled(ctx, level)
+	return h.next.Enabled(withLoggerName(ctx, h.context.Name), level)
 }
 
 // Handle processes a log record and passes it to the next handler.
 //
 // It extracts all attributes from the record, processes them through the handle function
 // (which may add context attributes, apply grouping, and add names), and creates a new
-// record with the processed message and attributes.
+// record with the processed message and attributes. Attributes created with SampledAttr are
+// kept or dropped right after the handle function.
+//
+// If the handler has a name, the context passed to the next handler carries it,
+// so that downstream handlers can retrieve it with LoggerName.
 func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.clamp.contains(r.Level) {
+		return nil
+	}
+
 	// Collect all attributes from the record (which is the most recent attribute set).
 	// These attributes are ordered from oldest to newest, and our collection will be too.
 	attrs := make([]slog.Attr, 0, r.NumAttrs())
@@ -155,19 +308,93 @@ func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
 	})
 
 	message := r.Message
-	message, attrs = h.handle(ctx, h.context, r.Time, r.Level, message, attrs)
+	if !allowed(h.preFilters, ctx, r.Level, message, attrs) {
+		return nil
+	}
+
+	// An error emitting the summaries does not prevent r from being handled; it is joined with
+	// the result of r.
+	var summaryErr error
+	if h.coalescer != nil {
+		summaryErr = h.emitSummaries()
+		if r.Level >= slog.LevelError && !h.coalescer.observe(h, r.Level, message, attrs) {
+			return summaryErr
+		}
+	}
+
+	// The name is resolved once per record, for the HandleFuncs and the next handler.
+	hc := h.context
+	name := hc.Name
+	if hc.NameFunc != nil {
+		name = hc.resolveName(ctx)
+		resolved := *hc
+		resolved.Name, resolved.NameFunc = name, nil
+		hc = &resolved
+	}
+
+	message, attrs = h.handle(ctx, hc, r.Time, r.Level, message, attrs)
+	if h.extractors != nil {
+		attrs = append(h.extractors.extract(ctx), attrs...)
+	}
+	attrs = sampleAttrs(attrs, keepSampled)
+	for _, m := range h.middlewares {
+		message, attrs = m(ctx, hc, r.Time, r.Level, message, attrs)
+	}
+	if h.stackOnce != nil && r.Level >= slog.LevelError && len(errorTypes(nil, attrs)) > 0 {
+		template := MessageTemplate(ctx)
+		if template == "" {
+			template = r.Message
+		}
+		attrs = append(attrs, h.stackOnce.attrs(defaultFingerprint(template, attrs), r.PC, 1)...)
+	}
+	if h.components != nil && r.PC != 0 {
+		if component := h.components.component(r.PC); component != "" {
+			attrs = append(attrs, slog.String(ComponentKey, component))
+		}
+	}
+
+	if !allowed(h.filters, ctx, r.Level, message, attrs) {
+		return summaryErr
+	}
+
+	pc := r.PC
+	if pc != 0 && h.callerWhen != nil && !h.callerWhen(ctx, r.Level, attrs) {
+		pc = 0
+	}
+	if pc != 0 && h.source != nil {
+		attrs = append(h.source.attrs(pc), attrs...)
+		pc = 0
+	}
 
 	// Add all attributes to new record (because old record has all the old attributes as private members)
 	newR := &slog.Record{
 		Time:    r.Time,
 		Level:   r.Level,
 		Message: message,
-		PC:      r.PC,
+		PC:      pc,
 	}
 
 	// Add attributes back in
 	newR.AddAttrs(attrs...)
-	return h.next.Handle(ctx, *newR)
+
+	err := h.forward(withLoggerName(ctx, name), *newR)
+	if summaryErr != nil {
+		return errors.Join(summaryErr, err)
+	}
+	return err
+}
+
+// Close emits the pending summaries of WithErrorCoalescing and stops its background goroutine.
+// Handlers derived from h share what Close stops, so it only needs to be called once.
+//
+// It returns the last error returned by the next handler for a summary, or ctx.Err() if ctx is
+// done first. Close is a no-op if error coalescing is not enabled. The handler keeps handling
+// records after Close.
+func (h *Handler) Close(ctx context.Context) error {
+	if h.coalescer == nil {
+		return nil
+	}
+	return h.coalescer.close(ctx)
 }
 
 // Clone creates a shallow copy of the handler with a deep copy of mutable state.
@@ -181,6 +408,49 @@ func (h *Handler) Clone() *Handler {
 	return &h2
 }
 
+// use returns a new Handler with m appended to the middleware chain.
+func (h *Handler) use(m HandleFunc) *Handler {
+	h2 := h.Clone()
+	h2.middlewares = append(slices.Clip(h.middlewares), m)
+	return h2
+}
+
+// WithFilter returns a new Handler that drops records for which fn returns false.
+//
+// The filter runs after the HandleFunc pipeline (see FilterAfterHandle). Filters added by
+// successive calls are combined with AND: a record is handled only if every filter accepts it.
+// Filters run for every record that reaches Handle, so they should be cheap.
+func (h *Handler) WithFilter(fn FilterFunc) *Handler {
+	return h.WithFilterAt(FilterAfterHandle, fn)
+}
+
+// WithFilterAt is like WithFilter but lets the caller choose when the filter runs.
+//
+// A nil fn is ignored.
+func (h *Handler) WithFilterAt(stage FilterStage, fn FilterFunc) *Handler {
+	if fn == nil {
+		return h
+	}
+
+	h2 := h.Clone()
+	if stage == FilterBeforeHandle {
+		h2.preFilters = append(slices.Clip(h.preFilters), fn)
+	} else {
+		h2.filters = append(slices.Clip(h.filters), fn)
+	}
+	return h2
+}
+
+// allowed reports whether every filter accepts the record.
+func allowed(filters []FilterFunc, ctx context.Context, level slog.Level, msg string, attrs []slog.Attr) bool {
+	for _, f := range filters {
+		if !f(ctx, level, msg, attrs) {
+			return false
+		}
+	}
+	return true
+}
+
 // withGroup returns a new Handler with the given group name added to the attribute chain.
 func (h *Handler) withGroup(name string) *Handler {
 	h2 := h.Clone()
@@ -199,7 +469,11 @@ func (h *Handler) WithGroup(name string) slog.Handler {
 // withAttrs returns a new Handler with the given attributes added to the attribute chain.
 func (h *Handler) withAttrs(attrs []slog.Attr) *Handler {
 	h2 := h.Clone()
-	h2.context.Attrs = h.context.Attrs.WithAttrs(attrs)
+	if h.dedupAttrs {
+		h2.context.Attrs = h.context.Attrs.withAttrsReplacing(attrs)
+	} else {
+		h2.context.Attrs = h.context.Attrs.WithAttrs(attrs)
+	}
 	return h2
 }
 
@@ -220,25 +494,117 @@ func (h *Handler) WithLevel(level slog.Leveler) *Handler {
 	return h2
 }
 
-// Named returns a new Handler with the given name set as the logger's name.
+// WithLevelClamp returns a new Handler that drops the records whose level is below minLevel or
+// above maxLevel, e.g. so that a sink in a MultiHandler only receives Warn and Error records
+// whatever the level of the logger.
+//
+// Unlike WithLevel, which only sets a minimum, the range also bounds the level from above, and
+// records outside of it are dropped even when passed to Handle directly. Both apply when set
+// together. If minLevel is above maxLevel, every record is dropped.
+//
+// Example:
+//
+//	alerts := slogs.NewHandler(pager).WithLevelClamp(slog.LevelWarn, slog.LevelError)
+//	logger := slogs.New(slogs.NewHandler(slogs.MultiHandler(console, alerts)))
+func (h *Handler) WithLevelClamp(minLevel, maxLevel slog.Level) *Handler {
+	h2 := h.Clone()
+	h2.clamp = &levelClamp{min: minLevel, max: maxLevel}
+	return h2
+}
+
+// levelClamp is the level range set by WithLevelClamp.
+type levelClamp struct {
+	min, max slog.Level
+}
+
+// contains reports whether level is in the range; a nil range contains every level.
+func (c *levelClamp) contains(level slog.Level) bool {
+	return c == nil || (level >= c.min && level <= c.max)
+}
+
+// Named returns a new Handler with the given name appended to the logger's name chain.
+//
+// Names are joined with a period, so h.Named("service").Named("database") yields
+// "service.database". An empty name leaves the chain unchanged.
 func (h *Handler) Named(name string) *Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := h.Clone()
+	if h.context.Name == "" {
+		h2.context.Name = name
+	} else {
+		h2.context.Name = h.context.Name + "." + name
+	}
+	return h2
+}
+
+// NamedReset returns a new Handler whose name chain is replaced by name.
+//
+// Use it to start a fresh chain regardless of the names accumulated so far;
+// an empty name clears the chain.
+func (h *Handler) NamedReset(name string) *Handler {
 	h2 := h.Clone()
 	h2.context.Name = name
 	return h2
 }
 
 // Name returns the handler's name.
+//
+// Only the static name set via Named is reported; names derived by WithNameFunc
+// are resolved per record and are not visible here.
 func (h *Handler) Name() string {
 	return h.context.Name
 }
 
+// WithNameFunc returns a new Handler that derives the logger name from each record's context.
+//
+// This allows per-request naming (for example by tenant or component stored in the context)
+// without deriving a new logger for every request. When fn returns a non-empty string it
+// replaces the static name set via Named for that record; when it returns an empty string
+// the static name is used. Passing nil removes a previously configured function.
+func (h *Handler) WithNameFunc(fn func(ctx context.Context) string) *Handler {
+	h2 := h.Clone()
+	h2.context.NameFunc = fn
+	return h2
+}
+
+// WithCallerWhen returns a new Handler that removes the caller information of records for
+// which fn returns false.
+//
+// fn receives the processed attributes, as filters added by WithFilter do, so it sees the
+// attributes from the context and from With, nested in their groups. Passing nil removes a
+// previously configured function. See the WithCallerWhen option to use it from a Logger.
+func (h *Handler) WithCallerWhen(fn func(ctx context.Context, level slog.Level, attrs []slog.Attr) bool) *Handler {
+	h2 := h.Clone()
+	h2.callerWhen = fn
+	return h2
+}
+
+// WithDedupAttrs returns a new Handler whose WithAttrs replaces the attributes already added
+// with the same key instead of appending duplicates, so that derived loggers can override a
+// field: logger.With("env", "dev").With("env", "prod") logs a single env=prod.
+//
+// Only attributes of the current group level are replaced: after WithGroup("http"), a
+// "status" attribute does not replace a top-level "status". Attributes of the record itself
+// and of the context are not affected. Attributes added before WithDedupAttrs are kept as
+// they are, but can be replaced by later calls.
+func (h *Handler) WithDedupAttrs(enabled bool) *Handler {
+	h2 := h.Clone()
+	h2.dedupAttrs = enabled
+	return h2
+}
+
 // DefaultHandleFunc is the default handler function used when no custom HandleFunc is provided.
 //
 // It implements the standard slogs behavior:
 //  1. Appends context attributes from Append() to the end
-//  2. Processes the attribute group chain, applying groups and flattening attributes
+//  2. Processes the attribute group chain, applying groups and flattening attributes;
+//     groups that would contain no attributes are omitted
 //  3. Prepends context attributes from Prepend() to the start
-//  4. Prefixes the message with logger names if any (e.g., "[service.database]")
+//  4. Prefixes the message with logger names if any (e.g., "[service.database]"),
+//     using HandlerContext.NameFunc when it yields a non-empty name
 //
 // This function maintains attribute ordering and ensures proper group structure.
 func DefaultHandleFunc(ctx context.Context, hc *HandlerContext, rt time.Time, rl slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
@@ -249,6 +615,13 @@ func DefaultHandleFunc(ctx context.Context, hc *HandlerContext, rt time.Time, rl
 	// Iterate through the goa (group Or Attributes) linked list, which is ordered from newest to oldest
 	for g := hc.Attrs; g != nil; g = g.next {
 		if g.group != "" {
+			// Omit groups that would end up empty, as the standard library handlers do.
+			// Not every downstream handler does this itself and some emit "group":{}.
+			if !hasAttrs(attrs) {
+				attrs = nil
+				continue
+			}
+
 			// If a group, but all the previous attributes (the newest ones) in it
 			attrs = []slog.Attr{{
 				Key:   g.group,
@@ -265,9 +638,43 @@ func DefaultHandleFunc(ctx context.Context, hc *HandlerContext, rt time.Time, rl
 	prepended := ExtractPrepended(ctx)
 	attrs = append(prepended, attrs...)
 
-	if hc.Name != "" {
-		rm = "[" + hc.Name + "] " + rm
+	if name := hc.resolveName(ctx); name != "" {
+		rm = "[" + name + "] " + rm
 	}
 
 	return rm, attrs
 }
+
+// resolveName returns the effective logger name for a record logged with ctx.
+func (hc *HandlerContext) resolveName(ctx context.Context) string {
+	if hc.NameFunc != nil {
+		if dynamic := hc.NameFunc(ctx); dynamic != "" {
+			return dynamic
+		}
+	}
+	return hc.Name
+}
+
+// hasAttrs reports whether attrs contains at least one attribute a handler would output.
+//
+// Following slog's rules, empty attributes and groups without any non-empty attribute
+// are ignored.
+func hasAttrs(attrs []slog.Attr) bool {
+	for _, a := range attrs {
+		if a.Value.Kind() == slog.KindGroup {
+			if hasAttrs(a.Value.Group()) {
+				return true
+			}
+			continue
+		}
+		if !a.Equal(slog.Attr{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *Handler) unwrap() slog.Handler {
+	return h.next
+}
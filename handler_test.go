@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHandler(t *testing.T) {
@@ -60,6 +62,42 @@ func TestHandler_WithLevel(t *testing.T) {
 	assert.False(t, h2.Enabled(context.Background(), slog.LevelWarn))
 }
 
+func TestHandler_WithLevelClamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		level slog.Level
+		want  bool
+	}{
+		{name: "below min", level: slog.LevelInfo, want: false},
+		{name: "min", level: slog.LevelWarn, want: true},
+		{name: "max", level: slog.LevelError, want: true},
+		{name: "above max", level: slog.LevelError + 4, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			h := NewHandler(next).WithLevelClamp(slog.LevelWarn, slog.LevelError)
+
+			assert.Equal(t, tt.want, h.Enabled(context.Background(), tt.level))
+			require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Time{}, tt.level, "m", 0)))
+			assert.Equal(t, tt.want, next.recordCount() == 1, "records outside the range are dropped by Handle")
+		})
+	}
+}
+
+func TestHandler_WithLevelClamp_Sibling(t *testing.T) {
+	all, alerts := newTestHandler(true), newTestHandler(true)
+	logger := slog.New(MultiHandler(NewHandler(all), NewHandler(alerts).WithLevelClamp(slog.LevelWarn, slog.LevelWarn)))
+
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+
+	assert.Equal(t, 3, all.recordCount())
+	assert.Equal(t, 1, alerts.recordCount())
+}
+
 func TestNewMiddleware(t *testing.T) {
 	buf := &bytes.Buffer{}
 	middleware := NewMiddleware(nil)
@@ -74,6 +112,82 @@ func TestNewHandler_NilPanic(t *testing.T) {
 	})
 }
 
+func TestNewHandlerE(t *testing.T) {
+	h, err := NewHandlerE(nil)
+	assert.ErrorIs(t, err, ErrNilNextHandler)
+	assert.Nil(t, h)
+
+	h, err = NewHandlerE(newTestHandler(true))
+	require.NoError(t, err)
+	assert.NotNil(t, h)
+}
+
+func TestNewHandlerWithOptionsE(t *testing.T) {
+	noop := func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, attrs
+	}
+
+	tests := []struct {
+		name    string
+		next    slog.Handler
+		opts    *HandlerOptions
+		wantErr error
+	}{
+		{name: "nil options", next: newTestHandler(true), opts: nil},
+		{name: "custom handle func", next: newTestHandler(true), opts: &HandlerOptions{HandleFunc: noop}},
+		{name: "handle funcs", next: newTestHandler(true), opts: &HandlerOptions{HandleFuncs: []HandleFunc{noop, DefaultHandleFunc, noop}}},
+		{name: "nil next", next: nil, opts: nil, wantErr: ErrNilNextHandler},
+		{name: "both handle func and handle funcs", next: newTestHandler(true), opts: &HandlerOptions{HandleFunc: noop, HandleFuncs: []HandleFunc{noop}}, wantErr: ErrInvalidOptions},
+		{name: "nil in handle funcs", next: newTestHandler(true), opts: &HandlerOptions{HandleFuncs: []HandleFunc{DefaultHandleFunc, nil}}, wantErr: ErrInvalidOptions},
+		{name: "default handle func twice", next: newTestHandler(true), opts: &HandlerOptions{HandleFuncs: []HandleFunc{DefaultHandleFunc, noop, DefaultHandleFunc}}, wantErr: ErrInvalidOptions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := NewHandlerWithOptionsE(tt.next, tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, h)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, h)
+		})
+	}
+}
+
+func TestNewHandlerWithOptions_InvalidPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewHandlerWithOptions(newTestHandler(true), &HandlerOptions{HandleFuncs: []HandleFunc{nil}})
+	})
+}
+
+func TestHandlerOptions_Validate(t *testing.T) {
+	var nilOpts *HandlerOptions
+	assert.NoError(t, nilOpts.Validate())
+	assert.NoError(t, (&HandlerOptions{}).Validate())
+
+	err := (&HandlerOptions{HandleFuncs: []HandleFunc{nil, DefaultHandleFunc, DefaultHandleFunc}}).Validate()
+	require.ErrorIs(t, err, ErrInvalidOptions)
+	assert.Contains(t, err.Error(), "HandleFuncs[0] is nil")
+	assert.Contains(t, err.Error(), "DefaultHandleFunc appears 2 times")
+}
+
+func TestHandler_HandleFuncs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	upper := func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return strings.ToUpper(rm), attrs
+	}
+	h := NewHandlerWithOptions(slog.NewJSONHandler(buf, nil), &HandlerOptions{
+		HandleFuncs: []HandleFunc{DefaultHandleFunc, upper},
+	})
+
+	slog.New(h.WithAttrs([]slog.Attr{slog.String("k", "v")})).Info("hello")
+
+	assert.Contains(t, buf.String(), `"msg":"HELLO"`)
+	assert.Contains(t, buf.String(), `"k":"v"`)
+}
+
 func TestHandler_Named(t *testing.T) {
 	buf := &bytes.Buffer{}
 	base := slog.NewJSONHandler(buf, nil)
@@ -129,3 +243,408 @@ func TestHandler_WithLevel_Leveler(t *testing.T) {
 	assert.True(t, h2.Enabled(context.Background(), slog.LevelWarn))
 	assert.True(t, h2.Enabled(context.Background(), slog.LevelError))
 }
+
+type ctxKeyName struct{}
+
+func TestHandler_WithNameFunc(t *testing.T) {
+	nameFromCtx := func(ctx context.Context) string {
+		name, _ := ctx.Value(ctxKeyName{}).(string)
+		return name
+	}
+
+	tests := []struct {
+		name       string
+		staticName string
+		ctxName    string
+		wantPrefix string
+	}{
+		{name: "dynamic name without static name", ctxName: "tenant-a", wantPrefix: `"msg":"[tenant-a] test"`},
+		{name: "dynamic name overrides static name", staticName: "svc", ctxName: "tenant-b", wantPrefix: `"msg":"[tenant-b] test"`},
+		{name: "empty dynamic name falls back to static name", staticName: "svc", wantPrefix: `"msg":"[svc] test"`},
+		{name: "no names", wantPrefix: `"msg":"test"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, nil)).Named(tt.staticName).WithNameFunc(nameFromCtx)
+
+			ctx := context.Background()
+			if tt.ctxName != "" {
+				ctx = context.WithValue(ctx, ctxKeyName{}, tt.ctxName)
+			}
+
+			r := slog.NewRecord(time.Time{}, slog.LevelInfo, "test", 0)
+			assert.NoError(t, h.Handle(ctx, r))
+			assert.Contains(t, buf.String(), tt.wantPrefix)
+		})
+	}
+}
+
+func TestHandler_WithNameFunc_ResolvedOnce(t *testing.T) {
+	var names []string
+	next := nameCapturingHandler{Handler: slog.NewTextHandler(&bytes.Buffer{}, nil), names: &names}
+	calls := 0
+	h := NewHandler(next).WithNameFunc(func(context.Context) string {
+		calls++
+		return "dynamic"
+	})
+
+	var seen []string
+	h = h.WithHandleFunc(func(_ context.Context, hc *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		seen = append(seen, hc.Name)
+		return rm, attrs
+	})
+
+	require.True(t, h.Enabled(context.Background(), slog.LevelInfo))
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)))
+	assert.Equal(t, 1, calls, "NameFunc is called once per record, by Handle only")
+	assert.Equal(t, []string{"dynamic"}, seen, "HandleFuncs receive the resolved name")
+	assert.Equal(t, []string{"", "dynamic"}, names)
+}
+
+func TestHandler_Enabled_NoAllocs(t *testing.T) {
+	ctx := context.Background()
+	h := NewHandler(newTestHandler(true))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { h.Enabled(ctx, slog.LevelInfo) }), "unnamed loggers do not wrap the context")
+
+	h = h.WithNameFunc(func(context.Context) string { t.Error("NameFunc called by Enabled"); return "" })
+	assert.True(t, h.Enabled(ctx, slog.LevelInfo))
+}
+
+func TestHandler_WithNameFunc_DoesNotAffectParent(t *testing.T) {
+	h := NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	h2 := h.WithNameFunc(func(context.Context) string { return "x" })
+
+	assert.Nil(t, h.context.NameFunc)
+	assert.NotNil(t, h2.context.NameFunc)
+}
+
+func TestHandler_WithCallerWhen(t *testing.T) {
+	tests := []struct {
+		name   string
+		keep   bool
+		wantPC bool
+	}{
+		{name: "keeps caller", keep: true, wantPC: true},
+		{name: "removes caller", keep: false, wantPC: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			var gotAttrs []slog.Attr
+			h := NewHandler(next).WithCallerWhen(func(_ context.Context, _ slog.Level, attrs []slog.Attr) bool {
+				gotAttrs = attrs
+				return tt.keep
+			})
+
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 1)
+			r.AddAttrs(slog.Bool("debug", true))
+			assert.NoError(t, h.WithAttrs([]slog.Attr{slog.String("k", "v")}).Handle(context.Background(), r))
+
+			assert.Equal(t, []slog.Attr{slog.String("k", "v"), slog.Bool("debug", true)}, gotAttrs)
+			assert.Equal(t, tt.wantPC, next.getRecords()[0].PC != 0)
+		})
+	}
+}
+
+func TestHandler_WithFilter(t *testing.T) {
+	hasKey := func(key string) FilterFunc {
+		return func(_ context.Context, _ slog.Level, _ string, attrs []slog.Attr) bool {
+			for _, a := range attrs {
+				if a.Key == key {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	notDebug := func(_ context.Context, level slog.Level, _ string, _ []slog.Attr) bool {
+		return level > slog.LevelDebug
+	}
+	namedOnly := func(_ context.Context, _ slog.Level, msg string, _ []slog.Attr) bool {
+		return strings.HasPrefix(msg, "[svc] ")
+	}
+
+	tests := []struct {
+		name    string
+		build   func(h *Handler) *Handler
+		ctx     context.Context
+		level   slog.Level
+		attrs   []slog.Attr
+		wantLog bool
+	}{
+		{
+			name:    "accepted record is handled",
+			build:   func(h *Handler) *Handler { return h.WithFilter(notDebug) },
+			level:   slog.LevelInfo,
+			wantLog: true,
+		},
+		{
+			name:    "rejected record is dropped",
+			build:   func(h *Handler) *Handler { return h.WithFilter(notDebug) },
+			level:   slog.LevelDebug,
+			wantLog: false,
+		},
+		{
+			name:    "filters are combined with AND",
+			build:   func(h *Handler) *Handler { return h.WithFilter(notDebug).WithFilter(hasKey("keep")) },
+			level:   slog.LevelInfo,
+			attrs:   []slog.Attr{slog.String("other", "x")},
+			wantLog: false,
+		},
+		{
+			name:    "after-handle filter sees context attrs",
+			build:   func(h *Handler) *Handler { return h.WithFilter(hasKey("keep")) },
+			ctx:     Prepend(context.Background(), "keep", true),
+			level:   slog.LevelInfo,
+			wantLog: true,
+		},
+		{
+			name:    "before-handle filter does not see context attrs",
+			build:   func(h *Handler) *Handler { return h.WithFilterAt(FilterBeforeHandle, hasKey("keep")) },
+			ctx:     Prepend(context.Background(), "keep", true),
+			level:   slog.LevelInfo,
+			wantLog: false,
+		},
+		{
+			name:    "after-handle filter sees processed message",
+			build:   func(h *Handler) *Handler { return h.Named("svc").WithFilter(namedOnly) },
+			level:   slog.LevelInfo,
+			wantLog: true,
+		},
+		{
+			name:    "before-handle filter sees raw message",
+			build:   func(h *Handler) *Handler { return h.Named("svc").WithFilterAt(FilterBeforeHandle, namedOnly) },
+			level:   slog.LevelInfo,
+			wantLog: false,
+		},
+		{
+			name:    "nil filter is ignored",
+			build:   func(h *Handler) *Handler { return h.WithFilter(nil) },
+			level:   slog.LevelInfo,
+			wantLog: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			h := tt.build(NewHandler(next))
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			r := slog.NewRecord(time.Time{}, tt.level, "test", 0)
+			r.AddAttrs(tt.attrs...)
+			assert.NoError(t, h.Handle(ctx, r))
+
+			if tt.wantLog {
+				assert.Equal(t, 1, next.recordCount())
+			} else {
+				assert.Equal(t, 0, next.recordCount())
+			}
+		})
+	}
+}
+
+func TestHandler_WithFilter_DoesNotAffectParent(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewHandler(next)
+	_ = h.WithFilter(func(context.Context, slog.Level, string, []slog.Attr) bool { return false })
+
+	assert.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "test", 0)))
+	assert.Equal(t, 1, next.recordCount())
+}
+
+func TestHandler_Named_Chain(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(h *Handler) *Handler
+		want  string
+	}{
+		{name: "single name", build: func(h *Handler) *Handler { return h.Named("a") }, want: "a"},
+		{name: "appends names", build: func(h *Handler) *Handler { return h.Named("a").Named("b").Named("c") }, want: "a.b.c"},
+		{name: "empty name is ignored", build: func(h *Handler) *Handler { return h.Named("a").Named("").Named("b") }, want: "a.b"},
+		{name: "reset replaces chain", build: func(h *Handler) *Handler { return h.Named("a").Named("b").NamedReset("c") }, want: "c"},
+		{name: "append after reset", build: func(h *Handler) *Handler { return h.Named("a").NamedReset("b").Named("c") }, want: "b.c"},
+		{name: "reset with empty clears chain", build: func(h *Handler) *Handler { return h.Named("a").NamedReset("") }, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.build(NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil)))
+			assert.Equal(t, tt.want, h.Name())
+		})
+	}
+}
+
+func TestDefaultHandleFunc_OmitsEmptyGroups(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(h *Handler) *Handler
+		ctx   context.Context
+		attrs []slog.Attr
+		want  []slog.Attr
+	}{
+		{
+			name:  "group without attrs is omitted",
+			build: func(h *Handler) *Handler { return h.withGroup("g") },
+			want:  []slog.Attr{},
+		},
+		{
+			name:  "nested empty groups are omitted",
+			build: func(h *Handler) *Handler { return h.withGroup("g").withGroup("h") },
+			want:  []slog.Attr{},
+		},
+		{
+			name:  "group holding only empty attrs is omitted",
+			build: func(h *Handler) *Handler { return h.withGroup("g") },
+			attrs: []slog.Attr{{}, slog.Group("x")},
+			want:  []slog.Attr{},
+		},
+		{
+			name: "outer group kept when inner group is empty",
+			build: func(h *Handler) *Handler {
+				return h.withGroup("g").withAttrs([]slog.Attr{slog.Int("a", 1)}).withGroup("h")
+			},
+			want: []slog.Attr{slog.Group("g", slog.Int("a", 1))},
+		},
+		{
+			name:  "group with only appended context attrs is kept",
+			build: func(h *Handler) *Handler { return h.withGroup("g") },
+			ctx:   Append(context.Background(), "c", 1),
+			want:  []slog.Attr{slog.Group("g", slog.Int("c", 1))},
+		},
+		{
+			name:  "group with record attrs is kept",
+			build: func(h *Handler) *Handler { return h.withGroup("g") },
+			attrs: []slog.Attr{slog.String("k", "v")},
+			want:  []slog.Attr{slog.Group("g", slog.String("k", "v"))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			h := tt.build(NewHandler(next))
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			r := slog.NewRecord(time.Time{}, slog.LevelInfo, "test", 0)
+			r.AddAttrs(tt.attrs...)
+			assert.NoError(t, h.Handle(ctx, r))
+
+			records := next.getRecords()
+			assert.Len(t, records, 1)
+
+			got := []slog.Attr{}
+			records[0].Attrs(func(a slog.Attr) bool {
+				got = append(got, a)
+				return true
+			})
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.True(t, tt.want[i].Equal(got[i]), "attr %d: want %v, got %v", i, tt.want[i], got[i])
+			}
+		})
+	}
+}
+
+func TestDefaultHandleFunc_EmptyGroupsMatchStdlib(t *testing.T) {
+	noTime := &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}
+
+	logs := []func(l *slog.Logger){
+		func(l *slog.Logger) { l.WithGroup("g").Info("m") },
+		func(l *slog.Logger) { l.WithGroup("g").WithGroup("h").Info("m", slog.Group("x")) },
+		func(l *slog.Logger) { l.WithGroup("g").With("a", 1).WithGroup("h").Info("m") },
+	}
+
+	for i, log := range logs {
+		stdBuf, buf := &bytes.Buffer{}, &bytes.Buffer{}
+		log(slog.New(slog.NewJSONHandler(stdBuf, noTime)))
+		log(slog.New(NewHandler(slog.NewJSONHandler(buf, noTime))))
+		assert.Equal(t, stdBuf.String(), buf.String(), "case %d", i)
+	}
+}
+
+func TestHandler_WithDedupAttrs(t *testing.T) {
+	noTime := &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}
+
+	tests := []struct {
+		name   string
+		dedup  bool
+		log    func(l *slog.Logger)
+		expect string
+	}{
+		{
+			name:   "last wins",
+			dedup:  true,
+			log:    func(l *slog.Logger) { l.With("env", "dev", "a", 1).With("env", "prod").Info("m") },
+			expect: `{"level":"INFO","msg":"m","a":1,"env":"prod"}`,
+		},
+		{
+			name:   "repeated key in one call",
+			dedup:  true,
+			log:    func(l *slog.Logger) { l.With("env", "dev", "env", "prod").Info("m") },
+			expect: `{"level":"INFO","msg":"m","env":"prod"}`,
+		},
+		{
+			name:   "other group level kept",
+			dedup:  true,
+			log:    func(l *slog.Logger) { l.With("status", 1).WithGroup("http").With("status", 2).Info("m") },
+			expect: `{"level":"INFO","msg":"m","status":1,"http":{"status":2}}`,
+		},
+		{
+			name:   "within group",
+			dedup:  true,
+			log:    func(l *slog.Logger) { l.WithGroup("http").With("status", 1).With("status", 2).Info("m") },
+			expect: `{"level":"INFO","msg":"m","http":{"status":2}}`,
+		},
+		{
+			name:   "disabled",
+			dedup:  false,
+			log:    func(l *slog.Logger) { l.With("env", "dev").With("env", "prod").Info("m") },
+			expect: `{"level":"INFO","msg":"m","env":"dev","env":"prod"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, noTime)).WithDedupAttrs(tt.dedup)
+			tt.log(slog.New(h))
+			assert.Equal(t, tt.expect+"\n", buf.String())
+		})
+	}
+}
+
+func TestHandler_WithDedupAttrs_DoesNotAffectParent(t *testing.T) {
+	parentBuf, childBuf := &bytes.Buffer{}, &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(parentBuf, nil)).WithDedupAttrs(true)
+	parent := h.WithAttrs([]slog.Attr{slog.String("env", "dev")}).(*Handler)
+	child := parent.WithAttrs([]slog.Attr{slog.String("env", "prod")}).(*Handler)
+	child.next = slog.NewJSONHandler(childBuf, nil)
+
+	slog.New(parent).Info("m")
+	slog.New(child).Info("m")
+	assert.Contains(t, parentBuf.String(), `"env":"dev"`)
+	assert.Contains(t, childBuf.String(), `"env":"prod"`)
+	assert.NotContains(t, childBuf.String(), `"env":"dev"`)
+}
@@ -0,0 +1,69 @@
+package slogs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// hashSize is the number of bytes of the HMAC-SHA256 kept by Handler.WithHashKeys: 128 bits
+// are more than enough to avoid collisions between pseudonyms, in half the width.
+const hashSize = 16
+
+// WithHashKeys returns a new Handler that replaces the values of the attributes with the given
+// keys by a pseudonym: the hex-encoded HMAC-SHA256 of their string form keyed with salt,
+// truncated to 128 bits. Unlike redaction, the same value always gets the same pseudonym, so
+// records about the same user can still be correlated without the logs holding the raw email
+// address or identifier.
+//
+// Keys are matched against leaf attributes at every level, including inside groups. Values are
+// converted to strings as by WithStringifyValues, so 42 and "42" get the same pseudonym. With
+// no keys, h is returned.
+//
+// The salt is what prevents recovering values by hashing candidates, e.g. every known email
+// address, so it must be kept secret like a password: load it from a secret store rather than
+// the source code, and keep it out of the logs. Pseudonyms only correlate across logs written
+// with the same salt; rotating it starts a new set of pseudonyms, which can be used to cut off
+// correlation with older logs.
+//
+// Panics if salt is empty.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithHashKeys([]string{"email", "user_id"}, salt)
+//	logger := slog.New(handler)
+//	logger.Info("login", "email", "alice@example.com") // {"msg":"login","email":"5c1b3f0e..."}
+func (h *Handler) WithHashKeys(keys []string, salt []byte) *Handler {
+	if len(salt) == 0 {
+		panic("slogs: salt cannot be empty")
+	}
+	if len(keys) == 0 {
+		return h
+	}
+
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[key] = struct{}{}
+	}
+	salt = slices.Clone(salt)
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, replaceAttrs(nil, attrs, func(_ []string, a slog.Attr) slog.Attr {
+			if _, ok := set[a.Key]; ok {
+				a.Value = slog.StringValue(hashValue(salt, stringifyValue(a.Value)))
+			}
+			return a
+		})
+	})
+}
+
+// hashValue returns the pseudonym of s, see Handler.WithHashKeys.
+func hashValue(salt []byte, s string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil)[:hashSize])
+}
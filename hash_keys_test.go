@@ -0,0 +1,84 @@
+package slogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_WithHashKeys(t *testing.T) {
+	salt := []byte("secret")
+	alice := hashValue(salt, "alice@example.com")
+
+	tests := []struct {
+		name     string
+		log      func(l *slog.Logger)
+		expected string
+	}{
+		{
+			name:     "top-level key",
+			log:      func(l *slog.Logger) { l.Info("m", "email", "alice@example.com", "n", 1) },
+			expected: `{"level":"INFO","msg":"m","email":"` + alice + `","n":1}`,
+		},
+		{
+			name:     "nested key",
+			log:      func(l *slog.Logger) { l.WithGroup("g").Info("m", slog.Group("user", "email", "alice@example.com")) },
+			expected: `{"level":"INFO","msg":"m","g":{"user":{"email":"` + alice + `"}}}`,
+		},
+		{
+			name:     "attributes from With",
+			log:      func(l *slog.Logger) { l.With("email", "alice@example.com").Info("m") },
+			expected: `{"level":"INFO","msg":"m","email":"` + alice + `"}`,
+		},
+		{
+			name:     "non-string value",
+			log:      func(l *slog.Logger) { l.Info("m", "user_id", 42) },
+			expected: `{"level":"INFO","msg":"m","user_id":"` + hashValue(salt, "42") + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+				WithHashKeys([]string{"email", "user_id"}, salt)
+
+			tt.log(slog.New(h))
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestHashValue(t *testing.T) {
+	a := hashValue([]byte("salt-1"), "alice")
+
+	assert.Len(t, a, 2*hashSize)
+	assert.Equal(t, a, hashValue([]byte("salt-1"), "alice"), "stable pseudonym")
+	assert.NotEqual(t, a, hashValue([]byte("salt-1"), "bob"), "distinct values")
+	assert.NotEqual(t, a, hashValue([]byte("salt-2"), "alice"), "distinct salts")
+	// HMAC-SHA256("key", "The quick brown fox jumps over the lazy dog"), truncated.
+	assert.Equal(t, "f7bc83f430538424b13298e6aa6fb143", hashValue([]byte("key"), "The quick brown fox jumps over the lazy dog"))
+}
+
+func TestHandler_WithHashKeys_Salt(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+
+	assert.PanicsWithValue(t, "slogs: salt cannot be empty", func() { h.WithHashKeys([]string{"email"}, nil) })
+	assert.Same(t, h, h.WithHashKeys(nil, []byte("s")))
+
+	// The salt is copied, so changing the caller's slice does not change the pseudonyms.
+	var buf bytes.Buffer
+	salt := []byte("secret")
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil)).WithHashKeys([]string{"email"}, salt))
+	salt[0] = 'X'
+	logger.Info("m", "email", "alice")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, hashValue([]byte("secret"), "alice"), got["email"])
+}
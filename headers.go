@@ -0,0 +1,59 @@
+package slogs
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// defaultSensitiveHeaders are the headers always redacted by Headers, since they carry
+// credentials.
+var defaultSensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// HeadersOptions configures Headers.
+type HeadersOptions struct {
+	// Sensitive lists headers to redact in addition to Authorization, Proxy-Authorization,
+	// Cookie and Set-Cookie, e.g. "X-Api-Key". Names are case-insensitive.
+	Sensitive []string
+}
+
+// Headers constructs a group attribute holding the headers of h, so that request and response
+// headers can be logged without leaking credentials.
+//
+// The values of the Authorization, Proxy-Authorization, Cookie and Set-Cookie headers and of
+// the headers in opts.Sensitive are replaced by "[REDACTED]". Headers with several values are
+// collapsed into a single string, with the values separated by ", " as in a combined header
+// field. Headers are sorted by name. opts may be nil.
+//
+// Example:
+//
+//	logger.Info("request", slogs.Headers("headers", r.Header, nil))
+//	// headers.Accept=*/* headers.Authorization=[REDACTED]
+func Headers(key string, h http.Header, opts *HeadersOptions) slog.Attr {
+	sensitive := make(map[string]bool, len(defaultSensitiveHeaders))
+	for _, name := range defaultSensitiveHeaders {
+		sensitive[http.CanonicalHeaderKey(name)] = true
+	}
+	if opts != nil {
+		for _, name := range opts.Sensitive {
+			sensitive[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attrs := make([]slog.Attr, 0, len(names))
+	for _, name := range names {
+		if sensitive[http.CanonicalHeaderKey(name)] {
+			attrs = append(attrs, slog.String(name, "[REDACTED]"))
+		} else {
+			attrs = append(attrs, slog.String(name, strings.Join(h[name], ", ")))
+		}
+	}
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}
+}
@@ -0,0 +1,63 @@
+package slogs
+
+import (
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret")
+	h.Add("Accept", "text/html")
+	h.Add("Accept", "application/json")
+	h.Set("Cookie", "session=abc")
+	h.Set("X-Api-Key", "k-123")
+	h["lowercase-token"] = []string{"t"}
+
+	tests := []struct {
+		name string
+		opts *HeadersOptions
+		want []slog.Attr
+	}{
+		{
+			name: "default redaction",
+			opts: nil,
+			want: []slog.Attr{
+				slog.String("Accept", "text/html, application/json"),
+				slog.String("Authorization", "[REDACTED]"),
+				slog.String("Cookie", "[REDACTED]"),
+				slog.String("X-Api-Key", "k-123"),
+				slog.String("lowercase-token", "t"),
+			},
+		},
+		{
+			name: "additional sensitive headers",
+			opts: &HeadersOptions{Sensitive: []string{"x-api-key", "Lowercase-Token"}},
+			want: []slog.Attr{
+				slog.String("Accept", "text/html, application/json"),
+				slog.String("Authorization", "[REDACTED]"),
+				slog.String("Cookie", "[REDACTED]"),
+				slog.String("X-Api-Key", "[REDACTED]"),
+				slog.String("lowercase-token", "[REDACTED]"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Headers("headers", h, tt.opts)
+
+			assert.Equal(t, "headers", a.Key)
+			assert.Equal(t, slog.KindGroup, a.Value.Kind())
+			assert.Equal(t, tt.want, a.Value.Group())
+		})
+	}
+}
+
+func TestHeaders_Empty(t *testing.T) {
+	a := Headers("headers", nil, nil)
+	assert.Empty(t, a.Value.Group())
+}
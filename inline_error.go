@@ -0,0 +1,34 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// WithInlineError returns a new Handler that removes the top-level attribute named key, e.g.
+// ErrorKey, and appends its value to the message as ": <err>", which reads better on a console
+// than a separate field.
+//
+// It changes the structure of the records, so it is meant for the Handler wrapping a text or
+// console sink, while the other sinks keep the error as an attribute:
+//
+//	console := slogs.NewHandler(slog.NewTextHandler(os.Stderr, nil)).WithInlineError(slogs.ErrorKey)
+//	logger := slogs.New(slogs.NewHandler(slogs.MultiHandler(console, jsonHandler)))
+//	logger.Error("saving order", slogs.Err(err))
+//	// console: level=ERROR msg="saving order: disk full"
+//	// JSON:    {"level":"ERROR","msg":"saving order","error":"disk full"}
+//
+// Only the first attribute named key is inlined. Records without it are left unchanged.
+func (h *Handler) WithInlineError(key string) *Handler {
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		i := slices.IndexFunc(attrs, func(a slog.Attr) bool { return a.Key == key })
+		if i < 0 {
+			return rm, attrs
+		}
+
+		rm += ": " + attrs[i].Value.Resolve().String()
+		return rm, slices.Delete(slices.Clone(attrs), i, i+1)
+	})
+}
@@ -0,0 +1,52 @@
+package slogs
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_WithInlineError(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      func(l *Logger)
+		wantText string
+		wantJSON string
+	}{
+		{
+			name:     "error inlined",
+			log:      func(l *Logger) { l.Error("saving order", "id", 7, Err(errors.New("disk full"))) },
+			wantText: "level=ERROR msg=\"saving order: disk full\" id=7\n",
+			wantJSON: `{"level":"ERROR","msg":"saving order","id":7,"error":"disk full"}`,
+		},
+		{
+			name:     "no error",
+			log:      func(l *Logger) { l.Info("saved", "id", 7) },
+			wantText: "level=INFO msg=saved id=7\n",
+			wantJSON: `{"level":"INFO","msg":"saved","id":7}`,
+		},
+		{
+			name:     "grouped error kept",
+			log:      func(l *Logger) { l.WithGroup("g").Error("failed", Err(errors.New("boom"))) },
+			wantText: "level=ERROR msg=failed g.error=boom\n",
+			wantJSON: `{"level":"ERROR","msg":"failed","g":{"error":"boom"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, js := &bytes.Buffer{}, &bytes.Buffer{}
+			opts := &slog.HandlerOptions{ReplaceAttr: dropTime}
+			console := NewHandler(slog.NewTextHandler(text, opts)).WithInlineError(ErrorKey)
+			logger := New(NewHandler(MultiHandler(console, slog.NewJSONHandler(js, opts))))
+
+			tt.log(logger)
+
+			assert.Equal(t, tt.wantText, text.String())
+			assert.JSONEq(t, tt.wantJSON, js.String())
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package slogs
+
+import "log/slog"
+
+// Additional levels commonly needed on top of the four slog levels.
+//
+// slog renders unknown levels relative to the nearest standard one (e.g. LevelNotice
+// becomes "INFO+2"). Use LevelNameReplacer to render them by name.
+const (
+	LevelTrace  = slog.LevelDebug - 4
+	LevelNotice = slog.LevelInfo + 2
+	LevelFatal  = slog.LevelError + 4
+)
+
+// LevelName returns the display name of level.
+//
+// names is consulted first, then the levels defined by this package, and finally the
+// level's own String method.
+func LevelName(level slog.Level, names map[slog.Level]string) string {
+	if name, ok := names[level]; ok {
+		return name
+	}
+
+	switch level {
+	case LevelTrace:
+		return "TRACE"
+	case LevelNotice:
+		return "NOTICE"
+	case LevelFatal:
+		return "FATAL"
+	default:
+		return level.String()
+	}
+}
+
+// LevelNameReplacer returns a slog.HandlerOptions.ReplaceAttr function that renders
+// the record level using LevelName, so custom levels display as readable names.
+//
+// There are two ways to get readable custom levels end-to-end. The first is to plug the
+// replacer into the options of the terminal slog handler, which covers the built-in level
+// field of the JSON and Text handlers:
+//
+//	opts := &slog.HandlerOptions{
+//		Level:       slogs.LevelTrace,
+//		ReplaceAttr: slogs.LevelNameReplacer(map[slog.Level]string{slog.LevelInfo + 1: "AUDIT"}),
+//	}
+//	logger := slogs.New(slogs.NewHandler(slog.NewJSONHandler(os.Stdout, opts)))
+//	logger.Log(ctx, slogs.LevelNotice, "disk almost full") // "level":"NOTICE"
+//
+// The second, for sinks whose options cannot be changed, is to call LevelName yourself,
+// for example from a HandleFunc that adds the name as an ordinary attribute.
+//
+// Only the top-level level attribute is replaced; attributes in groups are left untouched.
+func LevelNameReplacer(names map[slog.Level]string) func(groups []string, a slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) > 0 || a.Key != slog.LevelKey {
+			return a
+		}
+
+		level, ok := a.Value.Any().(slog.Level)
+		if !ok {
+			return a
+		}
+		return slog.String(a.Key, LevelName(level, names))
+	}
+}
@@ -0,0 +1,102 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelName(t *testing.T) {
+	custom := map[slog.Level]string{
+		slog.LevelInfo + 1: "AUDIT",
+		LevelNotice:        "NOTE",
+	}
+
+	tests := []struct {
+		name  string
+		level slog.Level
+		names map[slog.Level]string
+		want  string
+	}{
+		{name: "standard level", level: slog.LevelWarn, want: "WARN"},
+		{name: "trace", level: LevelTrace, want: "TRACE"},
+		{name: "notice", level: LevelNotice, want: "NOTICE"},
+		{name: "fatal", level: LevelFatal, want: "FATAL"},
+		{name: "unknown level", level: slog.LevelError + 1, want: "ERROR+1"},
+		{name: "custom name", level: slog.LevelInfo + 1, names: custom, want: "AUDIT"},
+		{name: "custom name overrides package name", level: LevelNotice, names: custom, want: "NOTE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LevelName(tt.level, tt.names))
+		})
+	}
+}
+
+func TestLevelNameReplacer(t *testing.T) {
+	replacer := LevelNameReplacer(map[slog.Level]string{slog.LevelInfo + 1: "AUDIT"})
+
+	tests := []struct {
+		name    string
+		newSink func(buf *bytes.Buffer) slog.Handler
+		level   slog.Level
+		want    string
+	}{
+		{
+			name: "json notice",
+			newSink: func(buf *bytes.Buffer) slog.Handler {
+				return slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: LevelTrace, ReplaceAttr: replacer})
+			},
+			level: LevelNotice,
+			want:  `"level":"NOTICE"`,
+		},
+		{
+			name: "json custom",
+			newSink: func(buf *bytes.Buffer) slog.Handler {
+				return slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: LevelTrace, ReplaceAttr: replacer})
+			},
+			level: slog.LevelInfo + 1,
+			want:  `"level":"AUDIT"`,
+		},
+		{
+			name: "text trace",
+			newSink: func(buf *bytes.Buffer) slog.Handler {
+				return slog.NewTextHandler(buf, &slog.HandlerOptions{Level: LevelTrace, ReplaceAttr: replacer})
+			},
+			level: LevelTrace,
+			want:  "level=TRACE",
+		},
+		{
+			name: "text standard level unchanged",
+			newSink: func(buf *bytes.Buffer) slog.Handler {
+				return slog.NewTextHandler(buf, &slog.HandlerOptions{Level: LevelTrace, ReplaceAttr: replacer})
+			},
+			level: slog.LevelWarn,
+			want:  "level=WARN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := New(NewHandler(tt.newSink(buf)))
+
+			logger.Log(context.Background(), tt.level, "msg")
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestLevelNameReplacer_IgnoresGroupedAttrs(t *testing.T) {
+	replacer := LevelNameReplacer(nil)
+
+	a := slog.Any(slog.LevelKey, LevelNotice)
+	assert.Equal(t, a, replacer([]string{"g"}, a))
+
+	plain := slog.String(slog.LevelKey, "custom")
+	assert.Equal(t, plain, replacer(nil, plain))
+}
@@ -0,0 +1,61 @@
+package slogs
+
+import (
+	"log/slog"
+	"math"
+	"sync/atomic"
+)
+
+// noLevel is the tracked level before any record is logged.
+const noLevel = math.MinInt64
+
+// levelTracker records the highest level logged by a logger tree.
+type levelTracker struct {
+	level atomic.Int64
+}
+
+func newLevelTracker() *levelTracker {
+	t := &levelTracker{}
+	t.level.Store(noLevel)
+	return t
+}
+
+// observe raises the tracked level to level if it is higher.
+func (t *levelTracker) observe(level slog.Level) {
+	for {
+		current := t.level.Load()
+		if int64(level) <= current || t.level.CompareAndSwap(current, int64(level)) {
+			return
+		}
+	}
+}
+
+// HighestLevel returns the highest level logged since the logger was created or
+// ResetHighestLevel was last called. ok is false if nothing was logged or the logger
+// was not created with WithCLIMode.
+//
+// Loggers derived from the same logger share the tracked level, so main can check it
+// once before exiting:
+//
+//	if level, ok := logger.HighestLevel(); ok && level >= slog.LevelError {
+//		os.Exit(1)
+//	}
+func (l *Logger) HighestLevel() (level slog.Level, ok bool) {
+	if l.levels == nil {
+		return 0, false
+	}
+
+	v := l.levels.level.Load()
+	if v == noLevel {
+		return 0, false
+	}
+	return slog.Level(v), true
+}
+
+// ResetHighestLevel forgets the levels logged so far, for example between the commands
+// of an interactive CLI. It is a no-op if the logger was not created with WithCLIMode.
+func (l *Logger) ResetHighestLevel() {
+	if l.levels != nil {
+		l.levels.level.Store(noLevel)
+	}
+}
@@ -0,0 +1,107 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_HighestLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      func(l *Logger)
+		expected slog.Level
+		ok       bool
+	}{
+		{
+			name: "nothing logged",
+			log:  func(*Logger) {},
+		},
+		{
+			name: "highest wins",
+			log: func(l *Logger) {
+				l.Info("a")
+				l.Error("b")
+				l.Warn("c")
+			},
+			expected: slog.LevelError,
+			ok:       true,
+		},
+		{
+			name: "derived loggers share the level",
+			log: func(l *Logger) {
+				l.With("k", "v").Named("child").Warn("a")
+				l.Sugar().Debugf("b %d", 1)
+			},
+			expected: slog.LevelWarn,
+			ok:       true,
+		},
+		{
+			name: "disabled levels are not tracked",
+			log: func(l *Logger) {
+				l.WithOptions(WithLevel(slog.LevelInfo)).Debug("a")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := New(NewHandler(newTestHandler(true)), WithCLIMode(true))
+			tt.log(logger)
+
+			level, ok := logger.HighestLevel()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestLogger_ResetHighestLevel(t *testing.T) {
+	logger := New(NewHandler(newTestHandler(true)), WithCLIMode(true))
+
+	logger.Error("failed")
+	logger.ResetHighestLevel()
+	_, ok := logger.HighestLevel()
+	assert.False(t, ok)
+
+	logger.Debug("debug")
+	level, ok := logger.HighestLevel()
+	assert.True(t, ok)
+	assert.Equal(t, slog.LevelDebug, level)
+}
+
+func TestLogger_HighestLevel_Disabled(t *testing.T) {
+	for _, logger := range []*Logger{
+		New(NewHandler(newTestHandler(true))),
+		New(NewHandler(newTestHandler(true)), WithCLIMode(true), WithCLIMode(false)),
+	} {
+		logger.Error("failed")
+		logger.ResetHighestLevel()
+
+		_, ok := logger.HighestLevel()
+		assert.False(t, ok)
+	}
+}
+
+func TestLogger_HighestLevel_Concurrent(t *testing.T) {
+	logger := New(NewHandler(newTestHandler(true)), WithCLIMode(true))
+
+	var wg sync.WaitGroup
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelError, slog.LevelWarn} {
+		wg.Add(1)
+		go func(level slog.Level) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				logger.Log(context.Background(), level, "msg")
+			}
+		}(level)
+	}
+	wg.Wait()
+
+	level, ok := logger.HighestLevel()
+	assert.True(t, ok)
+	assert.Equal(t, slog.LevelError, level)
+}
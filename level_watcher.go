@@ -0,0 +1,135 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var _ Closer = (*LevelWatcher)(nil)
+
+// LevelWatcher logs a record whenever a slog.LevelVar changes, to keep an audit trail of the
+// verbosity changes made at runtime.
+//
+// Changes made with LevelWatcher.Set are logged right away, with who made them. Changes made
+// directly on the LevelVar are detected by polling it, so they are logged within an interval
+// and without the author; a change reverted before the next poll is not logged.
+//
+// The record is logged at slog.LevelInfo with the attributes "old" and "new", and "changed_by"
+// when known. If logger is itself governed by the LevelVar, raising the level above Info
+// suppresses the record, so an audit logger with a fixed level should be used instead.
+//
+// Close must be called to stop the background goroutine.
+//
+// Example:
+//
+//	level := new(slog.LevelVar)
+//	watcher := slogs.NewLevelWatcher(level, auditLogger, nil, 10*time.Second)
+//	defer watcher.Close(context.Background())
+//	watcher.Set(slog.LevelDebug, "alice") // level=INFO msg="log level changed" old=INFO new=DEBUG changed_by=alice
+type LevelWatcher struct {
+	v      *slog.LevelVar
+	logger *Logger
+
+	mu     sync.Mutex
+	last   slog.Level
+	closed bool
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewLevelWatcher creates a LevelWatcher logging the changes of v to logger, polling v every
+// interval. The interval is measured with clock, or DefaultClock if clock is nil, and defaults
+// to one second if it is <= 0.
+//
+// Panics if v or logger is nil.
+func NewLevelWatcher(v *slog.LevelVar, logger *Logger, clock Clock, interval time.Duration) *LevelWatcher {
+	if v == nil || logger == nil {
+		panic("slogs: level var and logger cannot be nil")
+	}
+	if clock == nil {
+		clock = DefaultClock
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	w := &LevelWatcher{
+		v:       v,
+		logger:  logger,
+		last:    v.Level(),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go w.run(clock.NewTicker(interval))
+
+	return w
+}
+
+// Set sets the level of the LevelVar and logs the change, if any, as made by changedBy, e.g.
+// the user or the remote address of the request that changed it. changedBy may be empty.
+func (w *LevelWatcher) Set(level slog.Level, changedBy string) {
+	w.mu.Lock()
+	old := w.last
+	w.v.Set(level)
+	w.last = level
+	w.mu.Unlock()
+
+	w.logChange(old, level, changedBy)
+}
+
+// Close stops polling the LevelVar. It returns ctx.Err() if ctx is done before the background
+// goroutine stops.
+func (w *LevelWatcher) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.stop)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *LevelWatcher) run(ticker *time.Ticker) {
+	defer close(w.stopped)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.poll()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// poll logs the change of the LevelVar since the last poll or Set, if any.
+func (w *LevelWatcher) poll() {
+	w.mu.Lock()
+	old, level := w.last, w.v.Level()
+	w.last = level
+	w.mu.Unlock()
+
+	w.logChange(old, level, "")
+}
+
+func (w *LevelWatcher) logChange(old, level slog.Level, changedBy string) {
+	if old == level {
+		return
+	}
+
+	attrs := []slog.Attr{slog.String("old", old.String()), slog.String("new", level.String())}
+	if changedBy != "" {
+		attrs = append(attrs, slog.String("changed_by", changedBy))
+	}
+	w.logger.LogAttrs(context.Background(), slog.LevelInfo, "log level changed", attrs...)
+}
@@ -0,0 +1,81 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rockcookies/go-slogs/slogstest"
+)
+
+func TestLevelWatcher(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(v *slog.LevelVar, w *LevelWatcher, clock *slogstest.FakeClock)
+		want   []map[string]string
+	}{
+		{
+			name:   "Set",
+			change: func(_ *slog.LevelVar, w *LevelWatcher, _ *slogstest.FakeClock) { w.Set(slog.LevelDebug, "alice") },
+			want:   []map[string]string{{"old": "INFO", "new": "DEBUG", "changed_by": "alice"}},
+		},
+		{
+			name:   "Set without author",
+			change: func(_ *slog.LevelVar, w *LevelWatcher, _ *slogstest.FakeClock) { w.Set(slog.LevelWarn, "") },
+			want:   []map[string]string{{"old": "INFO", "new": "WARN"}},
+		},
+		{
+			name:   "Set to the same level",
+			change: func(_ *slog.LevelVar, w *LevelWatcher, _ *slogstest.FakeClock) { w.Set(slog.LevelInfo, "alice") },
+		},
+		{
+			name: "direct change",
+			change: func(v *slog.LevelVar, _ *LevelWatcher, clock *slogstest.FakeClock) {
+				v.Set(slog.LevelError)
+				clock.Advance(time.Second)
+				clock.Advance(time.Second)
+			},
+			want: []map[string]string{{"old": "INFO", "new": "ERROR"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			next := newTestHandler(true)
+			v := new(slog.LevelVar)
+			w := NewLevelWatcher(v, New(NewHandler(next)), clock, time.Second)
+
+			tt.change(v, w, clock)
+			require.NoError(t, w.Close(context.Background()))
+
+			var got []map[string]string
+			for _, r := range next.getRecords() {
+				assert.Equal(t, "log level changed", r.Message)
+				attrs := make(map[string]string)
+				r.Attrs(func(a slog.Attr) bool {
+					attrs[a.Key] = a.Value.String()
+					return true
+				})
+				got = append(got, attrs)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLevelWatcher_Close(t *testing.T) {
+	w := NewLevelWatcher(new(slog.LevelVar), New(NewHandler(newTestHandler(true))), newFakeClock(), time.Second)
+	require.NoError(t, w.Close(context.Background()))
+	require.NoError(t, w.Close(context.Background()))
+}
+
+func TestNewLevelWatcher_Nil(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: level var and logger cannot be nil", func() {
+		NewLevelWatcher(nil, New(NewHandler(newTestHandler(true))), nil, 0)
+	})
+}
@@ -0,0 +1,114 @@
+package slogs
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+)
+
+var _ slog.Handler = (*leveledHandler)(nil)
+
+// leveledHandler passes each record to the handler of the highest configured level not above
+// the record's, or to the default handler.
+type leveledHandler struct {
+	levels   []slog.Level   // sorted by decreasing level
+	handlers []slog.Handler // handlers[i] handles records from levels[i]
+	def      slog.Handler   // nil if there is no default writer
+}
+
+// NewLeveledFileHandler creates a handler writing records as JSON to a writer chosen by level,
+// e.g. Debug and Info to one file and Warn and Error to another.
+//
+// A record is written to writers[level] if its level is configured, otherwise to the writer of
+// the nearest configured level below it, otherwise to defaultWriter. Records matching no writer
+// are dropped; defaultWriter may be nil. opts is shared by the JSON handlers of all writers and
+// may be nil, so opts.Level applies to every writer.
+//
+// Each writer gets its own JSON handler: a writer configured for several levels should be safe
+// for concurrent writes, as files are.
+//
+// Example:
+//
+//	handler := slogs.NewLeveledFileHandler(map[slog.Level]io.Writer{
+//		slog.LevelDebug: debugFile, // Debug and Info
+//		slog.LevelWarn:  errorFile, // Warn and Error
+//	}, nil, &slog.HandlerOptions{Level: slog.LevelDebug})
+func NewLeveledFileHandler(writers map[slog.Level]io.Writer, defaultWriter io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	h := &leveledHandler{}
+	for level := range writers {
+		if writers[level] != nil {
+			h.levels = append(h.levels, level)
+		}
+	}
+	sort.Slice(h.levels, func(i, j int) bool { return h.levels[i] > h.levels[j] })
+	for _, level := range h.levels {
+		h.handlers = append(h.handlers, slog.NewJSONHandler(writers[level], opts))
+	}
+	if defaultWriter != nil {
+		h.def = slog.NewJSONHandler(defaultWriter, opts)
+	}
+	return h
+}
+
+// Enabled reports whether the handler selected for level exists and is enabled.
+func (h *leveledHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	next := h.find(level)
+	return next != nil && next.Enabled(ctx, level)
+}
+
+// Handle passes r to the handler selected for its level.
+func (h *leveledHandler) Handle(ctx context.Context, r slog.Record) error {
+	next := h.find(r.Level)
+	if next == nil {
+		return nil
+	}
+	return next.Handle(ctx, r)
+}
+
+// WithAttrs returns a leveledHandler whose handlers all have the given attributes.
+func (h *leveledHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.derive(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+// WithGroup returns a leveledHandler whose handlers all have the given group.
+func (h *leveledHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.derive(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h *leveledHandler) derive(fn func(next slog.Handler) slog.Handler) *leveledHandler {
+	h2 := &leveledHandler{levels: h.levels, handlers: make([]slog.Handler, len(h.handlers))}
+	for i, next := range h.handlers {
+		h2.handlers[i] = fn(next)
+	}
+	if h.def != nil {
+		h2.def = fn(h.def)
+	}
+	return h2
+}
+
+// find returns the handler of the highest configured level not above level, the default
+// handler, or nil.
+func (h *leveledHandler) find(level slog.Level) slog.Handler {
+	for i, l := range h.levels {
+		if level >= l {
+			return h.handlers[i]
+		}
+	}
+	return h.def
+}
+
+// unwrapAll returns the handlers h passes records to, see handlerSinks.
+func (h *leveledHandler) unwrapAll() []slog.Handler {
+	handlers := append(make([]slog.Handler, 0, len(h.handlers)+1), h.handlers...)
+	if h.def != nil {
+		handlers = append(handlers, h.def)
+	}
+	return handlers
+}
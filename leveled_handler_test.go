@@ -0,0 +1,74 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLeveledFileHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		withDebug  bool
+		withDef    bool
+		level      slog.Level
+		wantWriter string
+	}{
+		{name: "exact level", withDebug: true, level: slog.LevelWarn, wantWriter: "warn"},
+		{name: "nearest lower level", withDebug: true, level: slog.LevelError, wantWriter: "warn"},
+		{name: "lower writer", withDebug: true, level: slog.LevelInfo, wantWriter: "debug"},
+		{name: "default", withDef: true, level: slog.LevelInfo, wantWriter: "default"},
+		{name: "no writer", level: slog.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bufs := map[string]*bytes.Buffer{"debug": {}, "warn": {}, "default": {}}
+			writers := map[slog.Level]io.Writer{slog.LevelWarn: bufs["warn"]}
+			if tt.withDebug {
+				writers[slog.LevelDebug] = bufs["debug"]
+			}
+			var def io.Writer
+			if tt.withDef {
+				def = bufs["default"]
+			}
+			h := NewLeveledFileHandler(writers, def, &slog.HandlerOptions{Level: slog.LevelDebug})
+
+			assert.Equal(t, tt.wantWriter != "", h.Enabled(context.Background(), tt.level))
+			slog.New(h).With("a", 1).WithGroup("g").Log(context.Background(), tt.level, "m", "b", 2)
+
+			for name, buf := range bufs {
+				if name == tt.wantWriter {
+					assert.Contains(t, buf.String(), `"msg":"m","a":1,"g":{"b":2}}`, name)
+				} else {
+					assert.Empty(t, buf.String(), name)
+				}
+			}
+		})
+	}
+}
+
+func TestNewLeveledFileHandler_SharedOptions(t *testing.T) {
+	debug, warn := &bytes.Buffer{}, &bytes.Buffer{}
+	h := NewLeveledFileHandler(map[slog.Level]io.Writer{slog.LevelDebug: debug, slog.LevelWarn: warn}, nil, nil)
+
+	logger := slog.New(h)
+	logger.Debug("hidden")
+	logger.Info("info")
+	logger.Error("error")
+
+	assert.False(t, h.Enabled(context.Background(), slog.LevelDebug))
+	assert.Equal(t, 1, strings.Count(debug.String(), "\n"))
+	assert.Contains(t, debug.String(), `"msg":"info"`)
+	assert.Contains(t, warn.String(), `"msg":"error"`)
+}
+
+func TestNewLeveledFileHandler_Sinks(t *testing.T) {
+	h := NewLeveledFileHandler(map[slog.Level]io.Writer{slog.LevelWarn: io.Discard}, io.Discard, nil)
+	assert.Equal(t, []string{"*slog.JSONHandler", "*slog.JSONHandler"}, handlerSinks(h, nil))
+}
@@ -0,0 +1,119 @@
+package slogs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// LogConfig emits a record at slog.LevelInfo describing the active configuration of the logger.
+//
+// It is meant to be called once at startup, so that the output documents why some records
+// are or are not logged. The record has the following attributes:
+//   - min_level: the lowest standard level the logger emits, DEBUG or INFO
+//   - name: the logger name, if any
+//   - caller: whether caller information is added at that level
+//   - format: the output format, if the logger was created with NewWithFormat
+//   - sinks: the types of the handlers that finally write records, found by looking through
+//     the handlers of this package wrapping them
+//   - cli_mode: whether the highest logged level is tracked, see WithCLIMode
+//
+// Like any other record, it is not emitted if the logger does not emit slog.LevelInfo.
+//
+// Example output:
+//
+//	level=INFO msg="logger configuration" min_level=INFO name=api caller=true sinks=[*slog.JSONHandler] cli_mode=false
+//
+// If ctx is nil, context.Background() is used.
+func (l *Logger) LogConfig(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !l.Enabled(ctx, slog.LevelInfo) {
+		return
+	}
+
+	level := l.minLevel(ctx)
+	attrs := []slog.Attr{slog.String("min_level", level.String())}
+	if name := l.Name(); name != "" {
+		attrs = append(attrs, slog.String("name", name))
+	}
+	attrs = append(attrs, slog.Bool("caller", l.addCaller(ctx, level)))
+	if t := l.terminal; t != nil {
+		t.mu.Lock()
+		format := t.format
+		t.mu.Unlock()
+		attrs = append(attrs, slog.String("format", format.String()))
+	}
+	attrs = append(attrs,
+		slog.Any("sinks", handlerSinks(l.handler, nil)),
+		slog.Bool("cli_mode", l.levels != nil),
+	)
+
+	l.logAttrs(ctx, slog.LevelInfo, "logger configuration", attrs...)
+}
+
+// LogValue implements slog.LogValuer, so that a Logger logged as an attribute, e.g. as a field
+// of a logged struct, is rendered as a small group rather than as its internal pointers:
+//   - name: the logger name, if any
+//   - level: the lowest standard level the logger emits, or OFF if it emits none
+//   - caller: whether caller information is added at that level
+//
+// The group only holds plain values, so resolving it cannot recurse. A nil Logger is
+// rendered as an empty group.
+func (l *Logger) LogValue() slog.Value {
+	if l == nil {
+		return slog.GroupValue()
+	}
+
+	ctx := context.Background()
+	var attrs []slog.Attr
+	if name := l.Name(); name != "" {
+		attrs = append(attrs, slog.String("name", name))
+	}
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+		if l.handler.Enabled(ctx, level) {
+			return slog.GroupValue(append(attrs,
+				slog.String("level", level.String()),
+				slog.Bool("caller", l.addCaller(ctx, level)),
+			)...)
+		}
+	}
+	return slog.GroupValue(append(attrs, slog.String("level", "OFF"), slog.Bool("caller", false))...)
+}
+
+// minLevel returns the lowest standard level enabled for l, which is at most slog.LevelInfo
+// when LogConfig emits its record.
+func (l *Logger) minLevel(ctx context.Context) slog.Level {
+	if l.handler.Enabled(ctx, slog.LevelDebug) {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// unwrapper is implemented by the wrappers of this package passing records to a single handler.
+type unwrapper interface {
+	unwrap() slog.Handler
+}
+
+// multiUnwrapper is implemented by the wrappers of this package passing records to several handlers.
+type multiUnwrapper interface {
+	unwrapAll() []slog.Handler
+}
+
+// handlerSinks appends to sinks the types of the handlers reached from h that are not
+// wrappers defined by this package.
+func handlerSinks(h slog.Handler, sinks []string) []string {
+	switch h := h.(type) {
+	case unwrapper:
+		return handlerSinks(h.unwrap(), sinks)
+	case multiUnwrapper:
+		for _, next := range h.unwrapAll() {
+			sinks = handlerSinks(next, sinks)
+		}
+		return sinks
+	default:
+		return append(sinks, fmt.Sprintf("%T", h))
+	}
+}
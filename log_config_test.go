@@ -0,0 +1,171 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_LogConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		logger func(buf *bytes.Buffer) *Logger
+		want   map[string]any
+	}{
+		{
+			name: "defaults",
+			logger: func(buf *bytes.Buffer) *Logger {
+				return New(NewHandler(slog.NewJSONHandler(buf, nil)))
+			},
+			want: map[string]any{
+				"min_level": "INFO",
+				"caller":    false,
+				"sinks":     []any{"*slog.JSONHandler"},
+				"cli_mode":  false,
+			},
+		},
+		{
+			name: "configured",
+			logger: func(buf *bytes.Buffer) *Logger {
+				next := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+				return New(NewHandler(next), WithCaller(true), WithCLIMode(true)).Named("api")
+			},
+			want: map[string]any{
+				"min_level": "DEBUG",
+				"name":      "api",
+				"caller":    true,
+				"sinks":     []any{"*slog.JSONHandler"},
+				"cli_mode":  true,
+			},
+		},
+		{
+			name: "level from handler",
+			logger: func(buf *bytes.Buffer) *Logger {
+				next := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+				return New(NewHandler(next).WithLevel(slog.LevelInfo))
+			},
+			want: map[string]any{
+				"min_level": "INFO",
+				"caller":    false,
+				"sinks":     []any{"*slog.JSONHandler"},
+				"cli_mode":  false,
+			},
+		},
+		{
+			name: "with format",
+			logger: func(buf *bytes.Buffer) *Logger {
+				return NewWithFormat(FormatJSON, buf, nil)
+			},
+			want: map[string]any{
+				"min_level": "INFO",
+				"caller":    false,
+				"format":    "json",
+				"sinks":     []any{"*slog.JSONHandler"},
+				"cli_mode":  false,
+			},
+		},
+		{
+			name: "looks through wrappers",
+			logger: func(buf *bytes.Buffer) *Logger {
+				multi := MultiHandler(
+					slog.NewJSONHandler(buf, nil),
+					NewSamplingHandler(NewHandler(slog.NewTextHandler(io.Discard, nil)), nil, 0, 1, 1),
+					NewGlobalRateLimitHandler(slog.NewTextHandler(io.Discard, nil), nil, 1, 1),
+				)
+				return New(NewHandler(NewDeferredHandler(multi, 0)))
+			},
+			want: map[string]any{
+				"min_level": "INFO",
+				"caller":    false,
+				"sinks":     []any{"*slog.JSONHandler", "*slog.TextHandler", "*slog.TextHandler"},
+				"cli_mode":  false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tt.logger(buf).LogConfig(context.Background())
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Contains(t, got[slog.MessageKey], "logger configuration")
+			delete(got, slog.TimeKey)
+			delete(got, slog.LevelKey)
+			delete(got, slog.MessageKey)
+			delete(got, slog.SourceKey)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLogger_LogConfig_Disabled(t *testing.T) {
+	buf := &bytes.Buffer{}
+	next := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+	New(NewHandler(next)).LogConfig(nil)
+
+	assert.Empty(t, buf.String())
+}
+
+func TestLogger_LogValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		logger *Logger
+		want   string
+	}{
+		{
+			name:   "defaults",
+			logger: New(NewHandler(slog.NewJSONHandler(io.Discard, nil))),
+			want:   `{"logger":{"level":"INFO","caller":false}}`,
+		},
+		{
+			name:   "named with caller",
+			logger: New(NewHandler(slog.NewJSONHandler(io.Discard, nil)).WithLevel(slog.LevelWarn), WithCaller(true)).Named("db"),
+			want:   `{"logger":{"name":"db","level":"WARN","caller":true}}`,
+		},
+		{
+			name:   "disabled",
+			logger: New(NewHandler(slog.NewJSONHandler(io.Discard, nil)).WithLevel(slog.LevelError + 1)),
+			want:   `{"logger":{"level":"OFF","caller":false}}`,
+		},
+		{
+			name:   "nil",
+			logger: nil,
+			want:   `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			out := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && a.Key != "logger" {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+
+			out.Info("m", "logger", tt.logger)
+
+			assert.JSONEq(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestLogger_LogValue_Self(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := New(NewHandler(slog.NewJSONHandler(buf, nil))).Named("svc")
+
+	logger.Info("started", "logger", logger)
+
+	assert.Contains(t, buf.String(), `"logger":{"name":"svc","level":"INFO","caller":false}`)
+}
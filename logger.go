@@ -35,8 +35,10 @@ package slogs
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"runtime"
+	"sync/atomic"
 
 	"github.com/rockcookies/go-slogs/internal/attr"
 )
@@ -55,11 +57,23 @@ type Logger struct {
 	clock      Clock
 	callerSkip int
 	addCaller  func(ctx context.Context, level slog.Level) bool
+	fallback   *fallbackWriter
+	terminal   *terminal
+	levels     *levelTracker
+
+	// runtimeInfo makes New log RuntimeInfoAttrs, see WithRuntimeInfo.
+	runtimeInfo bool
+
+	// disabled mutes the loggers of the tree, see SetEnabled.
+	disabled *atomic.Bool
 }
 
+// ErrNilHandler is returned by NewE when the handler is nil.
+var ErrNilHandler = errors.New("slogs: handler cannot be nil")
+
 // New creates a new Logger with the given Handler and options.
 //
-// The handler must not be nil, or this function will panic.
+// The handler must not be nil, or this function will panic; see NewE.
 // Options can be used to configure caller tracking, log levels, and logger names.
 //
 // Example:
@@ -70,8 +84,26 @@ type Logger struct {
 //		slogs.WithLevel(slog.LevelInfo),
 //	)
 func New(h *Handler, options ...Option) *Logger {
+	l, err := NewE(h, options...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return l
+}
+
+// NewE is like New but returns ErrNilHandler instead of panicking if h is nil, e.g. when the
+// handler comes from a configuration that failed to load. Library code should prefer it, so
+// that a logging misconfiguration does not crash the process.
+//
+// Example:
+//
+//	logger, err := slogs.NewE(handler, slogs.WithCaller(true))
+//	if err != nil {
+//		return fmt.Errorf("configure logging: %w", err)
+//	}
+func NewE(h *Handler, options ...Option) (*Logger, error) {
 	if h == nil {
-		panic("nil Handler")
+		return nil, ErrNilHandler
 	}
 
 	l := &Logger{
@@ -79,13 +111,18 @@ func New(h *Handler, options ...Option) *Logger {
 		clock:      DefaultClock,
 		callerSkip: 0,
 		addCaller:  func(_ context.Context, _ slog.Level) bool { return false },
+		disabled:   new(atomic.Bool),
 	}
 
 	for _, opt := range options {
 		opt.apply(l)
 	}
 
-	return l
+	if l.runtimeInfo {
+		l.logAttrs(context.Background(), slog.LevelInfo, "runtime info", RuntimeInfoAttrs()...)
+	}
+
+	return l, nil
 }
 
 // clone creates a shallow copy of l.
@@ -117,6 +154,9 @@ func (l *Logger) Normalize() *slog.Logger {
 // The returned Logger shares the same handler but with additional attributes.
 // If no arguments are provided, the original logger is returned.
 //
+// A slog.Group attribute is nested under the groups opened by WithGroup before the With call,
+// and stays outside of groups opened afterwards, exactly as with slog.Logger.
+//
 // Example:
 //
 //	logger := logger.With("app", "myapp", "env", "prod")
@@ -159,14 +199,30 @@ func (l *Logger) WithGroup(name string) *Logger {
 // This can be used to avoid expensive operations when a log statement
 // would not produce output.
 //
+// It returns false for every level while the Logger is disabled, see SetEnabled.
+//
 // If ctx is nil, context.Background() is used.
 func (l *Logger) Enabled(ctx context.Context, level slog.Level) bool {
+	if l.disabled.Load() {
+		return false
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	return l.handler.Enabled(ctx, level)
 }
 
+// SetEnabled mutes the Logger if enabled is false and unmutes it otherwise, e.g. to silence a
+// noisy operation without rebuilding the Logger or changing levels.
+//
+// A muted Logger drops every record before doing any work, whatever its level. The setting is
+// shared by the Logger returned by New and all the loggers derived from it, so muting any of
+// them mutes all of them. Loggers obtained with Normalize log through the Handler directly and
+// are not muted. It is safe to call concurrently with logging.
+func (l *Logger) SetEnabled(enabled bool) {
+	l.disabled.Store(!enabled)
+}
+
 // WithOptions returns a new Logger with the given options applied.
 //
 // This allows you to create a logger variant with modified behavior,
@@ -200,12 +256,29 @@ func (l *Logger) Sugar() *SugaredLogger {
 }
 
 // Named returns a new Logger with the given name added to the logger's name chain.
+//
+// Names are joined with a period: logger.Named("service").Named("database") logs
+// messages prefixed with "[service.database]". An empty name leaves the chain unchanged.
 func (l *Logger) Named(s string) *Logger {
+	if s == "" {
+		return l
+	}
+
 	l2 := l.clone()
 	l2.handler = l2.handler.Named(s)
 	return l2
 }
 
+// NamedReset returns a new Logger whose name chain is replaced by the given name.
+//
+// Unlike Named, which appends, NamedReset discards any names accumulated so far.
+// An empty name clears the chain.
+func (l *Logger) NamedReset(s string) *Logger {
+	l2 := l.clone()
+	l2.handler = l2.handler.NamedReset(s)
+	return l2
+}
+
 // Name returns the logger's name.
 func (l *Logger) Name() string {
 	return l.handler.Name()
@@ -285,6 +358,64 @@ func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
 	l.log(ctx, slog.LevelError, msg, args...)
 }
 
+// CheckedLog is a log entry that has passed the level check and is waiting to be written.
+//
+// It is returned by Logger.Check and lets callers defer the construction of expensive
+// attributes until it is known that the entry will actually be emitted. The caller
+// information and timestamp are captured when Check is called, so the reported source
+// points at the Check call site rather than at Write.
+//
+// A nil *CheckedLog is valid; calling Write on it does nothing.
+type CheckedLog struct {
+	logger *Logger
+	ctx    context.Context
+	record slog.Record
+}
+
+// Check returns a CheckedLog if logging a message at the specified level is enabled,
+// or nil otherwise.
+//
+// Use Check to guard expensive attribute construction:
+//
+//	if ce := logger.Check(ctx, slog.LevelDebug, "cache state"); ce != nil {
+//		ce.Write(slog.Any("entries", dumpCache()))
+//	}
+//
+// If ctx is nil, context.Background() is used.
+func (l *Logger) Check(ctx context.Context, level slog.Level, msg string) *CheckedLog {
+	return l.check(ctx, level, msg)
+}
+
+// check keeps the same call depth as log so that capturePC reports the caller of Check.
+func (l *Logger) check(ctx context.Context, level slog.Level, msg string) *CheckedLog {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !l.Enabled(ctx, level) {
+		return nil
+	}
+
+	pc := l.capturePC(ctx, level)
+	return &CheckedLog{
+		logger: l,
+		ctx:    ctx,
+		record: slog.NewRecord(l.clock.Now(), level, msg, pc),
+	}
+}
+
+// Write emits the checked entry with the given attributes.
+//
+// A CheckedLog should be written at most once.
+func (ce *CheckedLog) Write(attrs ...slog.Attr) {
+	if ce == nil {
+		return
+	}
+
+	ce.record.AddAttrs(attrs...)
+	ce.logger.handle(ce.ctx, ce.record)
+}
+
 // capturePC captures the program counter of the calling code for caller information.
 func (l *Logger) capturePC(ctx context.Context, level slog.Level) uintptr {
 	var pc uintptr
@@ -311,7 +442,7 @@ func (l *Logger) log(ctx context.Context, level slog.Level, msg string, args ...
 	r := slog.NewRecord(l.clock.Now(), level, msg, pc)
 	r.AddAttrs(attr.ArgsToAttrSlice(args)...)
 
-	_ = l.handler.Handle(ctx, r)
+	l.handle(ctx, r)
 }
 
 // logAttrs is the internal logging method that accepts pre-converted slog.Attr values.
@@ -328,5 +459,15 @@ func (l *Logger) logAttrs(ctx context.Context, level slog.Level, msg string, att
 	r := slog.NewRecord(l.clock.Now(), level, msg, pc)
 	r.AddAttrs(attrs...)
 
-	_ = l.Handler().Handle(ctx, r)
+	l.handle(ctx, r)
+}
+
+// handle passes r to the handler, tracking its level in CLI mode and reporting failures to the fallback writer if one is configured.
+func (l *Logger) handle(ctx context.Context, r slog.Record) {
+	if l.levels != nil {
+		l.levels.observe(r.Level)
+	}
+	if err := l.handler.Handle(ctx, r); err != nil && l.fallback != nil {
+		l.fallback.write(l.clock.Now(), r, err)
+	}
 }
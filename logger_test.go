@@ -3,10 +3,14 @@ package slogs
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"log/slog"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLogger_Basic(t *testing.T) {
@@ -47,6 +51,26 @@ func TestLogger_Enabled(t *testing.T) {
 	assert.False(t, logger.Enabled(context.Background(), slog.LevelDebug))
 }
 
+func TestLogger_SetEnabled(t *testing.T) {
+	next := newTestHandler(true)
+	logger := New(NewHandler(next))
+	child := logger.Named("child").With("k", 1)
+	ctx := context.Background()
+
+	logger.Info("before")
+	child.SetEnabled(false)
+	assert.False(t, logger.Enabled(ctx, slog.LevelError))
+	logger.Error("muted")
+	child.Info("muted")
+	logger.Sugar().Infof("muted %d", 1)
+	require.Equal(t, 1, next.recordCount())
+
+	logger.SetEnabled(true)
+	assert.True(t, child.Enabled(ctx, slog.LevelInfo))
+	child.Info("after")
+	assert.Equal(t, 2, next.recordCount())
+}
+
 func TestLogger_Levels(t *testing.T) {
 	buf := &bytes.Buffer{}
 	h := NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
@@ -166,6 +190,16 @@ func TestNew_NilHandler(t *testing.T) {
 	})
 }
 
+func TestNewE(t *testing.T) {
+	l, err := NewE(nil)
+	assert.ErrorIs(t, err, ErrNilHandler)
+	assert.Nil(t, l)
+
+	l, err = NewE(NewHandler(newTestHandler(true)), WithLevel(slog.LevelWarn))
+	require.NoError(t, err)
+	assert.False(t, l.Enabled(context.Background(), slog.LevelInfo))
+}
+
 func TestLogger_Log_Disabled(t *testing.T) {
 	buf := &bytes.Buffer{}
 	h := NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -217,3 +251,169 @@ func TestLogger_Name_Empty(t *testing.T) {
 
 	assert.Equal(t, "", logger.Name())
 }
+
+func TestLogger_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   slog.Level
+		wantNil bool
+	}{
+		{name: "enabled level returns entry", level: slog.LevelWarn, wantNil: false},
+		{name: "disabled level returns nil", level: slog.LevelDebug, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, nil))
+			logger := New(h)
+
+			ce := logger.Check(context.Background(), tt.level, "checked")
+			if tt.wantNil {
+				assert.Nil(t, ce)
+				ce.Write(slog.String("k", "v")) // must be safe on nil
+				assert.Empty(t, buf.String())
+				return
+			}
+
+			require.NotNil(t, ce)
+			ce.Write(slog.String("k", "v"))
+			assert.Contains(t, buf.String(), `"msg":"checked"`)
+			assert.Contains(t, buf.String(), `"k":"v"`)
+		})
+	}
+}
+
+func TestLogger_Check_Caller(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true}))
+	logger := New(h, WithCaller(true))
+
+	_, _, line, _ := runtime.Caller(0)
+	ce := logger.Check(nil, slog.LevelInfo, "checked")
+	require.NotNil(t, ce)
+	func() {
+		ce.Write(slog.Int("n", 1))
+	}()
+
+	var out struct {
+		Source struct {
+			File string `json:"file"`
+			Line int    `json:"line"`
+		} `json:"source"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.True(t, strings.HasSuffix(out.Source.File, "logger_test.go"))
+	assert.Equal(t, line+1, out.Source.Line)
+}
+
+// TestLogger_With_GroupAttr pins down how slog.Group attributes passed to With compose with
+// WithGroup by comparing the middleware output against the standard library's own handling.
+func TestLogger_With_GroupAttr(t *testing.T) {
+	type builder interface {
+		Info(msg string, args ...any)
+	}
+
+	tests := []struct {
+		name   string
+		std    func(l *slog.Logger) builder
+		slogs  func(l *Logger) builder
+		expect string
+	}{
+		{
+			name:   "group attr before WithGroup stays at root",
+			std:    func(l *slog.Logger) builder { return l.With(slog.Group("a", "x", 1)).WithGroup("g") },
+			slogs:  func(l *Logger) builder { return l.With(slog.Group("a", "x", 1)).WithGroup("g") },
+			expect: `"a":{"x":1},"g":{"k":"v"}`,
+		},
+		{
+			name:   "group attr after WithGroup is nested",
+			std:    func(l *slog.Logger) builder { return l.WithGroup("g").With(slog.Group("a", "x", 1)) },
+			slogs:  func(l *Logger) builder { return l.WithGroup("g").With(slog.Group("a", "x", 1)) },
+			expect: `"g":{"a":{"x":1},"k":"v"}`,
+		},
+		{
+			name:   "empty-key group is inlined",
+			std:    func(l *slog.Logger) builder { return l.With(slog.Group("", "x", 1)).WithGroup("g") },
+			slogs:  func(l *Logger) builder { return l.With(slog.Group("", "x", 1)).WithGroup("g") },
+			expect: `"x":1,"g":{"k":"v"}`,
+		},
+		{
+			name:   "empty group is dropped",
+			std:    func(l *slog.Logger) builder { return l.With(slog.Group("a")).WithGroup("g") },
+			slogs:  func(l *Logger) builder { return l.With(slog.Group("a")).WithGroup("g") },
+			expect: `"g":{"k":"v"}`,
+		},
+		{
+			name: "nested groups between With calls",
+			std: func(l *slog.Logger) builder {
+				return l.With(slog.Group("a", "x", 1)).WithGroup("g").With(slog.Group("b", "y", 2)).WithGroup("h")
+			},
+			slogs: func(l *Logger) builder {
+				return l.With(slog.Group("a", "x", 1)).WithGroup("g").With(slog.Group("b", "y", 2)).WithGroup("h")
+			},
+			expect: `"a":{"x":1},"g":{"b":{"y":2},"h":{"k":"v"}}`,
+		},
+	}
+
+	noTime := &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdBuf := &bytes.Buffer{}
+			tt.std(slog.New(slog.NewJSONHandler(stdBuf, noTime))).Info("m", "k", "v")
+
+			buf := &bytes.Buffer{}
+			tt.slogs(New(NewHandler(slog.NewJSONHandler(buf, noTime)))).Info("m", "k", "v")
+
+			assert.Equal(t, stdBuf.String(), buf.String())
+			assert.Contains(t, buf.String(), tt.expect)
+		})
+	}
+}
+
+func TestLogger_Named_Chain(t *testing.T) {
+	tests := []struct {
+		name       string
+		build      func(l *Logger) *Logger
+		wantName   string
+		wantPrefix string
+	}{
+		{
+			name:       "appends names",
+			build:      func(l *Logger) *Logger { return l.Named("database").Named("pool") },
+			wantName:   "database.pool",
+			wantPrefix: "[database.pool] message",
+		},
+		{
+			name:       "reset replaces chain",
+			build:      func(l *Logger) *Logger { return l.Named("database").NamedReset("cache") },
+			wantName:   "cache",
+			wantPrefix: "[cache] message",
+		},
+		{
+			name:       "reset then append",
+			build:      func(l *Logger) *Logger { return l.Named("a").NamedReset("b").Named("c") },
+			wantName:   "b.c",
+			wantPrefix: "[b.c] message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			base := New(NewHandler(slog.NewJSONHandler(buf, nil)))
+			logger := tt.build(base)
+
+			logger.Info("message")
+			assert.Equal(t, tt.wantName, logger.Name())
+			assert.Contains(t, buf.String(), tt.wantPrefix)
+			assert.Equal(t, "", base.Name())
+		})
+	}
+}
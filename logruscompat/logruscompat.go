@@ -0,0 +1,238 @@
+// Package logruscompat provides a logrus-style API on top of slogs.Logger.
+//
+// It is intended to ease migration from github.com/sirupsen/logrus: after swapping the import
+// and the logger construction, existing call sites using WithField, WithFields, WithError and
+// the Print/Info/Warn/Error families keep compiling and produce structured slog records.
+//
+//	logger := logruscompat.New(slogs.New(handler))
+//	logger.WithField("user", "alice").WithError(err).Errorf("login failed after %d attempts", n)
+package logruscompat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+
+	"github.com/rockcookies/go-slogs"
+)
+
+// Levels that have no direct slog equivalent.
+const (
+	// LevelTrace is used by the Trace family of methods.
+	LevelTrace = slogs.LevelTrace
+	// LevelFatal is used by the Fatal family of methods.
+	LevelFatal = slogs.LevelFatal
+	// LevelPanic is used by the Panic family of methods.
+	LevelPanic = slog.LevelError + 8
+)
+
+// Fields is a set of key-value pairs, equivalent to logrus.Fields.
+type Fields map[string]any
+
+// Logger is a logrus-compatible facade over a slogs.Logger.
+//
+// Like logrus.Entry, every With* method returns a new Logger and leaves the receiver unchanged.
+type Logger struct {
+	base *slogs.Logger
+	ctx  context.Context
+	exit func(code int)
+}
+
+// New returns a Logger that writes through base.
+//
+// Caller information, when enabled on base, reports the code calling the logrus-style method
+// rather than this package.
+func New(base *slogs.Logger) *Logger {
+	return &Logger{
+		// skip [Logger.log, Logger.<Level>]
+		base: base.WithOptions(slogs.WithCallerSkip(2)),
+		ctx:  context.Background(),
+		exit: os.Exit,
+	}
+}
+
+// Desugar returns the underlying slogs.Logger.
+func (l *Logger) Desugar() *slogs.Logger {
+	return l.base.WithOptions(slogs.WithCallerSkip(-2))
+}
+
+func (l *Logger) clone() *Logger {
+	l2 := *l
+	return &l2
+}
+
+// WithField returns a Logger that adds the given key-value pair to each record.
+func (l *Logger) WithField(key string, value any) *Logger {
+	l2 := l.clone()
+	l2.base = l.base.With(key, value)
+	return l2
+}
+
+// WithFields returns a Logger that adds the given fields to each record.
+//
+// Fields are added in key order so that output is deterministic.
+func (l *Logger) WithFields(fields Fields) *Logger {
+	if len(fields) == 0 {
+		return l
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	args := make([]any, 0, len(fields))
+	for _, k := range keys {
+		args = append(args, slog.Any(k, fields[k]))
+	}
+
+	l2 := l.clone()
+	l2.base = l.base.With(args...)
+	return l2
+}
+
+// WithError returns a Logger that adds err to each record using slogs.Err.
+func (l *Logger) WithError(err error) *Logger {
+	l2 := l.clone()
+	l2.base = l.base.With(slogs.Err(err))
+	return l2
+}
+
+// WithContext returns a Logger that passes ctx to the handler for each record.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	l2 := l.clone()
+	l2.ctx = ctx
+	return l2
+}
+
+// Trace logs at LevelTrace. Uses fmt.Sprint to format the message.
+func (l *Logger) Trace(args ...any) { l.log(LevelTrace, fmt.Sprint, args) }
+
+// Debug logs at slog.LevelDebug. Uses fmt.Sprint to format the message.
+func (l *Logger) Debug(args ...any) { l.log(slog.LevelDebug, fmt.Sprint, args) }
+
+// Print logs at slog.LevelInfo. Uses fmt.Sprint to format the message.
+func (l *Logger) Print(args ...any) { l.log(slog.LevelInfo, fmt.Sprint, args) }
+
+// Info logs at slog.LevelInfo. Uses fmt.Sprint to format the message.
+func (l *Logger) Info(args ...any) { l.log(slog.LevelInfo, fmt.Sprint, args) }
+
+// Warn logs at slog.LevelWarn. Uses fmt.Sprint to format the message.
+func (l *Logger) Warn(args ...any) { l.log(slog.LevelWarn, fmt.Sprint, args) }
+
+// Warning is an alias for Warn.
+func (l *Logger) Warning(args ...any) { l.log(slog.LevelWarn, fmt.Sprint, args) }
+
+// Error logs at slog.LevelError. Uses fmt.Sprint to format the message.
+func (l *Logger) Error(args ...any) { l.log(slog.LevelError, fmt.Sprint, args) }
+
+// Fatal logs at LevelFatal and then exits the process with status 1.
+func (l *Logger) Fatal(args ...any) {
+	l.log(LevelFatal, fmt.Sprint, args)
+	l.exit(1)
+}
+
+// Panic logs at LevelPanic and then panics with the message.
+func (l *Logger) Panic(args ...any) {
+	msg := fmt.Sprint(args...)
+	l.log(LevelPanic, sprintMessage(msg), nil)
+	panic(msg)
+}
+
+// Tracef logs at LevelTrace. Uses fmt.Sprintf to format the message.
+func (l *Logger) Tracef(format string, args ...any) { l.logf(LevelTrace, format, args) }
+
+// Debugf logs at slog.LevelDebug. Uses fmt.Sprintf to format the message.
+func (l *Logger) Debugf(format string, args ...any) { l.logf(slog.LevelDebug, format, args) }
+
+// Printf logs at slog.LevelInfo. Uses fmt.Sprintf to format the message.
+func (l *Logger) Printf(format string, args ...any) { l.logf(slog.LevelInfo, format, args) }
+
+// Infof logs at slog.LevelInfo. Uses fmt.Sprintf to format the message.
+func (l *Logger) Infof(format string, args ...any) { l.logf(slog.LevelInfo, format, args) }
+
+// Warnf logs at slog.LevelWarn. Uses fmt.Sprintf to format the message.
+func (l *Logger) Warnf(format string, args ...any) { l.logf(slog.LevelWarn, format, args) }
+
+// Warningf is an alias for Warnf.
+func (l *Logger) Warningf(format string, args ...any) { l.logf(slog.LevelWarn, format, args) }
+
+// Errorf logs at slog.LevelError. Uses fmt.Sprintf to format the message.
+func (l *Logger) Errorf(format string, args ...any) { l.logf(slog.LevelError, format, args) }
+
+// Fatalf logs at LevelFatal and then exits the process with status 1.
+func (l *Logger) Fatalf(format string, args ...any) {
+	l.logf(LevelFatal, format, args)
+	l.exit(1)
+}
+
+// Panicf logs at LevelPanic and then panics with the message.
+func (l *Logger) Panicf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	l.log(LevelPanic, sprintMessage(msg), nil)
+	panic(msg)
+}
+
+// Traceln logs at LevelTrace. Uses fmt.Sprintln to format the message.
+func (l *Logger) Traceln(args ...any) { l.log(LevelTrace, sprintln, args) }
+
+// Debugln logs at slog.LevelDebug. Uses fmt.Sprintln to format the message.
+func (l *Logger) Debugln(args ...any) { l.log(slog.LevelDebug, sprintln, args) }
+
+// Println logs at slog.LevelInfo. Uses fmt.Sprintln to format the message.
+func (l *Logger) Println(args ...any) { l.log(slog.LevelInfo, sprintln, args) }
+
+// Infoln logs at slog.LevelInfo. Uses fmt.Sprintln to format the message.
+func (l *Logger) Infoln(args ...any) { l.log(slog.LevelInfo, sprintln, args) }
+
+// Warnln logs at slog.LevelWarn. Uses fmt.Sprintln to format the message.
+func (l *Logger) Warnln(args ...any) { l.log(slog.LevelWarn, sprintln, args) }
+
+// Warningln is an alias for Warnln.
+func (l *Logger) Warningln(args ...any) { l.log(slog.LevelWarn, sprintln, args) }
+
+// Errorln logs at slog.LevelError. Uses fmt.Sprintln to format the message.
+func (l *Logger) Errorln(args ...any) { l.log(slog.LevelError, sprintln, args) }
+
+// Fatalln logs at LevelFatal and then exits the process with status 1.
+func (l *Logger) Fatalln(args ...any) {
+	l.log(LevelFatal, sprintln, args)
+	l.exit(1)
+}
+
+// Panicln logs at LevelPanic and then panics with the message.
+func (l *Logger) Panicln(args ...any) {
+	msg := sprintln(args...)
+	l.log(LevelPanic, sprintMessage(msg), nil)
+	panic(msg)
+}
+
+// log formats the message with format only if the level is enabled.
+func (l *Logger) log(level slog.Level, format func(args ...any) string, args []any) {
+	if !l.base.Enabled(l.ctx, level) {
+		return
+	}
+	l.base.Log(l.ctx, level, format(args...))
+}
+
+// logf is the Sprintf-style counterpart of log.
+func (l *Logger) logf(level slog.Level, format string, args []any) {
+	if !l.base.Enabled(l.ctx, level) {
+		return
+	}
+	l.base.Log(l.ctx, level, fmt.Sprintf(format, args...))
+}
+
+// sprintln formats like fmt.Sprintln without the trailing newline, as logrus does.
+func sprintln(args ...any) string {
+	msg := fmt.Sprintln(args...)
+	return msg[:len(msg)-1]
+}
+
+// sprintMessage returns a formatter that ignores its arguments and yields msg.
+func sprintMessage(msg string) func(args ...any) string {
+	return func(...any) string { return msg }
+}
@@ -0,0 +1,185 @@
+package logruscompat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/rockcookies/go-slogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(buf *bytes.Buffer, opts ...slogs.Option) *Logger {
+	h := slogs.NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: LevelTrace, AddSource: true}))
+	return New(slogs.New(h, opts...))
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	return m
+}
+
+func TestLogger_Methods(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       func(l *Logger)
+		wantMsg   string
+		wantLevel string
+	}{
+		{name: "Trace", log: func(l *Logger) { l.Trace("a", 1) }, wantMsg: "a1", wantLevel: "DEBUG-4"},
+		{name: "Debug", log: func(l *Logger) { l.Debug("a", "b") }, wantMsg: "ab", wantLevel: "DEBUG"},
+		{name: "Print", log: func(l *Logger) { l.Print("p") }, wantMsg: "p", wantLevel: "INFO"},
+		{name: "Info", log: func(l *Logger) { l.Info(1, 2) }, wantMsg: "1 2", wantLevel: "INFO"},
+		{name: "Warn", log: func(l *Logger) { l.Warn("w") }, wantMsg: "w", wantLevel: "WARN"},
+		{name: "Warning", log: func(l *Logger) { l.Warning("w") }, wantMsg: "w", wantLevel: "WARN"},
+		{name: "Error", log: func(l *Logger) { l.Error("e") }, wantMsg: "e", wantLevel: "ERROR"},
+		{name: "Infof", log: func(l *Logger) { l.Infof("n=%d", 3) }, wantMsg: "n=3", wantLevel: "INFO"},
+		{name: "Warningf", log: func(l *Logger) { l.Warningf("%s!", "x") }, wantMsg: "x!", wantLevel: "WARN"},
+		{name: "Errorf", log: func(l *Logger) { l.Errorf("failed: %v", "io") }, wantMsg: "failed: io", wantLevel: "ERROR"},
+		{name: "Infoln", log: func(l *Logger) { l.Infoln("a", "b") }, wantMsg: "a b", wantLevel: "INFO"},
+		{name: "Warnln", log: func(l *Logger) { l.Warnln("a", 1) }, wantMsg: "a 1", wantLevel: "WARN"},
+		{name: "Errorln", log: func(l *Logger) { l.Errorln("x") }, wantMsg: "x", wantLevel: "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tt.log(newTestLogger(buf))
+
+			m := decode(t, buf)
+			assert.Equal(t, tt.wantMsg, m["msg"])
+			assert.Equal(t, tt.wantLevel, m["level"])
+		})
+	}
+}
+
+func TestLogger_WithFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := newTestLogger(buf)
+
+	l.WithField("user", "alice").
+		WithFields(Fields{"b": 2, "a": 1}).
+		WithError(errors.New("boom")).
+		Info("done")
+
+	assert.Contains(t, buf.String(), `"msg":"done","user":"alice","a":1,"b":2,"error":"boom"`)
+}
+
+func TestLogger_WithFields_Immutable(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := newTestLogger(buf)
+	_ = l.WithField("k", "v")
+	assert.Same(t, l, l.WithFields(nil))
+
+	l.Info("plain")
+	assert.NotContains(t, buf.String(), `"k"`)
+}
+
+type ctxKey struct{}
+
+func TestLogger_WithContext(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := newTestLogger(buf)
+
+	ctx := slogs.Prepend(context.WithValue(context.Background(), ctxKey{}, 1), "request_id", "r1")
+	l.WithContext(ctx).Info("hi")
+
+	assert.Contains(t, buf.String(), `"request_id":"r1"`)
+}
+
+func TestLogger_Fatal(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l *Logger)
+		want string
+	}{
+		{name: "Fatal", log: func(l *Logger) { l.Fatal("bye") }, want: "bye"},
+		{name: "Fatalf", log: func(l *Logger) { l.Fatalf("bye %d", 1) }, want: "bye 1"},
+		{name: "Fatalln", log: func(l *Logger) { l.Fatalln("bye", 2) }, want: "bye 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l := newTestLogger(buf)
+			code := -1
+			l.exit = func(c int) { code = c }
+
+			tt.log(l)
+
+			assert.Equal(t, 1, code)
+			m := decode(t, buf)
+			assert.Equal(t, tt.want, m["msg"])
+			assert.Equal(t, "ERROR+4", m["level"])
+		})
+	}
+}
+
+func TestLogger_Panic(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l *Logger)
+		want string
+	}{
+		{name: "Panic", log: func(l *Logger) { l.Panic("oops") }, want: "oops"},
+		{name: "Panicf", log: func(l *Logger) { l.Panicf("oops %d", 1) }, want: "oops 1"},
+		{name: "Panicln", log: func(l *Logger) { l.Panicln("oops", 2) }, want: "oops 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l := newTestLogger(buf)
+
+			assert.PanicsWithValue(t, tt.want, func() { tt.log(l) })
+			m := decode(t, buf)
+			assert.Equal(t, tt.want, m["msg"])
+			assert.Equal(t, "ERROR+8", m["level"])
+		})
+	}
+}
+
+func TestLogger_Disabled(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := slogs.NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelError}))
+	l := New(slogs.New(h))
+
+	l.Info("hidden")
+	l.Debugf("hidden %d", 1)
+	l.Warnln("hidden")
+
+	assert.Empty(t, buf.String())
+}
+
+func TestLogger_Caller(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := newTestLogger(buf, slogs.WithCaller(true))
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Infof("where %s", "am I")
+
+	src, ok := decode(t, buf)["source"].(map[string]any)
+	require.True(t, ok)
+	assert.True(t, strings.HasSuffix(src["file"].(string), "logruscompat_test.go"))
+	assert.Equal(t, float64(line+1), src["line"])
+}
+
+func TestLogger_Desugar(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := newTestLogger(buf, slogs.WithCaller(true))
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Desugar().Info("direct")
+
+	src, ok := decode(t, buf)["source"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, float64(line+1), src["line"])
+}
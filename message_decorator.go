@@ -0,0 +1,36 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"time"
+)
+
+// WithLevelMessageDecorator returns a new Handler that rewrites the message of records whose
+// level has a function in decorators, e.g. to prefix Error messages with "ERROR: " so that they
+// stand out when grepping mixed output. The level field of the record is left unchanged.
+//
+// Decorators run after the HandleFunc, so they see the message already prefixed with the logger
+// name, and in the order they were added with respect to the other options rewriting the
+// message: the last one added applies last. Levels without a decorator and nil decorators
+// leave the message unchanged. With no decorators, h is returned.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithLevelMessageDecorator(map[slog.Level]func(string) string{
+//		slog.LevelError: func(msg string) string { return "ERROR: " + msg },
+//	})
+func (h *Handler) WithLevelMessageDecorator(decorators map[slog.Level]func(msg string) string) *Handler {
+	if len(decorators) == 0 {
+		return h
+	}
+
+	decorators = maps.Clone(decorators)
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, level slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		if fn := decorators[level]; fn != nil {
+			rm = fn(rm)
+		}
+		return rm, attrs
+	})
+}
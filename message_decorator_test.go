@@ -0,0 +1,75 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_WithLevelMessageDecorator(t *testing.T) {
+	decorators := map[slog.Level]func(string) string{
+		slog.LevelError: func(msg string) string { return "ERROR: " + msg },
+		slog.LevelWarn:  nil,
+	}
+
+	tests := []struct {
+		name     string
+		handler  func(h *Handler) *Handler
+		level    slog.Level
+		expected string
+	}{
+		{
+			name:     "decorated level",
+			handler:  func(h *Handler) *Handler { return h.WithLevelMessageDecorator(decorators) },
+			level:    slog.LevelError,
+			expected: `level=ERROR msg="ERROR: failed"`,
+		},
+		{
+			name:     "other level",
+			handler:  func(h *Handler) *Handler { return h.WithLevelMessageDecorator(decorators) },
+			level:    slog.LevelInfo,
+			expected: `level=INFO msg=failed`,
+		},
+		{
+			name:     "nil decorator",
+			handler:  func(h *Handler) *Handler { return h.WithLevelMessageDecorator(decorators) },
+			level:    slog.LevelWarn,
+			expected: `level=WARN msg=failed`,
+		},
+		{
+			name:     "after name prefix",
+			handler:  func(h *Handler) *Handler { return h.Named("db").WithLevelMessageDecorator(decorators) },
+			level:    slog.LevelError,
+			expected: `level=ERROR msg="ERROR: [db] failed"`,
+		},
+		{
+			name: "composed in order",
+			handler: func(h *Handler) *Handler {
+				return h.WithLevelMessageDecorator(decorators).WithLevelMessageDecorator(map[slog.Level]func(string) string{
+					slog.LevelError: func(msg string) string { return "<" + msg + ">" },
+				})
+			},
+			level:    slog.LevelError,
+			expected: `level=ERROR msg="<ERROR: failed>"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := tt.handler(NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+
+			slog.New(h).Log(context.Background(), tt.level, "failed")
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestHandler_WithLevelMessageDecorator_Empty(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+	assert.Same(t, h, h.WithLevelMessageDecorator(nil))
+}
@@ -0,0 +1,108 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// metricValue is the value of an attribute created with Metric, Counter or Gauge.
+type metricValue struct {
+	value float64
+	unit  string
+	kind  string
+}
+
+// LogValue returns the metric as a group with the value, the unit and the kind if any.
+func (m metricValue) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.Float64("value", m.value), slog.String("unit", m.unit)}
+	if m.kind != "" {
+		attrs = append(attrs, slog.String("type", m.kind))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Metric constructs a field holding a numeric measurement, logged as a group with the value
+// and its unit so that log-based metric systems can extract it:
+//
+//	logger.Info("request served", slogs.Metric("latency", 12.5, "Milliseconds"))
+//	// {"msg":"request served","latency":{"value":12.5,"unit":"Milliseconds"}}
+//
+// See Handler.WithEMF to log metrics in the CloudWatch embedded metric format instead.
+func Metric(key string, value float64, unit string) slog.Attr {
+	return slog.Any(key, metricValue{value: value, unit: unit})
+}
+
+// Counter is like Metric but marks the measurement as a counter, adding "type":"counter"
+// to the group.
+func Counter(key string, value float64, unit string) slog.Attr {
+	return slog.Any(key, metricValue{value: value, unit: unit, kind: "counter"})
+}
+
+// Gauge is like Metric but marks the measurement as a gauge, adding "type":"gauge" to the group.
+func Gauge(key string, value float64, unit string) slog.Attr {
+	return slog.Any(key, metricValue{value: value, unit: unit, kind: "gauge"})
+}
+
+// WithEMF returns a new Handler that logs the metrics of each record in the CloudWatch
+// embedded metric format (EMF), so that CloudWatch extracts them from the logs.
+//
+// Metrics created with Metric, Counter or Gauge at the root level of the record are replaced
+// by their value, and an "_aws" object listing them with their unit, the namespace and the
+// dimensions is added. dimensions are the keys of root attributes identifying the metrics,
+// which must be present in the records. Units must be CloudWatch units such as "Count",
+// "Milliseconds" or "Bytes". Records without metrics are left unchanged.
+//
+// EMF requires JSON output, e.g. from slog.NewJSONHandler:
+//
+//	handler := slogs.NewHandler(slog.NewJSONHandler(os.Stdout, nil)).WithEMF("checkout", "service")
+//	logger := slogs.New(handler).With("service", "api")
+//	logger.Info("request served", slogs.Metric("latency", 12.5, "Milliseconds"))
+//	// {"msg":"request served","service":"api","latency":12.5,"_aws":{"Timestamp":1700000000000,
+//	//  "CloudWatchMetrics":[{"Namespace":"checkout","Dimensions":[["service"]],
+//	//  "Metrics":[{"Name":"latency","Unit":"Milliseconds"}]}]}}
+func (h *Handler) WithEMF(namespace string, dimensions ...string) *Handler {
+	dims := []any{append([]string{}, dimensions...)}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, rt time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		var out []slog.Attr
+		var metrics []any
+		for i, a := range attrs {
+			m, ok := metricOf(a)
+			if !ok {
+				if out != nil {
+					out = append(out, a)
+				}
+				continue
+			}
+
+			if out == nil {
+				out = make([]slog.Attr, i, len(attrs)+1)
+				copy(out, attrs[:i])
+			}
+			out = append(out, slog.Float64(a.Key, m.value))
+			metrics = append(metrics, map[string]any{"Name": a.Key, "Unit": m.unit})
+		}
+		if out == nil {
+			return rm, attrs
+		}
+
+		return rm, append(out, slog.Group("_aws",
+			slog.Int64("Timestamp", rt.UnixMilli()),
+			slog.Any("CloudWatchMetrics", []any{map[string]any{
+				"Namespace":  namespace,
+				"Dimensions": dims,
+				"Metrics":    metrics,
+			}}),
+		))
+	})
+}
+
+// metricOf returns the metric held by a, if any.
+func metricOf(a slog.Attr) (metricValue, bool) {
+	if a.Value.Kind() != slog.KindLogValuer {
+		return metricValue{}, false
+	}
+	m, ok := a.Value.Any().(metricValue)
+	return m, ok
+}
@@ -0,0 +1,68 @@
+package slogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetric(t *testing.T) {
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want string
+	}{
+		{name: "metric", attr: Metric("latency", 12.5, "Milliseconds"), want: `"latency":{"value":12.5,"unit":"Milliseconds"}`},
+		{name: "counter", attr: Counter("hits", 3, "Count"), want: `"hits":{"value":3,"unit":"Count","type":"counter"}`},
+		{name: "gauge", attr: Gauge("queue", 7, "None"), want: `"queue":{"value":7,"unit":"None","type":"gauge"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			slog.New(slog.NewJSONHandler(buf, nil)).Info("msg", tt.attr)
+
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestHandler_WithEMF(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(buf, nil)).WithEMF("checkout", "service")
+	logger := New(h).With("service", "api")
+
+	logger.Info("request served", Metric("latency", 12.5, "Milliseconds"), "path", "/", Counter("hits", 1, "Count"))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "api", got["service"])
+	assert.Equal(t, 12.5, got["latency"])
+	assert.Equal(t, 1.0, got["hits"])
+	assert.Equal(t, "/", got["path"])
+
+	aws, ok := got["_aws"].(map[string]any)
+	require.True(t, ok)
+	assert.IsType(t, float64(0), aws["Timestamp"])
+	assert.Equal(t, []any{map[string]any{
+		"Namespace":  "checkout",
+		"Dimensions": []any{[]any{"service"}},
+		"Metrics": []any{
+			map[string]any{"Name": "latency", "Unit": "Milliseconds"},
+			map[string]any{"Name": "hits", "Unit": "Count"},
+		},
+	}}, aws["CloudWatchMetrics"])
+}
+
+func TestHandler_WithEMF_NoMetrics(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithEMF("checkout")
+
+	New(h).Info("msg", "k", "v")
+
+	assert.Equal(t, `{"level":"INFO","msg":"msg","k":"v"}`+"\n", buf.String())
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"sync"
 )
 
 // Ensure multiHandler implements the slog.Handler interface at compile time
@@ -16,9 +17,24 @@ var _ slog.Handler = (*multiHandler)(nil)
 // multiHandler broadcasts each log record to all downstream handlers,
 // ensuring each handler receives a cloned copy of the record to prevent interference.
 type multiHandler struct {
-	handlers []slog.Handler
+	handlers   []slog.Handler
+	concurrent bool
+	strategy   ErrorStrategy
 }
 
+// ErrorStrategy defines how a handler created by MultiHandlerWithStrategy reacts to a
+// downstream handler returning an error.
+type ErrorStrategy int
+
+const (
+	// ContinueOnError passes the record to all handlers and returns their joined errors.
+	// It is the strategy of MultiHandler.
+	ContinueOnError ErrorStrategy = iota
+	// StopOnError returns the first error, without passing the record to the handlers after
+	// the failing one.
+	StopOnError
+)
+
 // MultiHandler creates a new handler that broadcasts logs to all provided handlers.
 //
 // If nil handlers are passed in, they will be filtered out and will not affect broadcasting.
@@ -32,13 +48,51 @@ type multiHandler struct {
 //	logger := slog.New(multi)
 //	logger.Info("this log will be output to both stdout and stderr")
 func MultiHandler(handlers ...slog.Handler) slog.Handler {
+	return newMultiHandler(false, ContinueOnError, handlers)
+}
+
+// MultiHandlerWithStrategy creates a new handler that broadcasts logs to all provided handlers
+// in order, like MultiHandler, reacting to their errors according to strategy.
+//
+// With StopOnError, the broadcast is aborted by the first failing handler, so the order of
+// handlers matters: e.g. a primary sink can be placed before the secondary ones that must not
+// receive records the primary one failed to write.
+//
+// Example:
+//
+//	multi := slogs.MultiHandlerWithStrategy(slogs.StopOnError, primary, mirror)
+func MultiHandlerWithStrategy(strategy ErrorStrategy, handlers ...slog.Handler) slog.Handler {
+	return newMultiHandler(false, strategy, handlers)
+}
+
+// MultiHandlerConcurrent creates a new handler that broadcasts logs to all provided handlers
+// like MultiHandler, but calls their Handle methods concurrently, each in its own goroutine,
+// so that a slow handler (e.g. one writing over the network) does not delay the others.
+//
+// Handle still waits for all handlers to return before returning their joined errors, so the
+// latency of a call is that of the slowest handler rather than the sum of all of them. With
+// fast handlers, the cost of the goroutines outweighs the gain and MultiHandler should be
+// preferred.
+//
+// Example:
+//
+//	local := slog.NewJSONHandler(os.Stdout, nil)
+//	remote := slog.NewJSONHandler(conn, nil)
+//	logger := slog.New(slogs.MultiHandlerConcurrent(local, remote))
+func MultiHandlerConcurrent(handlers ...slog.Handler) slog.Handler {
+	return newMultiHandler(true, ContinueOnError, handlers)
+}
+
+// newMultiHandler creates a multiHandler, flattening the nested ones with the same dispatch
+// mode and error strategy.
+func newMultiHandler(concurrent bool, strategy ErrorStrategy, handlers []slog.Handler) slog.Handler {
 	// Filter out nil handlers
 	var valid []slog.Handler
 	for _, handler := range handlers {
 		if handler == nil {
 			continue
 		}
-		if fan, ok := handler.(*multiHandler); ok {
+		if fan, ok := handler.(*multiHandler); ok && fan.concurrent == concurrent && fan.strategy == strategy {
 			valid = append(valid, fan.handlers...)
 		} else {
 			valid = append(valid, handler)
@@ -49,7 +103,7 @@ func MultiHandler(handlers ...slog.Handler) slog.Handler {
 		return valid[0]
 	}
 
-	return &multiHandler{handlers: valid}
+	return &multiHandler{handlers: valid, concurrent: concurrent, strategy: strategy}
 }
 
 // Enabled reports whether any downstream handler will process logs at the specified level.
@@ -70,9 +124,14 @@ func (h *multiHandler) Enabled(ctx context.Context, l slog.Level) bool {
 // For each enabled handler, it receives a cloned copy of the record
 // to prevent one handler from modifying the record and affecting other handlers.
 //
-// Errors from all handlers will be collected and merged using errors.Join.
-// If all handlers process successfully, it returns nil.
+// Errors from all handlers will be collected and merged using errors.Join, unless the
+// handler was created with StopOnError, in which case the first error is returned and the
+// remaining handlers are skipped. If all handlers process successfully, it returns nil.
 func (h *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.concurrent {
+		return h.handleConcurrent(ctx, r)
+	}
+
 	var errs []error
 
 	for i := range h.handlers {
@@ -80,6 +139,9 @@ func (h *multiHandler) Handle(ctx context.Context, r slog.Record) error {
 		if h.handlers[i].Enabled(ctx, r.Level) {
 			// Clone Record to prevent handler modification from affecting subsequent handlers
 			if err := h.handlers[i].Handle(ctx, r.Clone()); err != nil {
+				if h.strategy == StopOnError {
+					return err
+				}
 				errs = append(errs, err)
 			}
 		}
@@ -88,6 +150,35 @@ func (h *multiHandler) Handle(ctx context.Context, r slog.Record) error {
 	return errors.Join(errs...) // merge all handler errors
 }
 
+// handleConcurrent calls Handle on all enabled downstream handlers in their own goroutines
+// and waits for them to return.
+func (h *multiHandler) handleConcurrent(ctx context.Context, r slog.Record) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for i := range h.handlers {
+		if !h.handlers[i].Enabled(ctx, r.Level) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(handler slog.Handler, r slog.Record) {
+			defer wg.Done()
+			if err := handler.Handle(ctx, r); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(h.handlers[i], r.Clone())
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
 // WithAttrs returns a new multiHandler where each downstream handler has the same attributes added.
 //
 // Each handler creates its own WithAttrs copy, ensuring attribute isolation.
@@ -96,7 +187,7 @@ func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	for i := range h.handlers {
 		handlers = append(handlers, h.handlers[i].WithAttrs(attrs))
 	}
-	return MultiHandler(handlers...)
+	return newMultiHandler(h.concurrent, h.strategy, handlers)
 }
 
 // WithGroup returns a new multiHandler where each downstream handler has the same group name added.
@@ -112,5 +203,10 @@ func (h *multiHandler) WithGroup(name string) slog.Handler {
 	for i := range h.handlers {
 		handlers = append(handlers, h.handlers[i].WithGroup(name))
 	}
-	return MultiHandler(handlers...)
+	return newMultiHandler(h.concurrent, h.strategy, handlers)
+}
+
+// unwrapAll returns the handlers h passes records to, see handlerSinks.
+func (h *multiHandler) unwrapAll() []slog.Handler {
+	return h.handlers
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"sync"
 	"testing"
@@ -809,3 +810,223 @@ func BenchmarkMultiHandlerWithFlattening(b *testing.B) {
 		}
 	})
 }
+
+// slowHandler is a testHandler taking delay to handle each record.
+type slowHandler struct {
+	*testHandler
+	delay time.Duration
+}
+
+func (h *slowHandler) Handle(ctx context.Context, r slog.Record) error {
+	time.Sleep(h.delay)
+	return h.testHandler.Handle(ctx, r)
+}
+
+func TestMultiHandlerConcurrent(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupHandlers func() []slog.Handler
+		wantRecords   []int
+		errorContains []string
+	}{
+		{
+			name: "broadcasts to all enabled handlers",
+			setupHandlers: func() []slog.Handler {
+				return []slog.Handler{newTestHandler(true), newTestHandler(false), newTestHandler(true)}
+			},
+			wantRecords: []int{1, 0, 1},
+		},
+		{
+			name: "aggregates multiple handler errors",
+			setupHandlers: func() []slog.Handler {
+				h1 := newTestHandler(true)
+				h1.err = errors.New("first error")
+				h2 := newTestHandler(true)
+				h3 := newTestHandler(true)
+				h3.err = errors.New("third error")
+				return []slog.Handler{h1, h2, h3}
+			},
+			wantRecords:   []int{1, 1, 1},
+			errorContains: []string{"first error", "third error"},
+		},
+		{
+			name: "isolates records",
+			setupHandlers: func() []slog.Handler {
+				h1 := newTestHandler(true)
+				h1.mutate = func(r *slog.Record) { r.AddAttrs(slog.String("mutated", "yes")) }
+				return []slog.Handler{h1, newTestHandler(true)}
+			},
+			wantRecords: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := tt.setupHandlers()
+			multi := MultiHandlerConcurrent(handlers...)
+
+			record := slog.NewRecord(time.Now(), slog.LevelInfo, "test message", 0)
+			err := multi.Handle(context.Background(), record)
+
+			if len(tt.errorContains) > 0 {
+				require.Error(t, err)
+				for _, want := range tt.errorContains {
+					assert.Contains(t, err.Error(), want)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			for i, want := range tt.wantRecords {
+				th := handlers[i].(*testHandler)
+				require.Equal(t, want, th.recordCount(), "handler %d", i)
+				if want > 0 && th.mutate == nil {
+					assert.False(t, recordHasAttr(th.getRecords()[0], "mutated", "yes"))
+				}
+			}
+		})
+	}
+}
+
+func TestMultiHandlerConcurrent_SlowHandlers(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	handlers := make([]slog.Handler, 4)
+	for i := range handlers {
+		handlers[i] = &slowHandler{testHandler: newTestHandler(true), delay: delay}
+	}
+	multi := MultiHandlerConcurrent(handlers...)
+
+	start := time.Now()
+	err := multi.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0))
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.Less(t, elapsed, time.Duration(len(handlers))*delay, "handlers should run concurrently")
+	for _, h := range handlers {
+		assert.Equal(t, 1, h.(*slowHandler).recordCount(), "Handle must wait for all handlers")
+	}
+}
+
+func TestMultiHandlerConcurrent_Derived(t *testing.T) {
+	h1 := newTestHandler(true)
+	h2 := newTestHandler(true)
+	h3 := newTestHandler(true)
+
+	concurrent := MultiHandlerConcurrent(h1, h2)
+	withAttrs := concurrent.WithAttrs([]slog.Attr{slog.String("k", "v")})
+	withGroup := concurrent.WithGroup("g")
+	assert.True(t, withAttrs.(*multiHandler).concurrent, "WithAttrs keeps the dispatch mode")
+	assert.True(t, withGroup.(*multiHandler).concurrent, "WithGroup keeps the dispatch mode")
+
+	// A concurrent handler nested in a sequential one is not flattened, and conversely.
+	sequential := MultiHandler(concurrent, h3)
+	require.Len(t, sequential.(*multiHandler).handlers, 2)
+	assert.Same(t, concurrent, sequential.(*multiHandler).handlers[0])
+
+	flattened := MultiHandlerConcurrent(concurrent, h3)
+	assert.Len(t, flattened.(*multiHandler).handlers, 3)
+
+	assert.Same(t, h1, MultiHandlerConcurrent(nil, h1), "a single handler is returned as is")
+}
+
+func BenchmarkMultiHandler_SlowHandler(b *testing.B) {
+	newHandlers := func() []slog.Handler {
+		return []slog.Handler{
+			&slowHandler{testHandler: newTestHandler(true), delay: time.Millisecond},
+			slog.NewJSONHandler(io.Discard, nil),
+			slog.NewJSONHandler(io.Discard, nil),
+			&slowHandler{testHandler: newTestHandler(true), delay: time.Millisecond},
+		}
+	}
+	benchmarks := []struct {
+		name  string
+		multi slog.Handler
+	}{
+		{"sequential", MultiHandler(newHandlers()...)},
+		{"concurrent", MultiHandlerConcurrent(newHandlers()...)},
+	}
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0)
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				bm.multi.Handle(context.Background(), record)
+			}
+		})
+	}
+}
+
+func TestMultiHandlerWithStrategy(t *testing.T) {
+	errFirst := errors.New("first error")
+	errThird := errors.New("third error")
+
+	tests := []struct {
+		name        string
+		strategy    ErrorStrategy
+		errs        []error
+		wantErrs    []error
+		wantRecords []int
+	}{
+		{
+			name:        "continue on error invokes all handlers",
+			strategy:    ContinueOnError,
+			errs:        []error{errFirst, nil, errThird},
+			wantErrs:    []error{errFirst, errThird},
+			wantRecords: []int{1, 1, 1},
+		},
+		{
+			name:        "stop on error skips later handlers",
+			strategy:    StopOnError,
+			errs:        []error{nil, errFirst, errThird},
+			wantErrs:    []error{errFirst},
+			wantRecords: []int{1, 1, 0},
+		},
+		{
+			name:        "stop on error without errors",
+			strategy:    StopOnError,
+			errs:        []error{nil, nil, nil},
+			wantRecords: []int{1, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := make([]slog.Handler, len(tt.errs))
+			for i, err := range tt.errs {
+				th := newTestHandler(true)
+				th.err = err
+				handlers[i] = th
+			}
+			multi := MultiHandlerWithStrategy(tt.strategy, handlers...)
+
+			err := multi.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0))
+
+			if len(tt.wantErrs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, want := range tt.wantErrs {
+				assert.ErrorIs(t, err, want)
+			}
+			if tt.strategy == StopOnError && err != nil {
+				assert.NotErrorIs(t, err, errThird, "later errors are not returned")
+			}
+			for i, want := range tt.wantRecords {
+				assert.Equal(t, want, handlers[i].(*testHandler).recordCount(), "handler %d", i)
+			}
+		})
+	}
+}
+
+func TestMultiHandlerWithStrategy_Derived(t *testing.T) {
+	h1 := newTestHandler(true)
+	h2 := newTestHandler(true)
+	h3 := newTestHandler(true)
+
+	stop := MultiHandlerWithStrategy(StopOnError, h1, h2)
+	assert.Equal(t, StopOnError, stop.WithAttrs([]slog.Attr{slog.Int("k", 1)}).(*multiHandler).strategy)
+	assert.Equal(t, StopOnError, stop.WithGroup("g").(*multiHandler).strategy)
+
+	// Nested handlers are only flattened into handlers with the same strategy.
+	assert.Len(t, MultiHandler(stop, h3).(*multiHandler).handlers, 2)
+	assert.Len(t, MultiHandlerWithStrategy(StopOnError, stop, h3).(*multiHandler).handlers, 3)
+	assert.Len(t, MultiHandlerWithStrategy(ContinueOnError, MultiHandler(h1, h2), h3).(*multiHandler).handlers, 3)
+}
@@ -2,7 +2,9 @@ package slogs
 
 import (
 	"context"
+	"io"
 	"log/slog"
+	"os"
 )
 
 // Option configures a Logger.
@@ -29,28 +31,69 @@ func (f optionFunc) apply(l *Logger) {
 //	logger := slogs.New(handler, slogs.WithCaller(false)) // Disable for performance
 func WithCaller(enabled bool) Option {
 	return optionFunc(func(l *Logger) {
-		l.addCaller = func(_ context.Context, _ slog.Level) bool {
+		l.setAddCaller(func(_ context.Context, _ slog.Level) bool {
 			return enabled
-		}
+		})
 	})
 }
 
 func WithCallerAt(f func(ctx context.Context, level slog.Level) bool) Option {
 	return optionFunc(func(l *Logger) {
 		if f != nil {
-			l.addCaller = f
+			l.setAddCaller(f)
 		}
 	})
 }
 
 func WithCallerAtLevel(level slog.Level) Option {
 	return optionFunc(func(l *Logger) {
-		l.addCaller = func(_ context.Context, lvl slog.Level) bool {
+		l.setAddCaller(func(_ context.Context, lvl slog.Level) bool {
 			return lvl >= level
+		})
+	})
+}
+
+// WithCallerWhen configures the logger to add caller information only to records for which
+// fn returns true, e.g. records carrying a debug=true attribute regardless of their level.
+//
+// fn receives the attributes of the record after processing by the Handler, including those
+// from the context and from With, see Handler.WithCallerWhen. Because the attributes are only
+// known once the record is built, the caller's program counter is captured for every record
+// (a runtime.Callers call, comparable to WithCaller(true)) and dropped when fn returns false;
+// only the source resolution done by the output handler is saved. Prefer WithCallerAt when the
+// decision does not depend on attributes.
+//
+// The last caller option applied wins: WithCallerWhen replaces WithCaller, WithCallerAt and
+// WithCallerAtLevel, and they replace it. A nil fn is ignored.
+//
+// Example:
+//
+//	logger := slogs.New(handler, slogs.WithCallerWhen(func(_ context.Context, _ slog.Level, attrs []slog.Attr) bool {
+//		for _, a := range attrs {
+//			if a.Key == "debug" && a.Value.Equal(slog.BoolValue(true)) {
+//				return true
+//			}
+//		}
+//		return false
+//	}))
+func WithCallerWhen(fn func(ctx context.Context, level slog.Level, attrs []slog.Attr) bool) Option {
+	return optionFunc(func(l *Logger) {
+		if fn == nil {
+			return
 		}
+		l.addCaller = func(_ context.Context, _ slog.Level) bool { return true }
+		l.handler = l.handler.WithCallerWhen(fn)
 	})
 }
 
+// setAddCaller sets the caller decision of l, replacing any WithCallerWhen function.
+func (l *Logger) setAddCaller(f func(ctx context.Context, level slog.Level) bool) {
+	l.addCaller = f
+	if l.handler.callerWhen != nil {
+		l.handler = l.handler.WithCallerWhen(nil)
+	}
+}
+
 // WithCallerSkip adds the given number of stack frames to skip when capturing caller information.
 //
 // This is useful when wrapping the logger in your own logging functions.
@@ -84,3 +127,117 @@ func WithLevel(level slog.Level) Option {
 		l.handler = l.handler.WithLevel(level)
 	})
 }
+
+// WithClock configures the clock the logger reads the time of its records from, so that tests
+// can freeze time and assert exact timestamps. A nil clock restores DefaultClock.
+//
+// Example:
+//
+//	logger := slogs.New(handler, slogs.WithClock(fixedClock))
+func WithClock(c Clock) Option {
+	return optionFunc(func(l *Logger) {
+		if c == nil {
+			c = DefaultClock
+		}
+		l.clock = c
+	})
+}
+
+// WithNameFunc configures the logger to derive its name from the context of each record.
+//
+// A non-empty name returned by fn takes precedence over the static name set via Named;
+// an empty result falls back to the static name.
+//
+// Example:
+//
+//	logger := slogs.New(handler, slogs.WithNameFunc(func(ctx context.Context) string {
+//		tenant, _ := ctx.Value(tenantKey{}).(string)
+//		return tenant
+//	}))
+func WithNameFunc(fn func(ctx context.Context) string) Option {
+	return optionFunc(func(l *Logger) {
+		l.handler = l.handler.WithNameFunc(fn)
+	})
+}
+
+// WithContextExtractors configures the logger to add the attributes returned by the extractors
+// of e for the context of each record, see Handler.WithContextExtractors. It replaces the
+// extractors configured on the handler, so that a logger can use its own list.
+//
+// Example:
+//
+//	logger := slogs.New(handler, slogs.WithContextExtractors(extractors))
+func WithContextExtractors(e *ContextExtractors) Option {
+	return optionFunc(func(l *Logger) {
+		l.handler = l.handler.WithContextExtractors(e)
+	})
+}
+
+// WithStderrFallback configures whether records that the handler fails to process
+// are written to os.Stderr as a last resort.
+//
+// When enabled and Handler.Handle returns an error, a minimal text line containing the
+// record's time, level, message and the handler error is written to stderr so the log is
+// not lost entirely. Fallback output is rate limited to avoid flooding stderr when a sink
+// is down for a long time; suppressed lines are counted and reported.
+//
+// Example:
+//
+//	logger := slogs.New(handler, slogs.WithStderrFallback(true))
+func WithStderrFallback(enabled bool) Option {
+	if !enabled {
+		return WithFallbackWriter(nil)
+	}
+	return WithFallbackWriter(os.Stderr)
+}
+
+// WithFallbackWriter is like WithStderrFallback but writes fallback lines to w.
+//
+// Passing nil disables the fallback.
+func WithFallbackWriter(w io.Writer) Option {
+	return optionFunc(func(l *Logger) {
+		if w == nil {
+			l.fallback = nil
+			return
+		}
+		l.fallback = newFallbackWriter(w)
+	})
+}
+
+// WithCLIMode configures whether the logger tracks the highest level it logs, see
+// Logger.HighestLevel.
+//
+// This supports the common CLI pattern of failing the command if anything was logged at
+// Error level. Loggers derived after the option is applied share the tracked level.
+//
+// Example:
+//
+//	logger := slogs.New(handler, slogs.WithCLIMode(true))
+//	run(logger)
+//	if level, ok := logger.HighestLevel(); ok && level >= slog.LevelError {
+//		os.Exit(1)
+//	}
+func WithCLIMode(enabled bool) Option {
+	return optionFunc(func(l *Logger) {
+		if !enabled {
+			l.levels = nil
+			return
+		}
+		l.levels = newLevelTracker()
+	})
+}
+
+// WithRuntimeInfo configures whether New logs a record at slog.LevelInfo with the attributes
+// returned by RuntimeInfoAttrs, once all options are applied.
+//
+// It only takes effect in New: deriving loggers with WithOptions does not log the record again.
+//
+// Example:
+//
+//	logger := slogs.New(handler, slogs.WithRuntimeInfo(true))
+//	// Output: level=INFO msg="runtime info" go_version=go1.22.0 gomaxprocs=8 num_cpu=8 ...
+func WithRuntimeInfo(enabled bool) Option {
+	return optionFunc(func(l *Logger) {
+		l.runtimeInfo = enabled
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"log/slog"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWithCaller(t *testing.T) {
@@ -74,3 +77,133 @@ func TestWithCallerAt_Nil(t *testing.T) {
 	logger.Info("test")
 	assert.NotEmpty(t, buf.String())
 }
+
+func TestWithClock(t *testing.T) {
+	clock := newFakeClock()
+	next := newTestHandler(true)
+	logger := New(NewHandler(next), WithClock(clock))
+
+	logger.Info("info")
+	clock.Advance(time.Minute)
+	logger.LogAttrs(context.Background(), slog.LevelWarn, "attrs")
+	logger.Sugar().Errorf("%s", "sugar")
+	logger.WithOptions(WithClock(nil)).Info("default")
+
+	records := next.getRecords()
+	require.Len(t, records, 4)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, start, records[0].Time)
+	assert.Equal(t, start.Add(time.Minute), records[1].Time)
+	assert.Equal(t, start.Add(time.Minute), records[2].Time)
+	assert.WithinDuration(t, time.Now(), records[3].Time, time.Minute, "a nil clock restores DefaultClock")
+}
+
+func TestWithNameFunc(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(buf, nil))
+	logger := New(h, WithNameFunc(func(ctx context.Context) string {
+		name, _ := ctx.Value(ctxKeyName{}).(string)
+		return name
+	}))
+
+	logger.InfoContext(context.WithValue(context.Background(), ctxKeyName{}, "first"), "one")
+	logger.InfoContext(context.WithValue(context.Background(), ctxKeyName{}, "second"), "two")
+
+	assert.Contains(t, buf.String(), "[first] one")
+	assert.Contains(t, buf.String(), "[second] two")
+}
+
+func TestWithRuntimeInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		want    int
+	}{
+		{name: "enabled", enabled: true, want: 1},
+		{name: "disabled", enabled: false, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			logger := New(NewHandler(next), WithRuntimeInfo(tt.enabled))
+			logger.WithOptions(WithCaller(true))
+
+			assert.Equal(t, tt.want, next.recordCount())
+			for _, r := range next.getRecords() {
+				assert.Equal(t, "runtime info", r.Message)
+				assert.True(t, recordHasAttr(r, "go_version", runtime.Version()))
+			}
+		})
+	}
+}
+
+func TestWithCallerWhen(t *testing.T) {
+	debugAttr := func(_ context.Context, _ slog.Level, attrs []slog.Attr) bool {
+		for _, a := range attrs {
+			if a.Key == "debug" && a.Value.Equal(slog.BoolValue(true)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	tests := []struct {
+		name       string
+		options    []Option
+		log        func(l *Logger)
+		wantSource bool
+	}{
+		{
+			name:       "included by record attr",
+			options:    []Option{WithCallerWhen(debugAttr)},
+			log:        func(l *Logger) { l.Info("msg", "debug", true) },
+			wantSource: true,
+		},
+		{
+			name:       "included by logger attr",
+			options:    []Option{WithCallerWhen(debugAttr)},
+			log:        func(l *Logger) { l.With("debug", true).Error("msg") },
+			wantSource: true,
+		},
+		{
+			name:       "excluded without attr",
+			options:    []Option{WithCallerWhen(debugAttr)},
+			log:        func(l *Logger) { l.Error("msg", "debug", false) },
+			wantSource: false,
+		},
+		{
+			name:       "replaced by later caller option",
+			options:    []Option{WithCallerWhen(debugAttr), WithCaller(true)},
+			log:        func(l *Logger) { l.Info("msg") },
+			wantSource: true,
+		},
+		{
+			name:       "replaces earlier caller option",
+			options:    []Option{WithCaller(true), WithCallerWhen(debugAttr)},
+			log:        func(l *Logger) { l.Info("msg") },
+			wantSource: false,
+		},
+		{
+			name:       "nil is ignored",
+			options:    []Option{WithCaller(true), WithCallerWhen(nil)},
+			log:        func(l *Logger) { l.Info("msg") },
+			wantSource: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true}))
+
+			tt.log(New(h, tt.options...))
+
+			if tt.wantSource {
+				assert.Contains(t, buf.String(), `"source":{`)
+			} else {
+				assert.NotContains(t, buf.String(), `"source"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package otel
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+var _ slog.Handler = (*BaggageHandler)(nil)
+
+// BaggageHandler adds the W3C baggage members of the record context as attributes, so that
+// application-level context propagated across services, such as a tenant or feature flags,
+// shows up in the logs next to the trace identifiers.
+//
+// Members are added as string attributes named after their keys, at the end of the record,
+// and nested under the groups open on the handler. Use it as the next handler of a
+// slogs.Handler, which resolves its groups itself, to get top-level attributes. Records
+// logged with a context without baggage are passed through unchanged.
+//
+// Example:
+//
+//	h := otel.NewBaggageHandler(slog.NewJSONHandler(os.Stdout, nil), []string{"tenant"}).Namespaced("baggage")
+//	logger := slogs.New(slogs.NewHandler(h))
+//	logger.InfoContext(ctx, "served") // {"msg":"served","baggage":{"tenant":"acme"}}
+type BaggageHandler struct {
+	next  slog.Handler
+	allow []string
+	group string
+}
+
+// NewBaggageHandler creates a BaggageHandler adding the baggage members whose keys are in
+// allow, in that order, and passing records to next. A nil or empty allow adds all members,
+// sorted by key.
+//
+// Panics if next is nil.
+func NewBaggageHandler(next slog.Handler, allow []string) *BaggageHandler {
+	if next == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+
+	return &BaggageHandler{next: next, allow: allow}
+}
+
+// Namespaced returns a BaggageHandler adding the baggage members under a group with the given
+// name, e.g. "baggage", rather than as individual attributes.
+func (h *BaggageHandler) Namespaced(group string) *BaggageHandler {
+	h2 := *h
+	h2.group = group
+	return &h2
+}
+
+// Enabled reports whether the next handler handles records at level.
+func (h *BaggageHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the baggage members of ctx to r and passes it to the next handler.
+func (h *BaggageHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs := h.baggageAttrs(ctx); len(attrs) > 0 {
+		r = r.Clone()
+		if h.group != "" {
+			r.AddAttrs(slog.Attr{Key: h.group, Value: slog.GroupValue(attrs...)})
+		} else {
+			r.AddAttrs(attrs...)
+		}
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a BaggageHandler whose next handler has the given attributes.
+func (h *BaggageHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a BaggageHandler whose next handler has the given group.
+func (h *BaggageHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+// baggageAttrs returns the allowed baggage members of ctx as attributes.
+func (h *BaggageHandler) baggageAttrs(ctx context.Context) []slog.Attr {
+	b := baggage.FromContext(ctx)
+	if b.Len() == 0 {
+		return nil
+	}
+
+	if len(h.allow) == 0 {
+		members := b.Members()
+		sort.Slice(members, func(i, j int) bool {
+			return members[i].Key() < members[j].Key()
+		})
+
+		attrs := make([]slog.Attr, 0, len(members))
+		for _, m := range members {
+			attrs = append(attrs, slog.String(m.Key(), m.Value()))
+		}
+		return attrs
+	}
+
+	var attrs []slog.Attr
+	for _, key := range h.allow {
+		if m := b.Member(key); m.Key() != "" {
+			attrs = append(attrs, slog.String(key, m.Value()))
+		}
+	}
+	return attrs
+}
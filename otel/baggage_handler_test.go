@@ -0,0 +1,100 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/rockcookies/go-slogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// withBaggage returns a context holding baggage with the given key-value pairs.
+func withBaggage(t *testing.T, kvs ...string) context.Context {
+	t.Helper()
+	var members []baggage.Member
+	for i := 0; i+1 < len(kvs); i += 2 {
+		m, err := baggage.NewMember(kvs[i], kvs[i+1])
+		require.NoError(t, err)
+		members = append(members, m)
+	}
+	b, err := baggage.New(members...)
+	require.NoError(t, err)
+	return baggage.ContextWithBaggage(context.Background(), b)
+}
+
+func TestNewBaggageHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewBaggageHandler(nil, nil)
+	})
+}
+
+func TestBaggageHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		allow   []string
+		group   string
+		ctx     func(t *testing.T) context.Context
+		want    string
+		notWant string
+	}{
+		{
+			name:  "all members sorted by key",
+			allow: nil,
+			ctx:   func(t *testing.T) context.Context { return withBaggage(t, "tenant", "acme", "flag", "on") },
+			want:  `"k":1,"flag":"on","tenant":"acme"}`,
+		},
+		{
+			name:    "allowed members only",
+			allow:   []string{"tenant", "missing"},
+			ctx:     func(t *testing.T) context.Context { return withBaggage(t, "tenant", "acme", "secret", "x") },
+			want:    `"k":1,"tenant":"acme"}`,
+			notWant: "secret",
+		},
+		{
+			name:  "namespaced",
+			allow: []string{"tenant"},
+			group: "baggage",
+			ctx:   func(t *testing.T) context.Context { return withBaggage(t, "tenant", "acme") },
+			want:  `"k":1,"baggage":{"tenant":"acme"}}`,
+		},
+		{
+			name:    "no baggage",
+			group:   "baggage",
+			ctx:     func(t *testing.T) context.Context { return context.Background() },
+			want:    `"k":1}`,
+			notWant: "baggage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewBaggageHandler(slog.NewJSONHandler(buf, nil), tt.allow)
+			if tt.group != "" {
+				h = h.Namespaced(tt.group)
+			}
+			logger := slogs.New(slogs.NewHandler(h))
+
+			logger.InfoContext(tt.ctx(t), "served", "k", 1)
+
+			assert.Contains(t, buf.String(), tt.want)
+			if tt.notWant != "" {
+				assert.NotContains(t, buf.String(), tt.notWant)
+			}
+		})
+	}
+}
+
+func TestBaggageHandler_WithGroup(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewBaggageHandler(slog.NewJSONHandler(buf, nil), nil)
+	logger := slog.New(h).With("a", 1).WithGroup("req")
+
+	logger.InfoContext(withBaggage(t, "tenant", "acme"), "served")
+
+	assert.Contains(t, buf.String(), `"a":1,"req":{"tenant":"acme"}`)
+}
@@ -0,0 +1,229 @@
+// Package otel bridges slogs to the OpenTelemetry Logs API.
+//
+// It lives in its own module so that the core slogs module does not depend on OpenTelemetry.
+package otel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/rockcookies/go-slogs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+)
+
+var _ slog.Handler = (*Handler)(nil)
+
+// Handler is a slog.Handler that emits records to an OpenTelemetry LoggerProvider.
+//
+// The instrumentation scope of each record is the logger name chain reported by
+// slogs.LoggerName (e.g. "service.database"), so named loggers show up as separate
+// scopes in backends that group logs by scope. Records without a logger name use the
+// scope name passed to NewHandler. Scoped loggers are created once per name and cached.
+//
+// Example:
+//
+//	h := otel.NewHandler("github.com/acme/app", provider)
+//	logger := slogs.New(slogs.NewHandler(h)).Named("service")
+//	logger.Info("started") // emitted with instrumentation scope "service"
+type Handler struct {
+	provider log.LoggerProvider
+	name     string
+	loggers  *sync.Map
+
+	// groups holds the attributes added with WithAttrs, per open group.
+	// groups[0] is the root and never has a name.
+	groups []group
+}
+
+// NewHandler creates a Handler that emits records through loggers obtained from provider.
+//
+// name is the instrumentation scope used for records that carry no logger name;
+// it is recommended to use the import path of the application or library.
+// Panics if provider is nil.
+func NewHandler(name string, provider log.LoggerProvider) *Handler {
+	if provider == nil {
+		panic("slogs: logger provider cannot be nil")
+	}
+
+	return &Handler{
+		provider: provider,
+		name:     name,
+		loggers:  &sync.Map{},
+		groups:   []group{{}},
+	}
+}
+
+// Enabled reports whether the logger of the record's scope emits records at level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger(ctx).Enabled(ctx, log.EnabledParameters{Severity: Severity(level)})
+}
+
+// Handle converts r to an OpenTelemetry log record and emits it.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var record log.Record
+	record.SetTimestamp(r.Time)
+	record.SetSeverity(Severity(r.Level))
+	record.SetSeverityText(r.Level.String())
+	record.SetBody(attribute.StringValue(r.Message))
+
+	record.AddAttributes(recordAttributes(h.groups, r)...)
+
+	h.logger(ctx).Emit(ctx, record)
+	return nil
+}
+
+// WithAttrs returns a new Handler whose records include the given attributes.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = withAttrs(h.groups, attrs)
+	return &h2
+}
+
+// WithGroup returns a new Handler that nests subsequent attributes under name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), group{name: name})
+	return &h2
+}
+
+// logger returns the cached logger for the scope of a record logged with ctx.
+func (h *Handler) logger(ctx context.Context) log.Logger {
+	name := slogs.LoggerName(ctx)
+	if name == "" {
+		name = h.name
+	}
+
+	if l, ok := h.loggers.Load(name); ok {
+		return l.(log.Logger)
+	}
+	l, _ := h.loggers.LoadOrStore(name, h.provider.Logger(name))
+	return l.(log.Logger)
+}
+
+// Severity converts a slog level to an OpenTelemetry severity.
+//
+// slog.LevelDebug, slog.LevelInfo, slog.LevelWarn and slog.LevelError map to
+// log.SeverityDebug, log.SeverityInfo, log.SeverityWarn and log.SeverityError;
+// levels in between map to the corresponding intermediate severities
+// (e.g. slog.LevelInfo+1 to log.SeverityInfo2). Levels outside the range of
+// OpenTelemetry severities are clamped to log.SeverityTrace1 and log.SeverityFatal4.
+func Severity(level slog.Level) log.Severity {
+	s := int(level) + int(log.SeverityInfo)
+	switch {
+	case s < int(log.SeverityTrace1):
+		return log.SeverityTrace1
+	case s > int(log.SeverityFatal4):
+		return log.SeverityFatal4
+	default:
+		return log.Severity(s)
+	}
+}
+
+// group is a group opened with WithGroup along with the attributes added inside it.
+type group struct {
+	name  string
+	attrs []attribute.KeyValue
+}
+
+// withAttrs returns a copy of groups with attrs added to the innermost group.
+func withAttrs(groups []group, attrs []slog.Attr) []group {
+	groups = slices.Clone(groups)
+	last := &groups[len(groups)-1]
+	for _, a := range attrs {
+		last.attrs = appendAttr(slices.Clip(last.attrs), a)
+	}
+	return groups
+}
+
+// recordAttributes converts the attributes of r and nests them, along with those added with
+// WithAttrs, into the open groups. groups[0] is the root and never has a name.
+func recordAttributes(groups []group, r slog.Record) []attribute.KeyValue {
+	attrs := slices.Clip(groups[len(groups)-1].attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendAttr(attrs, a)
+		return true
+	})
+
+	// Nest the attributes into the open groups, innermost first, omitting empty groups.
+	for i := len(groups) - 1; i > 0; i-- {
+		g := groups[i-1]
+		if len(attrs) == 0 {
+			attrs = g.attrs
+			continue
+		}
+		attrs = append(slices.Clip(g.attrs), attribute.Map(groups[i].name, attrs...))
+	}
+	return attrs
+}
+
+// appendAttr converts a and appends it to kvs, skipping attributes slog handlers would omit.
+func appendAttr(kvs []attribute.KeyValue, a slog.Attr) []attribute.KeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return kvs
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		var members []attribute.KeyValue
+		for _, ga := range a.Value.Group() {
+			members = appendAttr(members, ga)
+		}
+		if len(members) == 0 {
+			return kvs
+		}
+		// Attributes of a group with an empty key are inlined, as slog does.
+		if a.Key == "" {
+			return append(kvs, members...)
+		}
+		return append(kvs, attribute.Map(a.Key, members...))
+	}
+
+	return append(kvs, attribute.KeyValue{Key: attribute.Key(a.Key), Value: convertValue(a.Value)})
+}
+
+// convertValue converts a resolved, non-group slog value to an OpenTelemetry attribute value.
+func convertValue(v slog.Value) attribute.Value {
+	switch v.Kind() {
+	case slog.KindBool:
+		return attribute.BoolValue(v.Bool())
+	case slog.KindInt64:
+		return attribute.Int64Value(v.Int64())
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return attribute.Int64Value(int64(u))
+		}
+		return attribute.StringValue(v.String())
+	case slog.KindFloat64:
+		return attribute.Float64Value(v.Float64())
+	case slog.KindString:
+		return attribute.StringValue(v.String())
+	case slog.KindDuration:
+		return attribute.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindTime:
+		return attribute.Int64Value(v.Time().UnixNano())
+	}
+
+	switch x := v.Any().(type) {
+	case []byte:
+		return attribute.ByteSliceValue(x)
+	case error:
+		return attribute.StringValue(x.Error())
+	case fmt.Stringer:
+		return attribute.StringValue(x.String())
+	default:
+		return attribute.StringValue(fmt.Sprintf("%+v", x))
+	}
+}
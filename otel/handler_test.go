@@ -0,0 +1,262 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-slogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+)
+
+// testProvider is a LoggerProvider that records emitted records per scope.
+type testProvider struct {
+	embedded.LoggerProvider
+
+	mu       sync.Mutex
+	created  map[string]int
+	records  map[string][]log.Record
+	minLevel log.Severity
+}
+
+func newTestProvider() *testProvider {
+	return &testProvider{
+		created: map[string]int{},
+		records: map[string][]log.Record{},
+	}
+}
+
+func (p *testProvider) Logger(name string, _ ...log.LoggerOption) log.Logger {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.created[name]++
+	return &testLogger{provider: p, scope: name}
+}
+
+func (p *testProvider) scopeRecords(scope string) []log.Record {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.records[scope]
+}
+
+type testLogger struct {
+	embedded.Logger
+
+	provider *testProvider
+	scope    string
+}
+
+func (l *testLogger) Emit(_ context.Context, r log.Record) {
+	l.provider.mu.Lock()
+	defer l.provider.mu.Unlock()
+	l.provider.records[l.scope] = append(l.provider.records[l.scope], r.Clone())
+}
+
+func (l *testLogger) Enabled(_ context.Context, param log.EnabledParameters) bool {
+	return param.Severity >= l.provider.minLevel
+}
+
+func recordAttrs(r log.Record) []attribute.KeyValue {
+	var kvs []attribute.KeyValue
+	r.WalkAttributes(func(kv attribute.KeyValue) bool {
+		kvs = append(kvs, kv)
+		return true
+	})
+	return kvs
+}
+
+func TestNewHandler_NilProvider(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: logger provider cannot be nil", func() {
+		NewHandler("app", nil)
+	})
+}
+
+func TestHandler_Scope(t *testing.T) {
+	tests := []struct {
+		name   string
+		logger func(h slog.Handler) *slogs.Logger
+		scope  string
+	}{
+		{
+			name:   "default scope",
+			logger: func(h slog.Handler) *slogs.Logger { return slogs.New(slogs.NewHandler(h)) },
+			scope:  "app",
+		},
+		{
+			name: "name chain",
+			logger: func(h slog.Handler) *slogs.Logger {
+				return slogs.New(slogs.NewHandler(h)).Named("service").Named("database")
+			},
+			scope: "service.database",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestProvider()
+			logger := tt.logger(NewHandler("app", provider))
+
+			logger.Info("first")
+			logger.Info("second")
+
+			records := provider.scopeRecords(tt.scope)
+			require.Len(t, records, 2)
+			assert.Equal(t, 1, provider.created[tt.scope], "scoped logger should be cached")
+		})
+	}
+}
+
+func TestHandler_SharedCache(t *testing.T) {
+	provider := newTestProvider()
+	h := NewHandler("app", provider)
+
+	ctx := context.Background()
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	require.NoError(t, h.Handle(ctx, r))
+	require.NoError(t, h.WithAttrs([]slog.Attr{slog.String("k", "v")}).Handle(ctx, r))
+	require.NoError(t, h.WithGroup("g").Handle(ctx, r))
+
+	assert.Equal(t, 1, provider.created["app"])
+	assert.Len(t, provider.scopeRecords("app"), 3)
+}
+
+func TestHandler_Record(t *testing.T) {
+	provider := newTestProvider()
+	h := NewHandler("app", provider)
+
+	now := time.Now()
+	r := slog.NewRecord(now, slog.LevelWarn, "disk almost full", 0)
+	r.AddAttrs(slog.Int("free", 3))
+	require.NoError(t, h.Handle(context.Background(), r))
+
+	records := provider.scopeRecords("app")
+	require.Len(t, records, 1)
+	assert.Equal(t, now, records[0].Timestamp())
+	assert.Equal(t, log.SeverityWarn, records[0].Severity())
+	assert.Equal(t, "WARN", records[0].SeverityText())
+	assert.Equal(t, "disk almost full", records[0].Body().AsString())
+	assert.Equal(t, []attribute.KeyValue{attribute.Int64("free", 3)}, recordAttrs(records[0]))
+}
+
+func TestHandler_Attrs(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  func(h slog.Handler) slog.Handler
+		attrs    []slog.Attr
+		expected []attribute.KeyValue
+	}{
+		{
+			name:    "values",
+			handler: func(h slog.Handler) slog.Handler { return h },
+			attrs: []slog.Attr{
+				slog.Bool("bool", true),
+				slog.Uint64("uint", 7),
+				slog.Float64("float", 1.5),
+				slog.Duration("duration", time.Second),
+				slog.Any("bytes", []byte("raw")),
+				slog.Any("error", errors.New("boom")),
+				slog.Any("slice", []int{1, 2}),
+			},
+			expected: []attribute.KeyValue{
+				attribute.Bool("bool", true),
+				attribute.Int64("uint", 7),
+				attribute.Float64("float", 1.5),
+				attribute.Int64("duration", int64(time.Second)),
+				attribute.ByteSlice("bytes", []byte("raw")),
+				attribute.String("error", "boom"),
+				attribute.String("slice", "[1 2]"),
+			},
+		},
+		{
+			name:    "group attr",
+			handler: func(h slog.Handler) slog.Handler { return h },
+			attrs: []slog.Attr{
+				slog.Group("req", slog.String("method", "GET")),
+				slog.Group("empty"),
+				slog.Group("", slog.String("inline", "yes")),
+			},
+			expected: []attribute.KeyValue{
+				attribute.Map("req", attribute.String("method", "GET")),
+				attribute.String("inline", "yes"),
+			},
+		},
+		{
+			name: "with attrs and groups",
+			handler: func(h slog.Handler) slog.Handler {
+				return h.WithAttrs([]slog.Attr{slog.String("service", "api")}).
+					WithGroup("req").
+					WithAttrs([]slog.Attr{slog.String("method", "GET")}).
+					WithGroup("db")
+			},
+			attrs: []slog.Attr{slog.Int("rows", 2)},
+			expected: []attribute.KeyValue{
+				attribute.String("service", "api"),
+				attribute.Map("req",
+					attribute.String("method", "GET"),
+					attribute.Map("db", attribute.Int64("rows", 2)),
+				),
+			},
+		},
+		{
+			name: "empty groups omitted",
+			handler: func(h slog.Handler) slog.Handler {
+				return h.WithAttrs([]slog.Attr{slog.String("service", "api")}).WithGroup("req").WithGroup("db")
+			},
+			expected: []attribute.KeyValue{attribute.String("service", "api")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestProvider()
+			h := tt.handler(NewHandler("app", provider))
+
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+			r.AddAttrs(tt.attrs...)
+			require.NoError(t, h.Handle(context.Background(), r))
+
+			records := provider.scopeRecords("app")
+			require.Len(t, records, 1)
+			assert.Equal(t, tt.expected, recordAttrs(records[0]))
+		})
+	}
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	provider := newTestProvider()
+	provider.minLevel = log.SeverityWarn
+	h := NewHandler("app", provider)
+
+	assert.False(t, h.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, h.Enabled(context.Background(), slog.LevelWarn))
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		level    slog.Level
+		expected log.Severity
+	}{
+		{slog.LevelDebug, log.SeverityDebug},
+		{slog.LevelInfo, log.SeverityInfo},
+		{slog.LevelInfo + 1, log.SeverityInfo2},
+		{slog.LevelWarn, log.SeverityWarn},
+		{slog.LevelError, log.SeverityError},
+		{slogs.LevelTrace, log.SeverityTrace1},
+		{slogs.LevelFatal, log.SeverityFatal},
+		{slog.Level(-100), log.SeverityTrace1},
+		{slog.Level(100), log.SeverityFatal4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, Severity(tt.level))
+		})
+	}
+}
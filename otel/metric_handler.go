@@ -0,0 +1,153 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/rockcookies/go-slogs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var _ slog.Handler = (*MetricHandler)(nil)
+
+// Names of the counters of a MetricHandler.
+const (
+	RecordsCounter        = "log.records"
+	MessageRecordsCounter = "log.records.by_message"
+)
+
+// Attribute keys of the counters of a MetricHandler.
+const (
+	LevelAttrKey   = attribute.Key("level")
+	LoggerAttrKey  = attribute.Key("logger")
+	MessageAttrKey = attribute.Key("message")
+)
+
+// MetricOptions configures a MetricHandler. The zero value counts records of level Info and
+// above, per level and logger only.
+type MetricOptions struct {
+	// Level is the minimum level of the counted records. Defaults to slog.LevelInfo.
+	Level slog.Leveler
+
+	// MaxMessages, if positive, also counts records per level and message in the
+	// MessageRecordsCounter, for the first MaxMessages distinct messages; the records of other
+	// messages are counted under slogs.OtherValue, which bounds the cardinality of the counter.
+	MaxMessages int
+}
+
+// MetricHandler counts records into OpenTelemetry metrics instead of writing them, giving
+// "logs per level per component" metrics without separate instrumentation. It is meant to be
+// placed alongside the real sink in a slogs.MultiHandler.
+//
+// Every record increments the RecordsCounter counter with the attributes "level" and, for
+// named loggers, "logger", the logger name chain reported by slogs.LoggerName. Logger names are
+// expected to be a small, fixed set; messages are only counted if MetricOptions.MaxMessages
+// bounds their cardinality.
+//
+// Example:
+//
+//	metrics, err := otel.NewMetricHandler(provider.Meter("github.com/acme/app"), nil)
+//	if err != nil {
+//		return err
+//	}
+//	logger := slogs.New(slogs.NewHandler(slogs.MultiHandler(slog.NewJSONHandler(os.Stdout, nil), metrics)))
+type MetricHandler struct {
+	level    slog.Leveler
+	records  metric.Int64Counter
+	messages metric.Int64Counter // nil if messages are not counted
+	seen     *messageSet
+}
+
+// messageSet tracks the distinct messages counted by a MetricHandler.
+type messageSet struct {
+	limit int
+
+	mu       sync.Mutex
+	messages map[string]struct{}
+}
+
+// NewMetricHandler creates a MetricHandler whose counters are created with meter. opts may be nil.
+//
+// It returns an error if a counter cannot be created. Panics if meter is nil.
+func NewMetricHandler(meter metric.Meter, opts *MetricOptions) (*MetricHandler, error) {
+	if meter == nil {
+		panic("slogs: meter cannot be nil")
+	}
+	if opts == nil {
+		opts = &MetricOptions{}
+	}
+
+	h := &MetricHandler{level: opts.Level}
+	if h.level == nil {
+		h.level = slog.LevelInfo
+	}
+
+	var err error
+	h.records, err = meter.Int64Counter(RecordsCounter,
+		metric.WithDescription("Number of log records, per level and logger."),
+		metric.WithUnit("{record}"))
+	if err != nil {
+		return nil, fmt.Errorf("create %s counter: %w", RecordsCounter, err)
+	}
+
+	if opts.MaxMessages > 0 {
+		h.messages, err = meter.Int64Counter(MessageRecordsCounter,
+			metric.WithDescription("Number of log records, per level and message."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, fmt.Errorf("create %s counter: %w", MessageRecordsCounter, err)
+		}
+		h.seen = &messageSet{limit: opts.MaxMessages, messages: make(map[string]struct{}, opts.MaxMessages)}
+	}
+
+	return h, nil
+}
+
+// Enabled reports whether records at level are counted.
+func (h *MetricHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle increments the counters of r.
+func (h *MetricHandler) Handle(ctx context.Context, r slog.Record) error {
+	level := LevelAttrKey.String(r.Level.String())
+
+	attrs := []attribute.KeyValue{level}
+	if name := slogs.LoggerName(ctx); name != "" {
+		attrs = append(attrs, LoggerAttrKey.String(name))
+	}
+	h.records.Add(ctx, 1, metric.WithAttributes(attrs...))
+
+	if h.messages != nil {
+		h.messages.Add(ctx, 1, metric.WithAttributes(level, MessageAttrKey.String(h.seen.bound(r.Message))))
+	}
+	return nil
+}
+
+// WithAttrs returns h: attributes do not change how records are counted.
+func (h *MetricHandler) WithAttrs(_ []slog.Attr) slog.Handler {
+	return h
+}
+
+// WithGroup returns h: groups do not change how records are counted.
+func (h *MetricHandler) WithGroup(_ string) slog.Handler {
+	return h
+}
+
+// bound returns msg if it is one of the first limit distinct messages, and slogs.OtherValue otherwise.
+func (s *messageSet) bound(msg string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.messages[msg]; ok {
+		return msg
+	}
+	if len(s.messages) < s.limit {
+		s.messages[msg] = struct{}{}
+		return msg
+	}
+	return slogs.OtherValue
+}
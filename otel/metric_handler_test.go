@@ -0,0 +1,154 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/rockcookies/go-slogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// fakeMeter is a Meter recording the increments of its Int64Counters, per counter name and
+// encoded attribute set.
+type fakeMeter struct {
+	noop.Meter
+	err error
+
+	mu     sync.Mutex
+	counts map[string]map[string]int64
+}
+
+func newFakeMeter() *fakeMeter {
+	return &fakeMeter{counts: make(map[string]map[string]int64)}
+}
+
+func (m *fakeMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return fakeCounter{meter: m, name: name}, nil
+}
+
+func (m *fakeMeter) get(name string) map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[name]
+}
+
+type fakeCounter struct {
+	noop.Int64Counter
+	meter *fakeMeter
+	name  string
+}
+
+func (c fakeCounter) Add(_ context.Context, incr int64, opts ...metric.AddOption) {
+	attrs := metric.NewAddConfig(opts).Attributes()
+	key := attrs.Encoded(attribute.DefaultEncoder())
+
+	c.meter.mu.Lock()
+	defer c.meter.mu.Unlock()
+	if c.meter.counts[c.name] == nil {
+		c.meter.counts[c.name] = make(map[string]int64)
+	}
+	c.meter.counts[c.name][key] += incr
+}
+
+func TestNewMetricHandler_NilMeter(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: meter cannot be nil", func() {
+		NewMetricHandler(nil, nil)
+	})
+}
+
+func TestNewMetricHandler_Error(t *testing.T) {
+	meter := newFakeMeter()
+	meter.err = errors.New("invalid instrument")
+
+	h, err := NewMetricHandler(meter, nil)
+
+	assert.ErrorIs(t, err, meter.err)
+	assert.Nil(t, h)
+}
+
+func TestMetricHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         *MetricOptions
+		wantRecords  map[string]int64
+		wantMessages map[string]int64
+	}{
+		{
+			name: "default options",
+			wantRecords: map[string]int64{
+				"level=INFO":            2,
+				"level=INFO,logger=api": 1,
+				"level=ERROR":           2,
+			},
+		},
+		{
+			name: "level",
+			opts: &MetricOptions{Level: slog.LevelDebug},
+			wantRecords: map[string]int64{
+				"level=DEBUG":           1,
+				"level=INFO":            2,
+				"level=INFO,logger=api": 1,
+				"level=ERROR":           2,
+			},
+		},
+		{
+			name: "messages",
+			opts: &MetricOptions{MaxMessages: 2},
+			wantRecords: map[string]int64{
+				"level=INFO":            2,
+				"level=INFO,logger=api": 1,
+				"level=ERROR":           2,
+			},
+			wantMessages: map[string]int64{
+				"level=INFO,message=started":  1,
+				"level=INFO,message=(other)":  1, // "[api] started", prefixed with the logger name
+				"level=INFO,message=served":   1,
+				"level=ERROR,message=(other)": 1,
+				"level=ERROR,message=started": 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meter := newFakeMeter()
+			h, err := NewMetricHandler(meter, tt.opts)
+			require.NoError(t, err)
+			logger := slogs.New(slogs.NewHandler(h))
+
+			logger.Debug("debug")
+			logger.Info("started")
+			logger.With("a", 1).WithGroup("g").Info("served")
+			logger.Named("api").Info("started")
+			logger.Error("failed")
+			logger.Error("started")
+
+			assert.Equal(t, tt.wantRecords, meter.get(RecordsCounter))
+			assert.Equal(t, tt.wantMessages, meter.get(MessageRecordsCounter))
+		})
+	}
+}
+
+func TestMetricHandler_MultiHandler(t *testing.T) {
+	meter := newFakeMeter()
+	metrics, err := NewMetricHandler(meter, nil)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	logger := slogs.New(slogs.NewHandler(slogs.MultiHandler(slog.NewJSONHandler(&buf, nil), metrics)))
+
+	logger.Warn("slow")
+
+	assert.Contains(t, buf.String(), `"msg":"slow"`)
+	assert.Equal(t, map[string]int64{"level=WARN": 1}, meter.get(RecordsCounter))
+}
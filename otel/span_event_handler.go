@@ -0,0 +1,113 @@
+package otel
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ slog.Handler = (*SpanEventHandler)(nil)
+
+// SpanEventHandler records log records as events on the span active in their context.
+//
+// Records at or above the handler's level logged with a context holding a recording span are
+// added to it with span.AddEvent, so that warnings and errors show up on the span timeline next
+// to the operation they relate to. The event is named after the message and carries the record
+// time, the level under the "level" attribute and the record attributes. Records are also
+// passed to the next handler, unless WithoutLogs is used. Without a recording span, the
+// handler only forwards records to next.
+//
+// This attaches logs to the span itself; it is independent from Handler, which emits records
+// through the OpenTelemetry Logs API.
+//
+// Example:
+//
+//	h := otel.NewSpanEventHandler(slog.NewJSONHandler(os.Stdout, nil), slog.LevelWarn)
+//	logger := slogs.New(slogs.NewHandler(h))
+//	logger.WarnContext(ctx, "retrying", "attempt", 2) // also added to the span of ctx
+type SpanEventHandler struct {
+	next      slog.Handler
+	level     slog.Leveler
+	exclusive bool
+
+	// groups holds the attributes added with WithAttrs, per open group.
+	// groups[0] is the root and never has a name.
+	groups []group
+}
+
+// NewSpanEventHandler creates a SpanEventHandler that adds records at or above minLevel as
+// span events and passes all records to next. A nil minLevel means slog.LevelInfo.
+//
+// Panics if next is nil.
+func NewSpanEventHandler(next slog.Handler, minLevel slog.Leveler) *SpanEventHandler {
+	if next == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+	if minLevel == nil {
+		minLevel = slog.LevelInfo
+	}
+
+	return &SpanEventHandler{next: next, level: minLevel, groups: []group{{}}}
+}
+
+// WithoutLogs returns a SpanEventHandler that does not pass the records it adds to a span to
+// the next handler, recording them as span events instead of logging them.
+func (h *SpanEventHandler) WithoutLogs() *SpanEventHandler {
+	h2 := *h
+	h2.exclusive = true
+	return &h2
+}
+
+// Enabled reports whether the next handler handles records at level, or whether records at
+// level are added to the span of ctx.
+func (h *SpanEventHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.recordsEvent(ctx, level) || h.next.Enabled(ctx, level)
+}
+
+// Handle adds r to the span of ctx if it qualifies and passes it to the next handler.
+func (h *SpanEventHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.recordsEvent(ctx, r.Level) {
+		attrs := append([]attribute.KeyValue{attribute.String(slog.LevelKey, r.Level.String())}, recordAttributes(h.groups, r)...)
+		trace.SpanFromContext(ctx).AddEvent(r.Message, trace.WithTimestamp(r.Time), trace.WithAttributes(attrs...))
+		if h.exclusive {
+			return nil
+		}
+	}
+
+	if !h.next.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a SpanEventHandler whose records and span events include the given attributes.
+func (h *SpanEventHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	h2.groups = withAttrs(h.groups, attrs)
+	return &h2
+}
+
+// WithGroup returns a SpanEventHandler that nests subsequent attributes under name.
+func (h *SpanEventHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	h2.groups = append(slices.Clip(h.groups), group{name: name})
+	return &h2
+}
+
+// recordsEvent reports whether records at level logged with ctx are added to a span.
+func (h *SpanEventHandler) recordsEvent(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && trace.SpanFromContext(ctx).IsRecording()
+}
@@ -0,0 +1,150 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-slogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// testSpan is a recording span that keeps the events added to it.
+type testSpan struct {
+	noop.Span
+
+	mu     sync.Mutex
+	events []testEvent
+}
+
+type testEvent struct {
+	name  string
+	time  time.Time
+	attrs []attribute.KeyValue
+}
+
+func (s *testSpan) IsRecording() bool {
+	return true
+}
+
+func (s *testSpan) AddEvent(name string, options ...trace.EventOption) {
+	cfg := trace.NewEventConfig(options...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, testEvent{name: name, time: cfg.Timestamp(), attrs: cfg.Attributes()})
+}
+
+func (s *testSpan) getEvents() []testEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.events
+}
+
+func TestNewSpanEventHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewSpanEventHandler(nil, nil)
+	})
+}
+
+func TestSpanEventHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		exclusive  bool
+		span       bool
+		log        func(l *slogs.Logger, ctx context.Context)
+		wantEvents []string
+		wantLogs   int
+	}{
+		{
+			name: "adds records at or above level",
+			span: true,
+			log: func(l *slogs.Logger, ctx context.Context) {
+				l.InfoContext(ctx, "info")
+				l.WarnContext(ctx, "warn")
+				l.ErrorContext(ctx, "error")
+			},
+			wantEvents: []string{"warn", "error"},
+			wantLogs:   3,
+		},
+		{
+			name:      "without logs",
+			exclusive: true,
+			span:      true,
+			log: func(l *slogs.Logger, ctx context.Context) {
+				l.InfoContext(ctx, "info")
+				l.WarnContext(ctx, "warn")
+			},
+			wantEvents: []string{"warn"},
+			wantLogs:   1,
+		},
+		{
+			name:      "no active span",
+			exclusive: true,
+			log: func(l *slogs.Logger, ctx context.Context) {
+				l.WarnContext(ctx, "warn")
+			},
+			wantLogs: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewSpanEventHandler(slog.NewJSONHandler(buf, nil), slog.LevelWarn)
+			if tt.exclusive {
+				h = h.WithoutLogs()
+			}
+			span := &testSpan{}
+			ctx := context.Background()
+			if tt.span {
+				ctx = trace.ContextWithSpan(ctx, span)
+			}
+
+			tt.log(slogs.New(slogs.NewHandler(h)), ctx)
+
+			var names []string
+			for _, e := range span.getEvents() {
+				names = append(names, e.name)
+			}
+			assert.Equal(t, tt.wantEvents, names)
+			assert.Equal(t, tt.wantLogs, bytes.Count(buf.Bytes(), []byte("\n")))
+		})
+	}
+}
+
+func TestSpanEventHandler_Attributes(t *testing.T) {
+	span := &testSpan{}
+	ctx := trace.ContextWithSpan(context.Background(), span)
+	h := NewSpanEventHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil), nil)
+	logger := slog.New(h).With("service", "api").WithGroup("req")
+
+	logger.InfoContext(ctx, "served", "status", 200)
+
+	events := span.getEvents()
+	require.Len(t, events, 1)
+	assert.False(t, events[0].time.IsZero())
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("level", "INFO"),
+		attribute.String("service", "api"),
+		attribute.Map("req", attribute.Int64("status", 200)),
+	}, events[0].attrs)
+}
+
+func TestSpanEventHandler_Enabled(t *testing.T) {
+	next := slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelError})
+	h := NewSpanEventHandler(next, slog.LevelWarn)
+	withSpan := trace.ContextWithSpan(context.Background(), &testSpan{})
+
+	assert.True(t, h.Enabled(withSpan, slog.LevelWarn))
+	assert.False(t, h.Enabled(withSpan, slog.LevelInfo))
+	assert.False(t, h.Enabled(context.Background(), slog.LevelWarn))
+	assert.True(t, h.Enabled(context.Background(), slog.LevelError))
+}
@@ -0,0 +1,94 @@
+package otel
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ slog.Handler = (*SpanNameHandler)(nil)
+
+// SpanNameKey is the default key of the attribute added by SpanNameHandler.
+const SpanNameKey = "span_name"
+
+// SpanNameHandler adds the name of the active span, e.g. "GET /users/:id", as an attribute, to
+// give the trace identifiers a readable context.
+//
+// The name is not part of the span context: it is read from the span stored in the record
+// context, and only spans exposing a Name() string method, such as the spans of the
+// OpenTelemetry SDK, provide it. Records logged without such a span, or whose span has an
+// empty name, are passed through unchanged.
+//
+// The attribute is added at the end of the record, nested under the groups open on the
+// handler, like the members added by BaggageHandler.
+//
+// Example:
+//
+//	h := otel.NewSpanNameHandler(slog.NewJSONHandler(os.Stdout, nil), "")
+//	logger := slogs.New(slogs.NewHandler(h))
+//	logger.InfoContext(ctx, "served") // {"msg":"served","span_name":"GET /users/:id"}
+type SpanNameHandler struct {
+	next slog.Handler
+	key  string
+}
+
+// NewSpanNameHandler creates a SpanNameHandler adding the span name under key, or SpanNameKey
+// if key is empty, and passing records to next.
+//
+// Panics if next is nil.
+func NewSpanNameHandler(next slog.Handler, key string) *SpanNameHandler {
+	if next == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+	if key == "" {
+		key = SpanNameKey
+	}
+
+	return &SpanNameHandler{next: next, key: key}
+}
+
+// Enabled reports whether the next handler handles records at level.
+func (h *SpanNameHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the name of the span of ctx to r, if known, and passes it to the next handler.
+func (h *SpanNameHandler) Handle(ctx context.Context, r slog.Record) error {
+	if name := SpanName(ctx); name != "" {
+		r = r.Clone()
+		r.AddAttrs(slog.String(h.key, name))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a SpanNameHandler whose next handler has the given attributes.
+func (h *SpanNameHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a SpanNameHandler whose next handler has the given group.
+func (h *SpanNameHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+// SpanName returns the name of the span of ctx, or an empty string if ctx holds no span or its
+// span does not expose its name.
+func SpanName(ctx context.Context) string {
+	if named, ok := trace.SpanFromContext(ctx).(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/rockcookies/go-slogs"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// namedSpan is a span exposing its name like the spans of the OpenTelemetry SDK.
+type namedSpan struct {
+	noop.Span
+	name string
+}
+
+func (s namedSpan) Name() string { return s.name }
+
+func TestNewSpanNameHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewSpanNameHandler(nil, "")
+	})
+}
+
+func TestSpanNameHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "default key",
+			ctx:  trace.ContextWithSpan(context.Background(), namedSpan{name: "GET /users/:id"}),
+			want: `"a":1,"span_name":"GET /users/:id"}`,
+		},
+		{
+			name: "custom key",
+			key:  "op",
+			ctx:  trace.ContextWithSpan(context.Background(), namedSpan{name: "GET /users/:id"}),
+			want: `"a":1,"op":"GET /users/:id"}`,
+		},
+		{
+			name: "unnamed span",
+			ctx:  trace.ContextWithSpan(context.Background(), noop.Span{}),
+			want: `"a":1}`,
+		},
+		{
+			name: "empty name",
+			ctx:  trace.ContextWithSpan(context.Background(), namedSpan{}),
+			want: `"a":1}`,
+		},
+		{
+			name: "no span",
+			ctx:  context.Background(),
+			want: `"a":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewSpanNameHandler(slog.NewJSONHandler(buf, nil), tt.key)
+
+			slogs.New(slogs.NewHandler(h)).InfoContext(tt.ctx, "m", "a", 1)
+
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package otel
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rockcookies/go-slogs"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceSampledFilter returns a filter keeping records only for sampled traces, so that an
+// expensive sink retains the logs of the requests whose traces are kept.
+//
+// Records at or above minLevel are always kept, so errors of unsampled requests are not lost.
+// Records below it are kept if the span context of their context is sampled. Records logged
+// without a valid span context are kept if allowNoSpan is true. A nil minLevel means
+// slog.LevelError.
+//
+// Example:
+//
+//	expensive := slogs.NewHandler(remote).
+//		WithFilterAt(slogs.FilterBeforeHandle, otel.TraceSampledFilter(slog.LevelError, true))
+//	logger := slogs.New(slogs.NewHandler(slogs.MultiHandler(local, expensive)))
+func TraceSampledFilter(minLevel slog.Leveler, allowNoSpan bool) slogs.FilterFunc {
+	if minLevel == nil {
+		minLevel = slog.LevelError
+	}
+
+	return func(ctx context.Context, level slog.Level, _ string, _ []slog.Attr) bool {
+		if level >= minLevel.Level() {
+			return true
+		}
+
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return allowNoSpan
+		}
+		return sc.IsSampled()
+	}
+}
+
+// TraceID returns the hexadecimal trace ID of the span context of ctx, or an empty string if
+// ctx holds no valid span context.
+//
+// It can be passed to slogs.NewTraceSamplingHandler so that all the records of a trace share
+// one sampling decision.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.TraceID().IsValid() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
@@ -0,0 +1,82 @@
+package otel
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/rockcookies/go-slogs"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanContext returns a context holding a valid span context with the given sampling decision.
+func spanContext(sampled bool) context.Context {
+	cfg := trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	}
+	if sampled {
+		cfg.TraceFlags = trace.FlagsSampled
+	}
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(cfg))
+}
+
+func TestTraceSampledFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		minLevel    slog.Leveler
+		allowNoSpan bool
+		ctx         context.Context
+		level       slog.Level
+		want        bool
+	}{
+		{name: "sampled", minLevel: slog.LevelError, ctx: spanContext(true), level: slog.LevelInfo, want: true},
+		{name: "unsampled", minLevel: slog.LevelError, ctx: spanContext(false), level: slog.LevelWarn, want: false},
+		{name: "unsampled at min level", minLevel: slog.LevelError, ctx: spanContext(false), level: slog.LevelError, want: true},
+		{name: "nil min level means error", minLevel: nil, ctx: spanContext(false), level: slog.LevelError, want: true},
+		{name: "no span allowed", minLevel: slog.LevelError, allowNoSpan: true, ctx: context.Background(), level: slog.LevelDebug, want: true},
+		{name: "no span denied", minLevel: slog.LevelError, allowNoSpan: false, ctx: context.Background(), level: slog.LevelDebug, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := TraceSampledFilter(tt.minLevel, tt.allowNoSpan)
+			assert.Equal(t, tt.want, filter(tt.ctx, tt.level, "m", nil))
+		})
+	}
+}
+
+func TestTraceSampledFilter_Handler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := slogs.NewHandler(slog.NewJSONHandler(buf, nil)).
+		WithFilterAt(slogs.FilterBeforeHandle, TraceSampledFilter(slog.LevelError, true))
+	logger := slogs.New(h)
+
+	logger.InfoContext(spanContext(false), "unsampled info")
+	logger.ErrorContext(spanContext(false), "unsampled error")
+	logger.InfoContext(spanContext(true), "sampled info")
+
+	assert.NotContains(t, buf.String(), "unsampled info")
+	assert.Contains(t, buf.String(), "unsampled error")
+	assert.Contains(t, buf.String(), "sampled info")
+}
+
+func TestTraceID(t *testing.T) {
+	assert.Equal(t, "01000000000000000000000000000000", TraceID(spanContext(true)))
+	assert.Empty(t, TraceID(context.Background()))
+}
+
+func TestTraceID_TraceSamplingHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slogs.New(slogs.NewHandler(slogs.NewTraceSamplingHandler(slog.NewJSONHandler(buf, nil), TraceID, 0, nil)))
+
+	logger.InfoContext(spanContext(true), "dropped")
+	logger.ErrorContext(spanContext(true), "kept error")
+	logger.InfoContext(context.Background(), "kept without trace")
+
+	assert.NotContains(t, buf.String(), "dropped")
+	assert.Contains(t, buf.String(), "kept error")
+	assert.Contains(t, buf.String(), "kept without trace")
+}
@@ -0,0 +1,56 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"runtime/pprof"
+	"sort"
+	"time"
+)
+
+// WithPprofLabels returns a new Handler that adds the pprof labels of the record context as
+// attributes, to tie logged operations to the samples of CPU and goroutine profiles.
+//
+// Labels are read from the context with pprof.Label, so they must have been set on it with
+// pprof.WithLabels or pprof.Do. Only the labels named in keys are added, in that order; with no
+// keys, every label is added, sorted by key. Labels are added at the root level of the record,
+// ahead of any attributes added via Prepend. Records whose context has no matching label are
+// left unchanged.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithPprofLabels("endpoint")
+//	pprof.Do(ctx, pprof.Labels("endpoint", "/users"), func(ctx context.Context) {
+//		logger.InfoContext(ctx, "listing users") // endpoint=/users
+//	})
+func (h *Handler) WithPprofLabels(keys ...string) *Handler {
+	return h.use(func(ctx context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		found := pprofLabels(ctx, keys)
+		if len(found) == 0 {
+			return rm, attrs
+		}
+		return rm, append(found, attrs...)
+	})
+}
+
+// pprofLabels returns the pprof labels of ctx named in keys, or all of them if keys is empty.
+func pprofLabels(ctx context.Context, keys []string) []slog.Attr {
+	var found []slog.Attr
+	if len(keys) > 0 {
+		for _, key := range keys {
+			if v, ok := pprof.Label(ctx, key); ok {
+				found = append(found, slog.String(key, v))
+			}
+		}
+		return found
+	}
+
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		found = append(found, slog.String(key, value))
+		return true
+	})
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Key < found[j].Key
+	})
+	return found
+}
@@ -0,0 +1,62 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_WithPprofLabels(t *testing.T) {
+	labeled := pprof.WithLabels(context.Background(), pprof.Labels("endpoint", "/users", "tenant", "acme", "az", "eu-1"))
+
+	tests := []struct {
+		name string
+		keys []string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "selected labels in key order",
+			keys: []string{"tenant", "endpoint", "missing"},
+			ctx:  labeled,
+			want: "level=INFO msg=m tenant=acme endpoint=/users k=v\n",
+		},
+		{
+			name: "all labels sorted",
+			ctx:  labeled,
+			want: "level=INFO msg=m az=eu-1 endpoint=/users tenant=acme k=v\n",
+		},
+		{
+			name: "no labels",
+			keys: []string{"endpoint"},
+			ctx:  context.Background(),
+			want: "level=INFO msg=m k=v\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithPprofLabels(tt.keys...)
+
+			New(h).InfoContext(tt.ctx, "m", "k", "v")
+
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestHandler_WithPprofLabels_Do(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := New(NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithPprofLabels("worker"))
+
+	pprof.Do(context.Background(), pprof.Labels("worker", "7"), func(ctx context.Context) {
+		logger.InfoContext(ctx, "job done")
+	})
+
+	assert.Equal(t, "level=INFO msg=\"job done\" worker=7\n", buf.String())
+}
@@ -0,0 +1,53 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// maxResolveDepth is the group nesting depth past which WithPreResolve stops resolving values.
+const maxResolveDepth = 64
+
+// truncatedValue replaces the values nested deeper than maxResolveDepth, which usually come from
+// a LogValuer returning a group that contains itself.
+var truncatedValue = slog.StringValue("!MAXDEPTH")
+
+// WithPreResolve returns a new Handler that, if enabled is true, fully resolves the attributes
+// of each record before passing it on: every slog.LogValuer is replaced by its value, including
+// in groups and in the groups returned by LogValue, recursively.
+//
+// Sinks then all see the same concrete values, even those that do not resolve nested values,
+// and LogValue runs once per record rather than once per sink of a MultiHandler. Options added
+// after WithPreResolve, such as WithReplaceAttr, also see the resolved values.
+//
+// Values nested more than 64 groups deep, as produced by a LogValuer that returns itself within
+// a group, are replaced by the string "!MAXDEPTH". Resolving costs an allocation per group, so
+// it is disabled by default.
+func (h *Handler) WithPreResolve(enabled bool) *Handler {
+	if !enabled {
+		return h
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, resolveAttrs(attrs, 0)
+	})
+}
+
+// resolveAttrs returns attrs with their values resolved, recursing into groups up to
+// maxResolveDepth.
+func resolveAttrs(attrs []slog.Attr, depth int) []slog.Attr {
+	out := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Value.Kind() == slog.KindGroup {
+			if depth >= maxResolveDepth {
+				a.Value = truncatedValue
+			} else {
+				a.Value = slog.GroupValue(resolveAttrs(a.Value.Group(), depth+1)...)
+			}
+		}
+		out[i] = a
+	}
+	return out
+}
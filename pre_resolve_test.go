@@ -0,0 +1,95 @@
+package slogs
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userValuer logs a user as a group holding a nested LogValuer.
+type userValuer struct{ name string }
+
+func (u userValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", u.name), slog.Any("token", tokenValuer{}))
+}
+
+// tokenValuer is a LogValuer redacting a token.
+type tokenValuer struct{}
+
+func (tokenValuer) LogValue() slog.Value { return slog.StringValue("[REDACTED]") }
+
+// loopValuer returns a group containing itself.
+type loopValuer struct{}
+
+func (loopValuer) LogValue() slog.Value { return slog.GroupValue(slog.Any("self", loopValuer{})) }
+
+func TestHandler_WithPreResolve(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewHandler(next).WithPreResolve(true)
+
+	slog.New(h).WithGroup("req").Info("m", "user", userValuer{name: "alice"})
+
+	records := next.getRecords()
+	require.Len(t, records, 1)
+
+	var attrs []slog.Attr
+	records[0].Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	want := slog.Group("req", slog.Group("user", slog.String("name", "alice"), slog.String("token", "[REDACTED]")))
+	require.Len(t, attrs, 1)
+	assert.True(t, want.Equal(attrs[0]), "nested LogValuers are resolved before the sink: %v", attrs[0])
+}
+
+func TestHandler_WithPreResolve_Transforms(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var kinds []slog.Kind
+	h := NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+		WithPreResolve(true).
+		WithReplaceAttr(func(_ []string, a slog.Attr) slog.Attr {
+			kinds = append(kinds, a.Value.Kind())
+			return a
+		})
+
+	slog.New(h).Info("m", "token", tokenValuer{})
+
+	assert.Equal(t, []slog.Kind{slog.KindString}, kinds)
+	assert.JSONEq(t, `{"level":"INFO","msg":"m","token":"[REDACTED]"}`, buf.String())
+}
+
+func TestHandler_WithPreResolve_Cycle(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewTextHandler(buf, nil)).WithPreResolve(true)
+
+	slog.New(h).Info("m", "loop", loopValuer{})
+
+	assert.Contains(t, buf.String(), strings.Repeat("self.", maxResolveDepth-1)+"self=!MAXDEPTH")
+}
+
+func TestHandler_WithPreResolve_Disabled(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+	assert.Same(t, h, h.WithPreResolve(false))
+}
+
+func BenchmarkHandler_WithPreResolve(b *testing.B) {
+	for _, enabled := range []bool{false, true} {
+		name := "disabled"
+		if enabled {
+			name = "enabled"
+		}
+		b.Run(name, func(b *testing.B) {
+			logger := slog.New(NewHandler(slog.NewJSONHandler(io.Discard, nil)).WithPreResolve(enabled))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				logger.Info("m", "user", userValuer{name: "alice"}, "n", i)
+			}
+		})
+	}
+}
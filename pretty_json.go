@@ -0,0 +1,55 @@
+package slogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// NewPrettyJSONHandler creates a handler writing records to w as indented multi-line JSON,
+// for reading logs locally. Production logs should use the compact single-line output of
+// slog.JSONHandler, which this handler produces before indenting it.
+//
+// Each level of nesting is indented with indent, e.g. two spaces. opts is passed to the slog
+// handler and may be nil. Records are separated by a newline.
+//
+// Example:
+//
+//	format := slogs.FormatJSON
+//	if dev {
+//		format = slogs.FormatPrettyJSON
+//	}
+//	logger := slogs.NewWithFormat(format, os.Stdout, nil)
+func NewPrettyJSONHandler(w io.Writer, indent string, opts *slog.HandlerOptions) slog.Handler {
+	return slog.NewJSONHandler(&prettyJSONWriter{w: w, indent: indent}, opts)
+}
+
+// prettyJSONWriter indents the lines written by a slog.JSONHandler, which writes each record
+// with a single Write call.
+type prettyJSONWriter struct {
+	w      io.Writer
+	indent string
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write writes the indented form of the JSON line p to the underlying writer. Lines that are
+// not valid JSON are written unchanged.
+func (pw *prettyJSONWriter) Write(p []byte) (int, error) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	pw.buf.Reset()
+	if err := json.Indent(&pw.buf, bytes.TrimRight(p, "\n"), "", pw.indent); err != nil {
+		return pw.w.Write(p)
+	}
+	pw.buf.WriteByte('\n')
+
+	if _, err := pw.w.Write(pw.buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
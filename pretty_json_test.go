@@ -0,0 +1,63 @@
+package slogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrettyJSONHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		indent string
+		log    func(l *slog.Logger)
+		want   string
+	}{
+		{
+			name:   "two spaces",
+			indent: "  ",
+			log:    func(l *slog.Logger) { l.Info("hello", "user", "alice") },
+			want:   "{\n  \"level\": \"INFO\",\n  \"msg\": \"hello\",\n  \"user\": \"alice\"\n}\n",
+		},
+		{
+			name:   "nested group with tabs",
+			indent: "\t",
+			log:    func(l *slog.Logger) { l.WithGroup("http").Info("req", "status", 200) },
+			want:   "{\n\t\"level\": \"INFO\",\n\t\"msg\": \"req\",\n\t\"http\": {\n\t\t\"status\": 200\n\t}\n}\n",
+		},
+		{
+			name:   "records are separated by newlines",
+			indent: " ",
+			log: func(l *slog.Logger) {
+				l.Info("a")
+				l.Info("b")
+			},
+			want: "{\n \"level\": \"INFO\",\n \"msg\": \"a\"\n}\n{\n \"level\": \"INFO\",\n \"msg\": \"b\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tt.log(slog.New(NewPrettyJSONHandler(buf, tt.indent, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestNewPrettyJSONHandler_ValidJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewWithFormat(FormatPrettyJSON, buf, nil)
+
+	logger.Info("hello", "n", 1, slog.Group("g", "k", "v"))
+
+	assert.Greater(t, strings.Count(buf.String(), "\n"), 1)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, map[string]any{"k": "v"}, got["g"])
+}
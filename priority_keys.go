@@ -0,0 +1,63 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithPriorityKeys returns a new Handler that moves the top-level attributes with the given keys
+// to the front of the record, in the order of keys, so that they are easy to spot in console
+// output. The other attributes keep their relative order after them.
+//
+// Only attributes at the root level are moved; keys missing from a record are skipped, and
+// several attributes with the same priority key stay together in their original order. With no
+// keys, h is returned.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(slog.NewTextHandler(os.Stdout, nil)).WithPriorityKeys([]string{"request_id", "error"})
+//	logger := slog.New(handler)
+//	logger.Error("failed", "path", "/users", "error", err, "request_id", "r-1")
+//	// level=ERROR msg=failed request_id=r-1 error="..." path=/users
+func (h *Handler) WithPriorityKeys(keys []string) *Handler {
+	if len(keys) == 0 {
+		return h
+	}
+
+	rank := make(map[string]int, len(keys))
+	for i, key := range keys {
+		if _, ok := rank[key]; !ok {
+			rank[key] = i
+		}
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, prioritizeAttrs(attrs, rank, len(keys))
+	})
+}
+
+// prioritizeAttrs returns attrs with the attributes whose key is in rank first, by rank, and
+// the others after them, keeping the relative order of attributes of the same rank.
+func prioritizeAttrs(attrs []slog.Attr, rank map[string]int, n int) []slog.Attr {
+	buckets := make([][]slog.Attr, n)
+	rest := make([]slog.Attr, 0, len(attrs))
+	found := false
+	for _, a := range attrs {
+		if i, ok := rank[a.Key]; ok {
+			buckets[i] = append(buckets[i], a)
+			found = true
+		} else {
+			rest = append(rest, a)
+		}
+	}
+	if !found {
+		return attrs
+	}
+
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, bucket := range buckets {
+		out = append(out, bucket...)
+	}
+	return append(out, rest...)
+}
@@ -0,0 +1,84 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_WithPriorityKeys(t *testing.T) {
+	noTime := &slog.HandlerOptions{ReplaceAttr: dropTime}
+
+	tests := []struct {
+		name     string
+		keys     []string
+		log      func(l *slog.Logger)
+		expected string
+	}{
+		{
+			name:     "all keys present",
+			keys:     []string{"request_id", "error"},
+			log:      func(l *slog.Logger) { l.Info("m", "path", "/", "error", "boom", "n", 1, "request_id", "r-1") },
+			expected: "level=INFO msg=m request_id=r-1 error=boom path=/ n=1\n",
+		},
+		{
+			name:     "partial match",
+			keys:     []string{"request_id", "error"},
+			log:      func(l *slog.Logger) { l.Info("m", "path", "/", "error", "boom") },
+			expected: "level=INFO msg=m error=boom path=/\n",
+		},
+		{
+			name:     "no match",
+			keys:     []string{"request_id"},
+			log:      func(l *slog.Logger) { l.Info("m", "b", 1, "a", 2) },
+			expected: "level=INFO msg=m b=1 a=2\n",
+		},
+		{
+			name:     "attributes from With",
+			keys:     []string{"request_id"},
+			log:      func(l *slog.Logger) { l.With("request_id", "r-1").Info("m", "a", 1) },
+			expected: "level=INFO msg=m request_id=r-1 a=1\n",
+		},
+		{
+			name:     "grouped keys are not moved",
+			keys:     []string{"request_id"},
+			log:      func(l *slog.Logger) { l.Info("m", "a", 1, slog.Group("g", "request_id", "r-1")) },
+			expected: "level=INFO msg=m a=1 g.request_id=r-1\n",
+		},
+		{
+			name:     "repeated key",
+			keys:     []string{"request_id"},
+			log:      func(l *slog.Logger) { l.Info("m", "a", 1, "request_id", "r-1", "request_id", "r-2") },
+			expected: "level=INFO msg=m request_id=r-1 request_id=r-2 a=1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewTextHandler(buf, noTime)).WithPriorityKeys(tt.keys)
+
+			tt.log(slog.New(h))
+
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestHandler_WithPriorityKeys_Context(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithPriorityKeys([]string{"request_id"})
+	ctx := Append(context.Background(), "request_id", "r-1")
+
+	slog.New(h).InfoContext(ctx, "m", "a", 1)
+
+	assert.Equal(t, "level=INFO msg=m request_id=r-1 a=1\n", buf.String())
+}
+
+func TestHandler_WithPriorityKeys_Empty(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+	assert.Same(t, h, h.WithPriorityKeys(nil))
+}
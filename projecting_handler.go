@@ -0,0 +1,249 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+var _ slog.Handler = (*projectingHandler)(nil)
+
+// Projection describes the shape of the records passed to a sink of a ProjectingMultiHandler,
+// e.g. a trimmed record for a cheap index store. It applies to the top-level attributes of the
+// records and of WithAttrs calls, in the order they are added; a group added with WithGroup
+// counts as a single top-level attribute named after the group.
+type Projection struct {
+	// Allow, if not empty, keeps only the attributes with these keys.
+	Allow []string
+	// Deny drops the attributes with these keys.
+	Deny []string
+	// MaxAttrs, if > 0, keeps at most this many attributes, the first ones that are allowed.
+	MaxAttrs int
+}
+
+// ProjectedSink is a sink of a ProjectingMultiHandler.
+type ProjectedSink struct {
+	// Handler receives the projected records.
+	Handler slog.Handler
+	// Projection is the shape of the records passed to Handler. A nil Projection passes
+	// records unchanged.
+	Projection *Projection
+}
+
+// projectingHandler is the handler returned by ProjectingMultiHandler.
+type projectingHandler struct {
+	groups []projectionGroup
+}
+
+// projectionGroup is a set of sinks sharing the same projection, for which the projected
+// attributes are computed once.
+type projectionGroup struct {
+	projection *compiledProjection // nil if records are passed unchanged
+	state      projectionState
+	handlers   []slog.Handler
+}
+
+// compiledProjection is a Projection with its keys as sets.
+type compiledProjection struct {
+	allow    map[string]struct{}
+	deny     map[string]struct{}
+	maxAttrs int
+}
+
+// projectionState tracks the WithAttrs and WithGroup calls a projection has been applied to.
+type projectionState struct {
+	used     int  // number of top-level attributes kept so far
+	grouped  bool // a kept group was opened: later attributes are in it and kept as they are
+	dropping bool // a dropped group was opened: later attributes are in it and dropped
+}
+
+// ProjectingMultiHandler creates a handler that broadcasts records to all sinks like
+// MultiHandler, each sink receiving the record with its own Projection applied, so that a
+// single logger can feed both a compact and a verbose store.
+//
+// Sinks sharing the same *Projection share the projected attributes, which are computed once
+// per record. Sinks with a nil Handler are ignored. Errors from all sinks are joined with
+// errors.Join.
+//
+// Example:
+//
+//	compact := &slogs.Projection{Allow: []string{"request_id", "status"}, MaxAttrs: 10}
+//	handler := slogs.ProjectingMultiHandler(
+//		slogs.ProjectedSink{Handler: indexHandler, Projection: compact},
+//		slogs.ProjectedSink{Handler: coldStorageHandler},
+//	)
+func ProjectingMultiHandler(sinks ...ProjectedSink) slog.Handler {
+	h := &projectingHandler{}
+	index := make(map[*Projection]int)
+	for _, sink := range sinks {
+		if sink.Handler == nil {
+			continue
+		}
+		i, ok := index[sink.Projection]
+		if !ok {
+			i = len(h.groups)
+			index[sink.Projection] = i
+			h.groups = append(h.groups, projectionGroup{projection: compileProjection(sink.Projection)})
+		}
+		h.groups[i].handlers = append(h.groups[i].handlers, sink.Handler)
+	}
+	return h
+}
+
+// compileProjection returns p with its keys as sets, or nil if p is nil.
+func compileProjection(p *Projection) *compiledProjection {
+	if p == nil {
+		return nil
+	}
+
+	c := &compiledProjection{maxAttrs: p.MaxAttrs}
+	if len(p.Allow) > 0 {
+		c.allow = make(map[string]struct{}, len(p.Allow))
+		for _, key := range p.Allow {
+			c.allow[key] = struct{}{}
+		}
+	}
+	c.deny = make(map[string]struct{}, len(p.Deny))
+	for _, key := range p.Deny {
+		c.deny[key] = struct{}{}
+	}
+	return c
+}
+
+// Enabled reports whether any sink is enabled at the specified level.
+func (h *projectingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, g := range h.groups {
+		for _, next := range g.handlers {
+			if next.Enabled(ctx, level) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Handle passes a copy of r with the projection of their group applied to all enabled sinks.
+func (h *projectingHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, g := range h.groups {
+		var projected *slog.Record
+		for _, next := range g.handlers {
+			if !next.Enabled(ctx, r.Level) {
+				continue
+			}
+			if projected == nil {
+				projected = g.project(r)
+			}
+			if err := next.Handle(ctx, projected.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a projectingHandler whose sinks have the attributes of attrs kept by
+// their projection.
+func (h *projectingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := &projectingHandler{groups: make([]projectionGroup, len(h.groups))}
+	for i, g := range h.groups {
+		state := g.state
+		kept := g.projection.apply(&state, attrs)
+		h2.groups[i] = g.derive(state, func(next slog.Handler) slog.Handler {
+			if len(kept) == 0 {
+				return next
+			}
+			return next.WithAttrs(kept)
+		})
+	}
+	return h2
+}
+
+// WithGroup returns a projectingHandler whose sinks have the given group, if their projection
+// keeps it.
+func (h *projectingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := &projectingHandler{groups: make([]projectionGroup, len(h.groups))}
+	for i, g := range h.groups {
+		state := g.state
+		// The group is kept or dropped as a whole, like a top-level attribute named after it.
+		if len(g.projection.apply(&state, []slog.Attr{{Key: name}})) == 0 {
+			state.dropping = true
+			h2.groups[i] = g.derive(state, func(next slog.Handler) slog.Handler { return next })
+			continue
+		}
+		state.grouped = true
+		h2.groups[i] = g.derive(state, func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+	}
+	return h2
+}
+
+// derive returns a copy of g with the given state whose handlers are derived with fn.
+func (g projectionGroup) derive(state projectionState, fn func(next slog.Handler) slog.Handler) projectionGroup {
+	handlers := make([]slog.Handler, len(g.handlers))
+	for i, next := range g.handlers {
+		handlers[i] = fn(next)
+	}
+	return projectionGroup{projection: g.projection, state: state, handlers: handlers}
+}
+
+// project returns a copy of r holding the attributes kept by the projection of g.
+func (g projectionGroup) project(r slog.Record) *slog.Record {
+	if g.projection == nil {
+		return &r
+	}
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	state := g.state
+	projected := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	projected.AddAttrs(g.projection.apply(&state, attrs)...)
+	return &projected
+}
+
+// apply returns the attributes of attrs kept by p, given and updating the state of the calls
+// applied before. A nil p keeps all attributes.
+func (p *compiledProjection) apply(state *projectionState, attrs []slog.Attr) []slog.Attr {
+	if p == nil || state.grouped {
+		return attrs
+	}
+	if state.dropping {
+		return nil
+	}
+
+	kept := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if p.maxAttrs > 0 && state.used >= p.maxAttrs {
+			break
+		}
+		if _, ok := p.deny[a.Key]; ok {
+			continue
+		}
+		if _, ok := p.allow[a.Key]; p.allow != nil && !ok {
+			continue
+		}
+		kept = append(kept, a)
+		state.used++
+	}
+	return kept
+}
+
+// unwrapAll returns the handlers h passes records to, see handlerSinks.
+func (h *projectingHandler) unwrapAll() []slog.Handler {
+	var handlers []slog.Handler
+	for _, g := range h.groups {
+		handlers = append(handlers, g.handlers...)
+	}
+	return handlers
+}
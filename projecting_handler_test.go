@@ -0,0 +1,164 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectingMultiHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		projection *Projection
+		log        func(l *slog.Logger)
+		expected   string
+	}{
+		{
+			name:       "no projection",
+			projection: nil,
+			log:        func(l *slog.Logger) { l.With("a", 1).Info("m", "b", 2, "c", 3) },
+			expected:   `{"level":"INFO","msg":"m","a":1,"b":2,"c":3}`,
+		},
+		{
+			name:       "allow",
+			projection: &Projection{Allow: []string{"a", "c"}},
+			log:        func(l *slog.Logger) { l.With("a", 1, "x", 0).Info("m", "b", 2, "c", 3) },
+			expected:   `{"level":"INFO","msg":"m","a":1,"c":3}`,
+		},
+		{
+			name:       "deny",
+			projection: &Projection{Deny: []string{"a", "c"}},
+			log:        func(l *slog.Logger) { l.With("a", 1).Info("m", "b", 2, "c", 3) },
+			expected:   `{"level":"INFO","msg":"m","b":2}`,
+		},
+		{
+			name:       "deny takes precedence over allow",
+			projection: &Projection{Allow: []string{"a", "b"}, Deny: []string{"a"}},
+			log:        func(l *slog.Logger) { l.Info("m", "a", 1, "b", 2) },
+			expected:   `{"level":"INFO","msg":"m","b":2}`,
+		},
+		{
+			name:       "limit across WithAttrs and record",
+			projection: &Projection{MaxAttrs: 2},
+			log:        func(l *slog.Logger) { l.With("a", 1).Info("m", "b", 2, "c", 3) },
+			expected:   `{"level":"INFO","msg":"m","a":1,"b":2}`,
+		},
+		{
+			name:       "limit counts kept attributes only",
+			projection: &Projection{Deny: []string{"a"}, MaxAttrs: 1},
+			log:        func(l *slog.Logger) { l.Info("m", "a", 1, "b", 2, "c", 3) },
+			expected:   `{"level":"INFO","msg":"m","b":2}`,
+		},
+		{
+			name:       "kept group keeps its attributes",
+			projection: &Projection{Allow: []string{"g"}},
+			log:        func(l *slog.Logger) { l.With("a", 1).WithGroup("g").With("b", 2).Info("m", "c", 3) },
+			expected:   `{"level":"INFO","msg":"m","g":{"b":2,"c":3}}`,
+		},
+		{
+			name:       "dropped group drops its attributes",
+			projection: &Projection{Deny: []string{"g"}},
+			log:        func(l *slog.Logger) { l.With("a", 1).WithGroup("g").With("b", 2).Info("m", "c", 3) },
+			expected:   `{"level":"INFO","msg":"m","a":1}`,
+		},
+		{
+			name:       "group past the limit",
+			projection: &Projection{MaxAttrs: 1},
+			log:        func(l *slog.Logger) { l.With("a", 1).WithGroup("g").Info("m", "c", 3) },
+			expected:   `{"level":"INFO","msg":"m","a":1}`,
+		},
+		{
+			name:       "record group",
+			projection: &Projection{Allow: []string{"g"}},
+			log:        func(l *slog.Logger) { l.Info("m", "a", 1, slog.Group("g", "b", 2)) },
+			expected:   `{"level":"INFO","msg":"m","g":{"b":2}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var projected, full bytes.Buffer
+			h := ProjectingMultiHandler(
+				ProjectedSink{Handler: slog.NewJSONHandler(&projected, &slog.HandlerOptions{ReplaceAttr: dropTime}), Projection: tt.projection},
+				ProjectedSink{Handler: slog.NewJSONHandler(&full, &slog.HandlerOptions{ReplaceAttr: dropTime})},
+			)
+
+			tt.log(slog.New(h))
+
+			assert.Equal(t, tt.expected+"\n", projected.String())
+			assert.NotEmpty(t, full.String())
+		})
+	}
+}
+
+func TestProjectingMultiHandler_SharedProjection(t *testing.T) {
+	compact := &Projection{Allow: []string{"a"}}
+	h1 := newTestHandler(true)
+	h1.mutate = func(r *slog.Record) { r.AddAttrs(slog.String("mutated", "yes")) }
+	h2 := newTestHandler(true)
+	disabled := newTestHandler(false)
+	full := newTestHandler(true)
+
+	h := ProjectingMultiHandler(
+		ProjectedSink{Handler: h1, Projection: compact},
+		ProjectedSink{Handler: nil, Projection: compact},
+		ProjectedSink{Handler: full},
+		ProjectedSink{Handler: disabled, Projection: compact},
+		ProjectedSink{Handler: h2, Projection: compact},
+	)
+	require.Len(t, h.(*projectingHandler).groups, 2, "sinks are grouped by projection")
+
+	require.NoError(t, h.Handle(context.Background(), recordWithAttrs("a", "1", "b", "2")))
+
+	for _, th := range []*testHandler{h1, h2} {
+		require.Equal(t, 1, th.recordCount())
+		assert.True(t, recordHasAttr(th.getRecords()[0], "a", "1"))
+		assert.False(t, recordHasAttr(th.getRecords()[0], "b", "2"))
+	}
+	assert.False(t, recordHasAttr(h2.getRecords()[0], "mutated", "yes"), "records are isolated")
+	assert.Equal(t, 0, disabled.recordCount())
+	require.Equal(t, 1, full.recordCount())
+	assert.True(t, recordHasAttr(full.getRecords()[0], "b", "2"))
+}
+
+func TestProjectingMultiHandler_Errors(t *testing.T) {
+	h1 := newTestHandler(true)
+	h1.err = errors.New("first error")
+	h2 := newTestHandler(true)
+	h2.err = errors.New("second error")
+
+	h := ProjectingMultiHandler(ProjectedSink{Handler: h1, Projection: &Projection{}}, ProjectedSink{Handler: h2})
+	err := h.Handle(context.Background(), recordWithAttrs())
+
+	assert.ErrorIs(t, err, h1.err)
+	assert.ErrorIs(t, err, h2.err)
+}
+
+func TestProjectingMultiHandler_Enabled(t *testing.T) {
+	ctx := context.Background()
+	h := ProjectingMultiHandler(
+		ProjectedSink{Handler: slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}), Projection: &Projection{}},
+		ProjectedSink{Handler: slog.NewTextHandler(io.Discard, nil)},
+	)
+
+	assert.True(t, h.Enabled(ctx, slog.LevelInfo))
+	assert.False(t, h.Enabled(ctx, slog.LevelDebug))
+	assert.False(t, ProjectingMultiHandler().Enabled(ctx, slog.LevelError))
+	assert.Equal(t, []string{"*slog.JSONHandler", "*slog.TextHandler"}, handlerSinks(h, nil))
+}
+
+// recordWithAttrs returns an Info record with the given key and value pairs as attributes.
+func recordWithAttrs(kvs ...string) slog.Record {
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "m", 0)
+	for i := 0; i+1 < len(kvs); i += 2 {
+		r.AddAttrs(slog.String(kvs[i], kvs[i+1]))
+	}
+	return r
+}
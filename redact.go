@@ -0,0 +1,66 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// RedactedValue is the value RedactHandleFunc replaces sensitive values with.
+const RedactedValue = "****"
+
+// RedactHandleFunc returns a HandleFunc that processes records like DefaultHandleFunc, then
+// replaces the values of the attributes matching keys with RedactedValue, so that secrets such
+// as passwords and tokens never reach the next handler.
+//
+// Redaction happens after the attributes of the context and of WithAttrs are merged and the
+// groups are applied, so those attributes are redacted too. Keys are matched case-insensitively
+// against leaf attributes:
+//   - a key without a dot, e.g. "password", matches at any group level
+//   - a dotted key, e.g. "user.password", matches the attribute by its full path, made of the
+//     names of its groups and its own key joined with dots
+//
+// Groups themselves are never replaced, only their members.
+//
+// Example:
+//
+//	handler := slogs.NewHandlerWithOptions(next, &slogs.HandlerOptions{
+//		HandleFunc: slogs.RedactHandleFunc("password", "token", "user.email"),
+//	})
+//	slog.New(handler).Info("login", slog.Group("user", "email", "a@b.c", "Password", "x"))
+//	// {"msg":"login","user":{"email":"****","Password":"****"}}
+func RedactHandleFunc(keys ...string) HandleFunc {
+	anyLevel := make(map[string]struct{}, len(keys))
+	paths := make(map[string]struct{})
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		if strings.Contains(key, ".") {
+			paths[key] = struct{}{}
+		} else {
+			anyLevel[key] = struct{}{}
+		}
+	}
+
+	redact := func(groups []string, a slog.Attr) slog.Attr {
+		key := strings.ToLower(a.Key)
+		if _, ok := anyLevel[key]; ok {
+			a.Value = slog.StringValue(RedactedValue)
+			return a
+		}
+		if len(paths) > 0 {
+			if len(groups) > 0 {
+				key = strings.ToLower(strings.Join(groups, ".")) + "." + key
+			}
+			if _, ok := paths[key]; ok {
+				a.Value = slog.StringValue(RedactedValue)
+			}
+		}
+		return a
+	}
+
+	return func(ctx context.Context, hc *HandlerContext, rt time.Time, rl slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		rm, attrs = DefaultHandleFunc(ctx, hc, rt, rl, rm, attrs)
+		return rm, replaceAttrs(nil, attrs, redact)
+	}
+}
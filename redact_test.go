@@ -0,0 +1,92 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactHandleFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []string
+		log      func(ctx context.Context, l *slog.Logger)
+		expected string
+	}{
+		{
+			name:     "top-level",
+			keys:     []string{"password"},
+			log:      func(ctx context.Context, l *slog.Logger) { l.InfoContext(ctx, "m", "user", "alice", "password", "x") },
+			expected: `{"level":"INFO","msg":"m","user":"alice","password":"****"}`,
+		},
+		{
+			name:     "case-insensitive",
+			keys:     []string{"Password"},
+			log:      func(ctx context.Context, l *slog.Logger) { l.InfoContext(ctx, "m", "PASSWORD", "x") },
+			expected: `{"level":"INFO","msg":"m","PASSWORD":"****"}`,
+		},
+		{
+			name: "any group level",
+			keys: []string{"token"},
+			log: func(ctx context.Context, l *slog.Logger) {
+				l.InfoContext(ctx, "m", slog.Group("auth", "token", "t", slog.Group("refresh", "token", "r")))
+			},
+			expected: `{"level":"INFO","msg":"m","auth":{"token":"****","refresh":{"token":"****"}}}`,
+		},
+		{
+			name: "group path",
+			keys: []string{"user.password"},
+			log: func(ctx context.Context, l *slog.Logger) {
+				l.InfoContext(ctx, "m", "password", "top", slog.Group("user", "password", "x"), slog.Group("db", "password", "y"))
+			},
+			expected: `{"level":"INFO","msg":"m","password":"top","user":{"password":"****"},"db":{"password":"y"}}`,
+		},
+		{
+			name:     "group path from WithGroup",
+			keys:     []string{"User.Password"},
+			log:      func(ctx context.Context, l *slog.Logger) { l.WithGroup("user").InfoContext(ctx, "m", "password", "x") },
+			expected: `{"level":"INFO","msg":"m","user":{"password":"****"}}`,
+		},
+		{
+			name:     "group itself kept",
+			keys:     []string{"user"},
+			log:      func(ctx context.Context, l *slog.Logger) { l.InfoContext(ctx, "m", slog.Group("user", "id", 1)) },
+			expected: `{"level":"INFO","msg":"m","user":{"id":1}}`,
+		},
+		{
+			name:     "attributes from With",
+			keys:     []string{"api_key"},
+			log:      func(ctx context.Context, l *slog.Logger) { l.With("api_key", "k").InfoContext(ctx, "m") },
+			expected: `{"level":"INFO","msg":"m","api_key":"****"}`,
+		},
+		{
+			name: "context attributes",
+			keys: []string{"session"},
+			log: func(ctx context.Context, l *slog.Logger) {
+				l.InfoContext(Append(Prepend(ctx, "session", "s"), "session", "s"), "m")
+			},
+			expected: `{"level":"INFO","msg":"m","session":"****","session":"****"}`,
+		},
+		{
+			name:     "no keys",
+			log:      func(ctx context.Context, l *slog.Logger) { l.InfoContext(ctx, "m", "password", "x") },
+			expected: `{"level":"INFO","msg":"m","password":"x"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandlerWithOptions(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime}), &HandlerOptions{
+				HandleFunc: RedactHandleFunc(tt.keys...),
+			})
+
+			tt.log(context.Background(), slog.New(h))
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
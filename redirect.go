@@ -3,6 +3,8 @@ package slogs
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
 	"log"
 	"log/slog"
 	"os"
@@ -10,6 +12,30 @@ import (
 	"time"
 )
 
+// RedirectOptions configures RedirectStdLogAtWithOptions. The zero value redirects every
+// line at slog.LevelInfo.
+type RedirectOptions struct {
+	// Level is the level of the redirected records.
+	Level slog.Level
+
+	// SkipEmpty drops lines made only of whitespace instead of logging empty records.
+	SkipEmpty bool
+
+	// TrimSpace removes all the surrounding whitespace of a line rather than only its
+	// trailing newline.
+	TrimSpace bool
+
+	// LineKey, if set, logs the line as an attribute with this key and leaves the record
+	// message empty, for pipelines that keep the message for the application's own records.
+	LineKey string
+
+	// Tee also writes each line to the output the standard logger had before the redirect,
+	// e.g. os.Stderr, with its original prefix and date and time flags, to ease migrations
+	// where some consumers still watch that output. File and line flags are not reproduced.
+	// The restore function then resets the output to that writer rather than os.Stderr.
+	Tee bool
+}
+
 // RedirectStdLogAt redirects output from the standard library's package-global
 // logger to the supplied logger at the specified level. Since slogs already
 // handles caller annotations, timestamps, etc., it automatically disables the
@@ -18,21 +44,54 @@ import (
 // It returns a function to restore the original prefix and flags and reset the
 // standard library's output to os.Stderr.
 func RedirectStdLogAt(logger *Logger, level slog.Level) (func(), error) {
+	return RedirectStdLogAtWithOptions(logger, &RedirectOptions{Level: level})
+}
+
+// RedirectStdLogAtWithOptions is like RedirectStdLogAt with additional control over how
+// lines are turned into records. opts may be nil.
+//
+// Example:
+//
+//	restore, err := slogs.RedirectStdLogAtWithOptions(logger, &slogs.RedirectOptions{
+//		Level:     slog.LevelWarn,
+//		SkipEmpty: true,
+//		TrimSpace: true,
+//	})
+func RedirectStdLogAtWithOptions(logger *Logger, opts *RedirectOptions) (func(), error) {
+	if opts == nil {
+		opts = &RedirectOptions{}
+	}
+
 	flags := log.Flags()
 	prefix := log.Prefix()
+	var output io.Writer = os.Stderr
+	var tee *log.Logger
+	if opts.Tee {
+		output = log.Writer()
+		tee = log.New(output, prefix, flags&^(log.Lshortfile|log.Llongfile))
+	}
 
 	handler := logger.Handler()
 	slog.SetDefault(slog.New(handler))
 
+	level := opts.Level
 	capturePC := log.Flags()&(log.Lshortfile|log.Llongfile) != 0
 	log.SetFlags(0) // we want just the log message, no time or location
 	log.SetPrefix("")
-	log.SetOutput(&handlerWriter{handler, &level, capturePC})
+	log.SetOutput(&handlerWriter{
+		h:         handler,
+		level:     &level,
+		capturePC: capturePC,
+		skipEmpty: opts.SkipEmpty,
+		trimSpace: opts.TrimSpace,
+		lineKey:   opts.LineKey,
+		tee:       tee,
+	})
 
 	return func() {
 		log.SetFlags(flags)
 		log.SetPrefix(prefix)
-		log.SetOutput(os.Stderr)
+		log.SetOutput(output)
 	}, nil
 }
 
@@ -42,12 +101,22 @@ type handlerWriter struct {
 	h         slog.Handler
 	level     slog.Leveler
 	capturePC bool
+	skipEmpty bool
+	trimSpace bool
+	lineKey   string
+	// tee, if set, also receives every line.
+	tee *log.Logger
 }
 
 func (w *handlerWriter) Write(buf []byte) (int, error) {
+	var teeErr error
+	if w.tee != nil {
+		teeErr = w.tee.Output(0, string(buf))
+	}
+
 	level := w.level.Level()
 	if !w.h.Enabled(context.Background(), level) {
-		return 0, nil
+		return 0, teeErr
 	}
 	var pc uintptr
 	if !w.capturePC {
@@ -57,9 +126,24 @@ func (w *handlerWriter) Write(buf []byte) (int, error) {
 		pc = pcs[0]
 	}
 
-	// Remove final newline.
 	origLen := len(buf) // Report that the entire buf was written.
-	buf = bytes.TrimSuffix(buf, []byte{'\n'})
-	r := slog.NewRecord(time.Now(), level, string(buf), pc)
-	return origLen, w.h.Handle(context.Background(), r)
+	if w.skipEmpty && len(bytes.TrimSpace(buf)) == 0 {
+		return origLen, teeErr
+	}
+
+	if w.trimSpace {
+		buf = bytes.TrimSpace(buf)
+	} else {
+		// Remove final newline.
+		buf = bytes.TrimSuffix(buf, []byte{'\n'})
+	}
+
+	var r slog.Record
+	if w.lineKey != "" {
+		r = slog.NewRecord(time.Now(), level, "", pc)
+		r.AddAttrs(slog.String(w.lineKey, string(buf)))
+	} else {
+		r = slog.NewRecord(time.Now(), level, string(buf), pc)
+	}
+	return origLen, errors.Join(teeErr, w.h.Handle(context.Background(), r))
 }
@@ -230,3 +230,95 @@ func TestRedirectStdLogAt_OutputToStderr(t *testing.T) {
 	// Verify log.Writer() is os.Stderr after restore
 	assert.Equal(t, os.Stderr, log.Writer())
 }
+
+func TestRedirectStdLogAtWithOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  *RedirectOptions
+		lines []string
+		want  []string
+	}{
+		{
+			name:  "nil options keep empty lines",
+			opts:  nil,
+			lines: []string{"", "hello"},
+			want:  []string{`level=INFO msg=""`, "level=INFO msg=hello"},
+		},
+		{
+			name:  "skip empty",
+			opts:  &RedirectOptions{SkipEmpty: true},
+			lines: []string{"", "  \t", "hello"},
+			want:  []string{"level=INFO msg=hello"},
+		},
+		{
+			name:  "trailing newline only",
+			opts:  &RedirectOptions{Level: slog.LevelWarn},
+			lines: []string{"  padded  "},
+			want:  []string{`level=WARN msg="  padded  "`},
+		},
+		{
+			name:  "trim space",
+			opts:  &RedirectOptions{TrimSpace: true},
+			lines: []string{"  padded  "},
+			want:  []string{"level=INFO msg=padded"},
+		},
+		{
+			name:  "line key",
+			opts:  &RedirectOptions{LineKey: "stdlog"},
+			lines: []string{"hello"},
+			want:  []string{`level=INFO msg="" stdlog=hello`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := New(NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+
+			restore, err := RedirectStdLogAtWithOptions(logger, tt.opts)
+			require.NoError(t, err)
+			defer restore()
+
+			for _, line := range tt.lines {
+				log.Println(line)
+			}
+
+			got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if buf.Len() == 0 {
+				got = nil
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRedirectStdLogAtWithOptions_Tee(t *testing.T) {
+	original := &bytes.Buffer{}
+	log.SetOutput(original)
+	log.SetPrefix("app: ")
+	log.SetFlags(log.Lshortfile)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		log.SetPrefix("")
+		log.SetFlags(log.LstdFlags)
+	})
+
+	buf := &bytes.Buffer{}
+	logger := New(NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+
+	restore, err := RedirectStdLogAtWithOptions(logger, &RedirectOptions{Tee: true})
+	require.NoError(t, err)
+
+	log.Println("teed message")
+	assert.Equal(t, "level=INFO msg=\"teed message\"\n", buf.String())
+	assert.Equal(t, "app: teed message\n", original.String())
+
+	restore()
+	assert.Same(t, original, log.Writer())
+	assert.Equal(t, "app: ", log.Prefix())
+	assert.Equal(t, log.Lshortfile, log.Flags())
+
+	log.Print("after restore")
+	assert.NotContains(t, buf.String(), "after restore")
+	assert.Contains(t, original.String(), "after restore")
+}
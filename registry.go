@@ -0,0 +1,84 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Closer is implemented by logging components that must be flushed or stopped on shutdown.
+type Closer interface {
+	// Close flushes pending output and releases resources. It should give up when ctx is done.
+	Close(ctx context.Context) error
+}
+
+// CloserFunc adapts a function to the Closer interface.
+type CloserFunc func(ctx context.Context) error
+
+// Close calls f(ctx).
+func (f CloserFunc) Close(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Registry collects the Closers of a logging pipeline so they can be shut down together.
+//
+// Create one Registry per application, register components as they are built, and close it
+// when the application exits. The zero value is ready to use.
+//
+// Example:
+//
+//	registry := slogs.NewRegistry()
+//	defer registry.Close(context.Background())
+//
+//	buffered := slogs.NewBufferedWriter(file, 0)
+//	logger := slogs.New(slogs.NewHandler(slog.NewJSONHandler(buffered, nil)))
+//	registry.Register(slogs.NewPeriodicFlusher(buffered.Flush, nil, time.Second))
+type Registry struct {
+	mu      sync.Mutex
+	closers []Closer
+	closed  bool
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds c to the registry.
+//
+// If the registry is already closed, c is closed immediately and its error is returned.
+func (r *Registry) Register(c Closer) error {
+	if c == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	if !r.closed {
+		r.closers = append(r.closers, c)
+		r.mu.Unlock()
+		return nil
+	}
+	r.mu.Unlock()
+
+	return c.Close(context.Background())
+}
+
+// Close closes the registered Closers in reverse order of registration, so components are
+// closed before the components they write to, and joins their errors.
+//
+// Subsequent calls return nil.
+func (r *Registry) Close(ctx context.Context) error {
+	r.mu.Lock()
+	closers := r.closers
+	r.closers = nil
+	r.closed = true
+	r.mu.Unlock()
+
+	var errs []error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
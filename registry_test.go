@@ -0,0 +1,53 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Close(t *testing.T) {
+	var order []string
+	closer := func(name string, err error) Closer {
+		return CloserFunc(func(context.Context) error {
+			order = append(order, name)
+			return err
+		})
+	}
+
+	errB := errors.New("b failed")
+	errC := errors.New("c failed")
+
+	r := NewRegistry()
+	require.NoError(t, r.Register(closer("a", nil)))
+	require.NoError(t, r.Register(closer("b", errB)))
+	require.NoError(t, r.Register(nil))
+	require.NoError(t, r.Register(closer("c", errC)))
+
+	err := r.Close(context.Background())
+	assert.Equal(t, []string{"c", "b", "a"}, order)
+	assert.ErrorIs(t, err, errB)
+	assert.ErrorIs(t, err, errC)
+
+	order = nil
+	require.NoError(t, r.Close(context.Background()))
+	assert.Empty(t, order, "closers are closed only once")
+}
+
+func TestRegistry_RegisterAfterClose(t *testing.T) {
+	var r Registry
+	require.NoError(t, r.Close(context.Background()))
+
+	errLate := errors.New("late")
+	closed := false
+	err := r.Register(CloserFunc(func(context.Context) error {
+		closed = true
+		return errLate
+	}))
+
+	assert.True(t, closed)
+	assert.ErrorIs(t, err, errLate)
+}
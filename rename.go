@@ -0,0 +1,156 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithRenameKeys returns a new Handler that renames attribute keys according to renames.
+//
+// Keys are matched exactly against leaf attributes at every level, including inside groups;
+// group names themselves are not renamed. Renaming runs after the HandleFunc, so it also
+// applies to attributes coming from the context.
+//
+// If several attributes in the same group end up with the same renamed key (for example
+// "err" and "error" both mapping to "error"), the last one wins: earlier attributes with
+// that key are dropped.
+//
+// Built-in record fields such as the message, level and time are produced by the terminal
+// handler, not by attributes; rename those with slog.HandlerOptions.ReplaceAttr.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithRenameKeys(map[string]string{
+//		"err":  "error",
+//		"user": "user_id",
+//	})
+func (h *Handler) WithRenameKeys(renames map[string]string) *Handler {
+	if len(renames) == 0 {
+		return h
+	}
+
+	targets := make(map[string]struct{}, len(renames))
+	for _, to := range renames {
+		targets[to] = struct{}{}
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, renameKeys(attrs, renames, targets)
+	})
+}
+
+// renameKeys renames leaf keys in attrs, recursing into groups.
+func renameKeys(attrs []slog.Attr, renames map[string]string, targets map[string]struct{}) []slog.Attr {
+	out := make([]slog.Attr, 0, len(attrs))
+	collision := false
+	seen := make(map[string]struct{})
+
+	for _, a := range attrs {
+		if a.Value.Kind() == slog.KindGroup {
+			a.Value = slog.GroupValue(renameKeys(a.Value.Group(), renames, targets)...)
+			out = append(out, a)
+			continue
+		}
+
+		if to, ok := renames[a.Key]; ok {
+			a.Key = to
+		}
+		if _, ok := targets[a.Key]; ok {
+			if _, dup := seen[a.Key]; dup {
+				collision = true
+			}
+			seen[a.Key] = struct{}{}
+		}
+		out = append(out, a)
+	}
+
+	if !collision {
+		return out
+	}
+	return keepLastTargets(out, targets)
+}
+
+// keepLastTargets drops all but the last leaf attribute for each rename target key.
+func keepLastTargets(attrs []slog.Attr, targets map[string]struct{}) []slog.Attr {
+	last := make(map[string]int)
+	for i, a := range attrs {
+		if a.Value.Kind() == slog.KindGroup {
+			continue
+		}
+		if _, ok := targets[a.Key]; ok {
+			last[a.Key] = i
+		}
+	}
+
+	out := attrs[:0]
+	for i, a := range attrs {
+		if a.Value.Kind() != slog.KindGroup {
+			if j, ok := last[a.Key]; ok && j != i {
+				continue
+			}
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// WithRenameGroups returns a new Handler that renames groups according to renames, e.g. to
+// match a schema expecting "request" where the code logs an "http" group.
+//
+// Group names are matched exactly at every level, whether the group comes from WithGroup or
+// from a group attribute such as slog.Group; leaf keys are not renamed, see WithRenameKeys.
+// Renaming runs after the HandleFunc has nested the attributes into their groups. Groups
+// renamed to the name of a sibling group are not merged with it.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithRenameGroups(map[string]string{"http": "request"})
+//	logger := slogs.New(handler).WithGroup("http")
+//	logger.Info("served", "status", 200) // request.status=200
+func (h *Handler) WithRenameGroups(renames map[string]string) *Handler {
+	if len(renames) == 0 {
+		return h
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		renamed, _ := renameGroups(attrs, renames)
+		return rm, renamed
+	})
+}
+
+// renameGroups renames the groups in attrs, recursing into them, and reports whether any
+// group was renamed. attrs is returned unchanged if none was.
+func renameGroups(attrs []slog.Attr, renames map[string]string) ([]slog.Attr, bool) {
+	var out []slog.Attr
+	for i, a := range attrs {
+		changed := false
+		if a.Value.Kind() == slog.KindGroup {
+			if group, ok := renameGroups(a.Value.Group(), renames); ok {
+				a.Value = slog.GroupValue(group...)
+				changed = true
+			}
+			if to, ok := renames[a.Key]; ok && a.Key != "" {
+				a.Key = to
+				changed = true
+			}
+		}
+
+		if !changed {
+			if out != nil {
+				out = append(out, a)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]slog.Attr, i, len(attrs))
+			copy(out, attrs[:i])
+		}
+		out = append(out, a)
+	}
+
+	if out == nil {
+		return attrs, false
+	}
+	return out, true
+}
@@ -0,0 +1,143 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_WithRenameKeys(t *testing.T) {
+	renames := map[string]string{
+		"err":  "error",
+		"e":    "error",
+		"user": "user_id",
+	}
+
+	tests := []struct {
+		name    string
+		log     func(l *Logger)
+		want    string
+		notWant []string
+	}{
+		{
+			name: "renames top-level keys",
+			log:  func(l *Logger) { l.Info("m", "err", "boom", "other", 1) },
+			want: `"error":"boom","other":1`,
+		},
+		{
+			name: "renames nested leaf keys but not group names",
+			log:  func(l *Logger) { l.WithGroup("user").Info("m", "user", "alice") },
+			want: `"user":{"user_id":"alice"}`,
+		},
+		{
+			name: "renames inside group attrs",
+			log:  func(l *Logger) { l.Info("m", slog.Group("req", "err", "x")) },
+			want: `"req":{"error":"x"}`,
+		},
+		{
+			name:    "renames context attributes",
+			log:     func(l *Logger) { l.InfoContext(Prepend(context.Background(), "user", "bob"), "m") },
+			want:    `"user_id":"bob"`,
+			notWant: []string{`"user":`},
+		},
+		{
+			name:    "collision keeps the last attribute",
+			log:     func(l *Logger) { l.Info("m", "err", "first", "k", 1, "e", "second") },
+			want:    `"k":1,"error":"second"`,
+			notWant: []string{"first"},
+		},
+		{
+			name:    "collision with an existing target key",
+			log:     func(l *Logger) { l.Info("m", "error", "original", "err", "renamed") },
+			want:    `"error":"renamed"`,
+			notWant: []string{"original"},
+		},
+		{
+			name: "collisions are scoped to a group level",
+			log:  func(l *Logger) { l.Info("m", "err", "root", slog.Group("g", "err", "nested")) },
+			want: `"error":"root","g":{"error":"nested"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, nil)).WithRenameKeys(renames)
+
+			tt.log(New(h))
+
+			assert.Contains(t, buf.String(), tt.want)
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, buf.String(), notWant)
+			}
+		})
+	}
+}
+
+func TestHandler_WithRenameKeys_Empty(t *testing.T) {
+	h := NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, h, h.WithRenameKeys(nil))
+}
+
+func TestHandler_WithRenameGroups(t *testing.T) {
+	renames := map[string]string{
+		"http":  "request",
+		"inner": "details",
+	}
+
+	tests := []struct {
+		name    string
+		log     func(l *Logger)
+		want    string
+		notWant []string
+	}{
+		{
+			name: "renames groups from WithGroup",
+			log:  func(l *Logger) { l.WithGroup("http").Info("m", "status", 200) },
+			want: `"request":{"status":200}`,
+		},
+		{
+			name: "renames nested groups",
+			log:  func(l *Logger) { l.WithGroup("http").WithGroup("inner").Info("m", "k", "v") },
+			want: `"request":{"details":{"k":"v"}}`,
+		},
+		{
+			name: "renames group attrs",
+			log:  func(l *Logger) { l.Info("m", slog.Group("outer", slog.Group("http", "k", "v"))) },
+			want: `"outer":{"request":{"k":"v"}}`,
+		},
+		{
+			name:    "leaves leaf keys untouched",
+			log:     func(l *Logger) { l.Info("m", "http", "GET", slog.Group("inner", "http", 1.1)) },
+			want:    `"http":"GET","details":{"http":1.1}`,
+			notWant: []string{`"request"`},
+		},
+		{
+			name: "leaves other groups untouched",
+			log:  func(l *Logger) { l.WithGroup("db").Info("m", "k", "v") },
+			want: `"db":{"k":"v"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, nil)).WithRenameGroups(renames)
+
+			tt.log(New(h))
+
+			assert.Contains(t, buf.String(), tt.want)
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, buf.String(), notWant)
+			}
+		})
+	}
+}
+
+func TestHandler_WithRenameGroups_Empty(t *testing.T) {
+	h := NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, h, h.WithRenameGroups(nil))
+}
@@ -0,0 +1,134 @@
+package slogs
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+var _ Closer = (*SignalReopener)(nil)
+
+// SignalReopener reopens the log output when a signal arrives, as expected by external log
+// rotation tools such as logrotate, which move the log file and then send SIGHUP so that the
+// service writes to a new file.
+//
+// Register it with a Registry to tie it to the application's shutdown.
+type SignalReopener struct {
+	open func() (io.Writer, error)
+	swap func(w io.Writer)
+
+	stopSignals func()
+	done        chan struct{}
+	stopped     chan struct{}
+	once        sync.Once
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// ReopenOnSignal creates a SignalReopener that calls open whenever the process receives sig,
+// and passes the opened writer to swap, which typically points a SwappableHandler to a new
+// handler writing to it and closes the previous file.
+//
+// If open fails, swap is not called, so logging goes on to the previous writer, and the error
+// is returned by Close.
+//
+// Panics if open or swap is nil.
+//
+// Example:
+//
+//	openLog := func() (*os.File, error) {
+//		return os.OpenFile("/var/log/app.log", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+//	}
+//	file, err := openLog()
+//	if err != nil {
+//		return err
+//	}
+//	swappable := slogs.NewSwappableHandler(slog.NewJSONHandler(file, nil))
+//	logger := slogs.New(slogs.NewHandler(swappable))
+//
+//	var mu sync.Mutex
+//	reopener := slogs.ReopenOnSignal(syscall.SIGHUP,
+//		func() (io.Writer, error) { return openLog() },
+//		func(w io.Writer) {
+//			swappable.Swap(slog.NewJSONHandler(w, nil))
+//			mu.Lock()
+//			defer mu.Unlock()
+//			if err := file.Close(); err != nil {
+//				logger.Error("closing rotated log file", slogs.Err(err))
+//			}
+//			file = w.(*os.File)
+//		})
+//	registry.Register(reopener)
+func ReopenOnSignal(sig os.Signal, open func() (io.Writer, error), swap func(w io.Writer)) *SignalReopener {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, sig)
+
+	return newSignalReopener(signals, func() { signal.Stop(signals) }, open, swap)
+}
+
+// newSignalReopener creates a SignalReopener reopening the output on each value received from
+// signals. stopSignals is called by Close to stop the delivery of signals.
+func newSignalReopener(signals <-chan os.Signal, stopSignals func(), open func() (io.Writer, error), swap func(w io.Writer)) *SignalReopener {
+	if open == nil || swap == nil {
+		panic("slogs: open and swap functions cannot be nil")
+	}
+
+	r := &SignalReopener{
+		open:        open,
+		swap:        swap,
+		stopSignals: stopSignals,
+		done:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	go r.run(signals)
+	return r
+}
+
+func (r *SignalReopener) run(signals <-chan os.Signal) {
+	defer close(r.stopped)
+
+	for {
+		select {
+		case <-signals:
+			w, err := r.open()
+			if err != nil {
+				r.mu.Lock()
+				r.lastErr = err
+				r.mu.Unlock()
+				continue
+			}
+			r.swap(w)
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// Close stops reopening the output on signals.
+//
+// It returns the last error returned by open, if any, or ctx.Err() if ctx is done before the
+// background goroutine stops. Subsequent calls return nil.
+func (r *SignalReopener) Close(ctx context.Context) error {
+	first := false
+	r.once.Do(func() {
+		first = true
+		r.stopSignals()
+		close(r.done)
+	})
+	if !first {
+		return nil
+	}
+
+	select {
+	case <-r.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastErr
+}
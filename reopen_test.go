@@ -0,0 +1,67 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSignalReopener_Nil(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: open and swap functions cannot be nil", func() {
+		newSignalReopener(nil, func() {}, nil, nil)
+	})
+}
+
+func TestSignalReopener_Reopens(t *testing.T) {
+	signals := make(chan os.Signal)
+	first, second := &bytes.Buffer{}, &bytes.Buffer{}
+	swappable := NewSwappableHandler(slog.NewJSONHandler(first, nil))
+	logger := slog.New(swappable)
+
+	swapped := make(chan struct{})
+	stopped := false
+	r := newSignalReopener(signals, func() { stopped = true },
+		func() (io.Writer, error) { return second, nil },
+		func(w io.Writer) {
+			swappable.Swap(slog.NewJSONHandler(w, nil))
+			swapped <- struct{}{}
+		})
+
+	logger.Info("before")
+	signals <- os.Interrupt
+	<-swapped
+	logger.Info("after")
+
+	require.NoError(t, r.Close(context.Background()))
+	assert.True(t, stopped)
+	assert.Contains(t, first.String(), `"msg":"before"`)
+	assert.NotContains(t, first.String(), `"msg":"after"`)
+	assert.Contains(t, second.String(), `"msg":"after"`)
+	require.NoError(t, r.Close(context.Background()), "second Close is a no-op")
+}
+
+func TestSignalReopener_OpenError(t *testing.T) {
+	signals := make(chan os.Signal)
+	errOpen := errors.New("permission denied")
+	opened := make(chan struct{}, 1)
+	swaps := 0
+	r := newSignalReopener(signals, func() {},
+		func() (io.Writer, error) {
+			opened <- struct{}{}
+			return nil, errOpen
+		},
+		func(io.Writer) { swaps++ })
+
+	signals <- os.Interrupt
+	<-opened
+
+	assert.ErrorIs(t, r.Close(context.Background()), errOpen)
+	assert.Zero(t, swaps)
+}
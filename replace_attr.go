@@ -0,0 +1,206 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// ReplaceAttrFunc rewrites or drops an attribute, with the same contract as
+// slog.HandlerOptions.ReplaceAttr: groups lists the groups containing a, and returning an
+// attribute with an empty key and value drops it. It is never called for group attributes.
+//
+// Because the signature matches, a ReplaceAttrFunc can be used both with Handler.WithReplaceAttr
+// and as the ReplaceAttr option of a slog terminal handler.
+type ReplaceAttrFunc func(groups []string, a slog.Attr) slog.Attr
+
+// WithReplaceAttr returns a new Handler that passes every attribute of a record through fn.
+//
+// fn runs after the HandleFunc, so it sees attributes coming from the context and the groups
+// opened with WithGroup. Groups left without attributes are omitted.
+//
+// Built-in record fields such as the level, time and source are produced by the terminal
+// handler and never reach the middleware; presets targeting them, like PresetRenameLevel and
+// PresetTrimSource, only take effect in slog.HandlerOptions.ReplaceAttr.
+//
+// Example:
+//
+//	replace := slogs.CombineTransforms(slogs.PresetRedact("password"), slogs.PresetUTCTime())
+//	handler := slogs.NewHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+//		ReplaceAttr: slogs.CombineTransforms(replace, slogs.PresetRenameLevel("severity")),
+//	})).WithReplaceAttr(replace)
+func (h *Handler) WithReplaceAttr(fn ReplaceAttrFunc) *Handler {
+	if fn == nil {
+		return h
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, replaceAttrs(nil, attrs, fn)
+	})
+}
+
+// RichReplaceAttrFunc is like ReplaceAttrFunc, but also receives the context, level and
+// message of the record, so that a transform can depend on them. It is not compatible with
+// slog.HandlerOptions.ReplaceAttr, see Handler.WithRichReplaceAttr.
+type RichReplaceAttrFunc func(ctx context.Context, level slog.Level, msg string, groups []string, a slog.Attr) slog.Attr
+
+// WithRichReplaceAttr returns a new Handler that passes every attribute of a record through fn,
+// like WithReplaceAttr, along with the record's context, level and message.
+//
+// The message is the one produced by the HandleFunc, so it includes the logger name prefix.
+//
+// Example:
+//
+//	// Keep full payloads only at Debug.
+//	handler := slogs.NewHandler(next).WithRichReplaceAttr(func(_ context.Context, level slog.Level, _ string, _ []string, a slog.Attr) slog.Attr {
+//		if a.Key == "payload" && level > slog.LevelDebug {
+//			return slog.Int("payload_size", len(a.Value.String()))
+//		}
+//		return a
+//	})
+func (h *Handler) WithRichReplaceAttr(fn RichReplaceAttrFunc) *Handler {
+	if fn == nil {
+		return h
+	}
+
+	return h.use(func(ctx context.Context, _ *HandlerContext, _ time.Time, level slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, replaceAttrs(nil, attrs, func(groups []string, a slog.Attr) slog.Attr {
+			return fn(ctx, level, rm, groups, a)
+		})
+	})
+}
+
+// replaceAttrs applies fn to the leaf attributes of attrs, recursing into groups.
+func replaceAttrs(groups []string, attrs []slog.Attr, fn ReplaceAttrFunc) []slog.Attr {
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Value.Kind() == slog.KindGroup {
+			members := a.Value.Group()
+			if a.Key != "" {
+				members = replaceAttrs(append(slices.Clip(groups), a.Key), members, fn)
+			} else {
+				members = replaceAttrs(groups, members, fn)
+			}
+			if len(members) == 0 {
+				continue
+			}
+			a.Value = slog.GroupValue(members...)
+			out = append(out, a)
+			continue
+		}
+
+		a = fn(groups, a)
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// CombineTransforms returns a ReplaceAttrFunc that applies fns in order, each receiving the
+// output of the previous one.
+//
+// Order matters: a function only sees the key produced by the functions before it, so for
+// example PresetRedact("level") placed after PresetRenameLevel("severity") never matches.
+// Once a function drops the attribute, the remaining functions are skipped. nil functions
+// are ignored.
+func CombineTransforms(fns ...ReplaceAttrFunc) ReplaceAttrFunc {
+	fns = slices.DeleteFunc(slices.Clone(fns), func(fn ReplaceAttrFunc) bool { return fn == nil })
+
+	return func(groups []string, a slog.Attr) slog.Attr {
+		for _, fn := range fns {
+			a = fn(groups, a)
+			if a.Equal(slog.Attr{}) {
+				return a
+			}
+		}
+		return a
+	}
+}
+
+// ForGroup returns a ReplaceAttrFunc that applies fn only to the attributes whose groups are
+// exactly path, e.g. []string{"request", "headers"}, and leaves the others unchanged. An empty
+// path targets the top-level attributes.
+//
+// The groups are computed as by slog: both WithGroup groups and group attributes count, groups
+// with an empty key do not. So the path of an attribute is the same whether fn is used with
+// Handler.WithReplaceAttr or in slog.HandlerOptions.ReplaceAttr.
+func ForGroup(path []string, fn ReplaceAttrFunc) ReplaceAttrFunc {
+	path = slices.Clone(path)
+
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if fn == nil || !slices.Equal(groups, path) {
+			return a
+		}
+		return fn(groups, a)
+	}
+}
+
+// PresetRedact returns a ReplaceAttrFunc that replaces the value of attributes with the given
+// keys by "[REDACTED]", at any group level.
+func PresetRedact(keys ...string) ReplaceAttrFunc {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+
+	return func(_ []string, a slog.Attr) slog.Attr {
+		if _, ok := set[a.Key]; ok {
+			a.Value = slog.StringValue("[REDACTED]")
+		}
+		return a
+	}
+}
+
+// PresetRenameLevel returns a ReplaceAttrFunc that renames the built-in level attribute to key,
+// e.g. "severity" for Google Cloud Logging.
+//
+// It only takes effect in slog.HandlerOptions.ReplaceAttr, see Handler.WithReplaceAttr.
+func PresetRenameLevel(key string) ReplaceAttrFunc {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && a.Key == slog.LevelKey {
+			a.Key = key
+		}
+		return a
+	}
+}
+
+// PresetTrimSource returns a ReplaceAttrFunc that shortens the file of the built-in source
+// attribute to its directory and file name, e.g. "slogs/handler.go".
+//
+// It only takes effect in slog.HandlerOptions.ReplaceAttr, see Handler.WithReplaceAttr.
+func PresetTrimSource() ReplaceAttrFunc {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) != 0 || a.Key != slog.SourceKey {
+			return a
+		}
+
+		src, ok := a.Value.Any().(*slog.Source)
+		if !ok || src == nil || src.File == "" {
+			return a
+		}
+
+		trimmed := *src
+		dir, file := filepath.Split(src.File)
+		trimmed.File = filepath.Join(filepath.Base(dir), file)
+		a.Value = slog.AnyValue(&trimmed)
+		return a
+	}
+}
+
+// PresetUTCTime returns a ReplaceAttrFunc that converts time values to UTC.
+//
+// It applies to the built-in time attribute when used in slog.HandlerOptions.ReplaceAttr,
+// and to time-valued attributes at any level in both places.
+func PresetUTCTime() ReplaceAttrFunc {
+	return func(_ []string, a slog.Attr) slog.Attr {
+		if a.Value.Kind() == slog.KindTime {
+			a.Value = slog.TimeValue(a.Value.Time().UTC())
+		}
+		return a
+	}
+}
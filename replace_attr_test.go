@@ -0,0 +1,260 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresetRedact(t *testing.T) {
+	fn := PresetRedact("password", "token")
+
+	tests := []struct {
+		name     string
+		groups   []string
+		attr     slog.Attr
+		expected slog.Attr
+	}{
+		{"matching key", nil, slog.String("password", "hunter2"), slog.String("password", "[REDACTED]")},
+		{"nested key", []string{"user"}, slog.Int("token", 42), slog.String("token", "[REDACTED]")},
+		{"other key", nil, slog.String("user", "alice"), slog.String("user", "alice")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.expected.Equal(fn(tt.groups, tt.attr)))
+		})
+	}
+}
+
+func TestPresetRenameLevel(t *testing.T) {
+	fn := PresetRenameLevel("severity")
+
+	assert.Equal(t, "severity", fn(nil, slog.Any(slog.LevelKey, slog.LevelInfo)).Key)
+	assert.Equal(t, slog.LevelKey, fn([]string{"g"}, slog.String(slog.LevelKey, "x")).Key, "only the top-level attribute is renamed")
+	assert.Equal(t, "msg", fn(nil, slog.String("msg", "x")).Key)
+}
+
+func TestPresetTrimSource(t *testing.T) {
+	fn := PresetTrimSource()
+
+	src := &slog.Source{Function: "main.main", File: "/home/dev/app/cmd/main.go", Line: 12}
+	a := fn(nil, slog.Any(slog.SourceKey, src))
+
+	trimmed, ok := a.Value.Any().(*slog.Source)
+	require.True(t, ok)
+	assert.Equal(t, &slog.Source{Function: "main.main", File: "cmd/main.go", Line: 12}, trimmed)
+	assert.Equal(t, "/home/dev/app/cmd/main.go", src.File, "the original source must not be modified")
+
+	empty := slog.Any(slog.SourceKey, &slog.Source{})
+	assert.True(t, empty.Equal(fn(nil, empty)))
+	other := slog.String(slog.SourceKey, "main.go:1")
+	assert.True(t, other.Equal(fn(nil, other)))
+}
+
+func TestPresetUTCTime(t *testing.T) {
+	fn := PresetUTCTime()
+	local := time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	a := fn([]string{"event"}, slog.Time("created_at", local))
+	assert.Equal(t, time.UTC, a.Value.Time().Location())
+	assert.True(t, local.Equal(a.Value.Time()))
+
+	other := slog.String("created_at", "yesterday")
+	assert.True(t, other.Equal(fn(nil, other)))
+}
+
+func TestCombineTransforms(t *testing.T) {
+	drop := func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == "drop" {
+			return slog.Attr{}
+		}
+		return a
+	}
+	var calls []string
+	record := func(_ []string, a slog.Attr) slog.Attr {
+		calls = append(calls, a.Key)
+		return a
+	}
+
+	tests := []struct {
+		name     string
+		fn       ReplaceAttrFunc
+		attr     slog.Attr
+		expected slog.Attr
+		calls    []string
+	}{
+		{
+			name:     "applied in order",
+			fn:       CombineTransforms(PresetRenameLevel("severity"), PresetRedact("severity"), record),
+			attr:     slog.String(slog.LevelKey, "INFO"),
+			expected: slog.String("severity", "[REDACTED]"),
+			calls:    []string{"severity"},
+		},
+		{
+			name:     "order matters",
+			fn:       CombineTransforms(PresetRenameLevel("severity"), PresetRedact(slog.LevelKey)),
+			attr:     slog.String(slog.LevelKey, "INFO"),
+			expected: slog.String("severity", "INFO"),
+		},
+		{
+			name:     "stops once dropped",
+			fn:       CombineTransforms(drop, record),
+			attr:     slog.String("drop", "x"),
+			expected: slog.Attr{},
+		},
+		{
+			name:     "nil functions ignored",
+			fn:       CombineTransforms(nil, PresetRedact("k"), nil),
+			attr:     slog.String("k", "v"),
+			expected: slog.String("k", "[REDACTED]"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			assert.True(t, tt.expected.Equal(tt.fn(nil, tt.attr)))
+			assert.Equal(t, tt.calls, calls)
+		})
+	}
+}
+
+func TestHandler_WithReplaceAttr(t *testing.T) {
+	var buf bytes.Buffer
+	next := slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})
+
+	var seen [][]string
+	fn := CombineTransforms(PresetRedact("password"), func(groups []string, a slog.Attr) slog.Attr {
+		seen = append(seen, groups)
+		if a.Key == "debug" {
+			return slog.Attr{}
+		}
+		return a
+	})
+
+	h := NewHandler(next).WithReplaceAttr(fn).WithGroup("req")
+	ctx := Prepend(context.Background(), "password", "ctx-secret")
+
+	r := slog.NewRecord(time.Time{}, slog.LevelInfo, "login", 0)
+	r.AddAttrs(
+		slog.String("password", "hunter2"),
+		slog.Group("user", slog.String("name", "alice")),
+		slog.Group("trace", slog.Bool("debug", true)),
+	)
+	require.NoError(t, h.Handle(ctx, r))
+
+	assert.JSONEq(t, `{
+		"level":"INFO","msg":"login",
+		"password":"[REDACTED]",
+		"req":{"password":"[REDACTED]","user":{"name":"alice"}}
+	}`, buf.String())
+	assert.Equal(t, [][]string{nil, {"req"}, {"req", "user"}, {"req", "trace"}}, seen)
+}
+
+func TestHandler_WithReplaceAttr_Nil(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+	assert.Same(t, h, h.WithReplaceAttr(nil))
+}
+
+func TestHandler_WithRichReplaceAttr(t *testing.T) {
+	fn := func(_ context.Context, level slog.Level, msg string, groups []string, a slog.Attr) slog.Attr {
+		if a.Key == "payload" && level > slog.LevelDebug {
+			return slog.Int("payload_size", len(a.Value.String()))
+		}
+		if a.Key == "msg_copy" {
+			return slog.String(a.Key, msg)
+		}
+		return a
+	}
+
+	tests := []struct {
+		name     string
+		level    slog.Level
+		expected string
+	}{
+		{"debug keeps payload", slog.LevelDebug, `{"level":"DEBUG","msg":"sent","req":{"payload":"hello","msg_copy":"sent"}}`},
+		{"info summarizes payload", slog.LevelInfo, `{"level":"INFO","msg":"sent","req":{"payload_size":5,"msg_copy":"sent"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			next := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: dropTime})
+			h := NewHandler(next).WithRichReplaceAttr(fn).WithGroup("req")
+
+			r := slog.NewRecord(time.Time{}, tt.level, "sent", 0)
+			r.AddAttrs(slog.String("payload", "hello"), slog.String("msg_copy", ""))
+			require.NoError(t, h.Handle(context.Background(), r))
+
+			assert.JSONEq(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestHandler_WithRichReplaceAttr_Nil(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+	assert.Same(t, h, h.WithRichReplaceAttr(nil))
+}
+
+func TestHandler_WithReplaceAttr_GroupsMatchStdlib(t *testing.T) {
+	// record collects the groups path and key of every non built-in attribute.
+	record := func(paths *[]string) ReplaceAttrFunc {
+		return func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+				return a
+			}
+			*paths = append(*paths, strings.Join(append(slices.Clip(groups), a.Key), "/"))
+			return a
+		}
+	}
+
+	log := func(l *slog.Logger) {
+		l.With("a", 1).
+			WithGroup("request").With("b", 2).
+			WithGroup("headers").
+			Info("m", "c", 3, slog.Group("deep", "d", 4, slog.Group("", "e", 5), slog.Group("deeper", "f", 6)))
+	}
+
+	var stdPaths, paths []string
+	log(slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{ReplaceAttr: record(&stdPaths)})))
+	log(slog.New(NewHandler(slog.NewJSONHandler(io.Discard, nil)).WithReplaceAttr(record(&paths))))
+
+	assert.Equal(t, []string{
+		"a",
+		"request/b",
+		"request/headers/c",
+		"request/headers/deep/d",
+		"request/headers/deep/e",
+		"request/headers/deep/deeper/f",
+	}, stdPaths)
+	assert.Equal(t, stdPaths, paths)
+}
+
+func TestForGroup(t *testing.T) {
+	var buf bytes.Buffer
+	redact := ForGroup([]string{"request", "headers"}, PresetRedact("token"))
+	h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithReplaceAttr(redact)
+
+	slog.New(h).WithGroup("request").Info("m",
+		"token", "a",
+		slog.Group("headers", "token", "b", slog.Group("nested", "token", "c")),
+	)
+
+	assert.JSONEq(t, `{
+		"level":"INFO","msg":"m",
+		"request":{"token":"a","headers":{"token":"[REDACTED]","nested":{"token":"c"}}}
+	}`, buf.String())
+
+	top := ForGroup(nil, PresetRedact("token"))
+	assert.Equal(t, "[REDACTED]", top(nil, slog.String("token", "x")).Value.String())
+	assert.Equal(t, "x", top([]string{"g"}, slog.String("token", "x")).Value.String())
+}
@@ -0,0 +1,184 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rockcookies/go-slogs/internal/attr"
+)
+
+// requestTokenKey is the context key for the request token used by RequestAttrStore.
+type requestTokenKey struct{}
+
+// WithRequestToken returns a context carrying token, which identifies the request in a
+// RequestAttrStore.
+func WithRequestToken(parent context.Context, token string) context.Context {
+	if parent == nil {
+		parent = context.Background()
+	}
+	return context.WithValue(parent, requestTokenKey{}, token)
+}
+
+// RequestToken returns the request token stored in ctx by WithRequestToken, or an empty string.
+func RequestToken(ctx context.Context) string {
+	if v, ok := ctx.Value(requestTokenKey{}).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// RequestAttrStore holds attributes per request token, set out-of-band from the code that logs.
+//
+// It bridges code that knows the request it is serving (e.g. by its request ID) but cannot
+// thread attributes through the context: attributes set with Set are added to every record
+// logged with a context identifying the same request, see Handler.WithRequestAttrs.
+//
+// Entries expire after the store's TTL unless they are set again, so abandoned requests do
+// not leak. The store is bounded: when it is full, expired entries are evicted first and then
+// the entries closest to expiry.
+type RequestAttrStore struct {
+	ttl        time.Duration
+	maxEntries int
+	clock      Clock
+
+	mu      sync.RWMutex
+	entries map[string]requestAttrs
+}
+
+// requestAttrs are the attributes of one request.
+type requestAttrs struct {
+	attrs   []slog.Attr
+	expires time.Time
+}
+
+// NewRequestAttrStore creates a RequestAttrStore whose entries expire after ttl, measured with
+// clock, or DefaultClock if clock is nil. maxEntries bounds the number of requests tracked;
+// a value <= 0 means 10000.
+func NewRequestAttrStore(ttl time.Duration, maxEntries int, clock Clock) *RequestAttrStore {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	return &RequestAttrStore{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		clock:      clock,
+		entries:    make(map[string]requestAttrs),
+	}
+}
+
+// Set adds attributes to the request identified by token and extends its expiry.
+//
+// The args are converted to attributes using the same rules as slog.Logger.Log.
+func (s *RequestAttrStore) Set(token string, args ...any) {
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[token]
+	if !ok || !now.Before(e.expires) {
+		e = requestAttrs{}
+		s.makeRoom(now)
+	}
+	e.attrs = append(slices.Clip(e.attrs), attr.ArgsToAttrSlice(args)...)
+	e.expires = now.Add(s.ttl)
+	s.entries[token] = e
+}
+
+// Delete removes the attributes of the request identified by token, typically when it ends.
+func (s *RequestAttrStore) Delete(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, token)
+}
+
+// Get returns the attributes of the request identified by token, or nil if there are none
+// or they expired. The returned slice must not be modified.
+func (s *RequestAttrStore) Get(token string) []slog.Attr {
+	now := s.clock.Now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.entries[token]
+	if !ok || !now.Before(e.expires) {
+		return nil
+	}
+	return e.attrs
+}
+
+// Len returns the number of requests tracked, including expired ones not evicted yet.
+func (s *RequestAttrStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// makeRoom evicts entries until a new one fits. s.mu must be held.
+func (s *RequestAttrStore) makeRoom(now time.Time) {
+	if len(s.entries) < s.maxEntries {
+		return
+	}
+
+	for token, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, token)
+		}
+	}
+
+	for len(s.entries) >= s.maxEntries {
+		var oldest string
+		var oldestExpires time.Time
+		for token, e := range s.entries {
+			if oldestExpires.IsZero() || e.expires.Before(oldestExpires) {
+				oldest, oldestExpires = token, e.expires
+			}
+		}
+		delete(s.entries, oldest)
+	}
+}
+
+// WithRequestAttrs returns a new Handler that adds the attributes stored in store for the
+// request of each record.
+//
+// The request is identified by token(ctx), or RequestToken(ctx) if token is nil, which lets
+// legacy code use a request ID it already stores in the context. The attributes are added at
+// the root level of the record, ahead of any attributes added via Prepend.
+//
+// Example:
+//
+//	store := slogs.NewRequestAttrStore(10*time.Minute, 0, nil)
+//	handler := slogs.NewHandler(next).WithRequestAttrs(store, nil)
+//
+//	// In legacy code that only knows the request ID:
+//	store.Set(requestID, "user", userID)
+//	// Anywhere the request is logged:
+//	logger.InfoContext(slogs.WithRequestToken(ctx, requestID), "processing")
+func (h *Handler) WithRequestAttrs(store *RequestAttrStore, token func(ctx context.Context) string) *Handler {
+	if store == nil {
+		return h
+	}
+	if token == nil {
+		token = RequestToken
+	}
+
+	return h.use(func(ctx context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		t := token(ctx)
+		if t == "" {
+			return rm, attrs
+		}
+
+		stored := store.Get(t)
+		if len(stored) == 0 {
+			return rm, attrs
+		}
+		return rm, append(slices.Clip(stored), attrs...)
+	})
+}
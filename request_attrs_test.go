@@ -0,0 +1,211 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rockcookies/go-slogs/slogstest"
+)
+
+func TestRequestToken(t *testing.T) {
+	assert.Equal(t, "", RequestToken(context.Background()))
+	assert.Equal(t, "abc", RequestToken(WithRequestToken(context.Background(), "abc")))
+	assert.Equal(t, "abc", RequestToken(WithRequestToken(nil, "abc")))
+}
+
+func TestRequestAttrStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		ops     func(s *RequestAttrStore, clock *slogstest.FakeClock)
+		token   string
+		want    []slog.Attr
+		wantLen int
+	}{
+		{
+			name:    "unknown token",
+			ops:     func(*RequestAttrStore, *slogstest.FakeClock) {},
+			token:   "a",
+			want:    nil,
+			wantLen: 0,
+		},
+		{
+			name: "set appends attributes",
+			ops: func(s *RequestAttrStore, _ *slogstest.FakeClock) {
+				s.Set("a", "user", "alice")
+				s.Set("a", slog.Int("n", 1))
+			},
+			token:   "a",
+			want:    []slog.Attr{slog.String("user", "alice"), slog.Int("n", 1)},
+			wantLen: 1,
+		},
+		{
+			name: "entries expire after ttl",
+			ops: func(s *RequestAttrStore, clock *slogstest.FakeClock) {
+				s.Set("a", "user", "alice")
+				clock.Advance(time.Minute)
+			},
+			token:   "a",
+			want:    nil,
+			wantLen: 1,
+		},
+		{
+			name: "set extends expiry",
+			ops: func(s *RequestAttrStore, clock *slogstest.FakeClock) {
+				s.Set("a", "user", "alice")
+				clock.Advance(30 * time.Second)
+				s.Set("a", "n", 1)
+				clock.Advance(45 * time.Second)
+			},
+			token:   "a",
+			want:    []slog.Attr{slog.String("user", "alice"), slog.Int("n", 1)},
+			wantLen: 1,
+		},
+		{
+			name: "set after expiry starts over",
+			ops: func(s *RequestAttrStore, clock *slogstest.FakeClock) {
+				s.Set("a", "user", "alice")
+				clock.Advance(time.Minute)
+				s.Set("a", "n", 1)
+			},
+			token:   "a",
+			want:    []slog.Attr{slog.Int("n", 1)},
+			wantLen: 1,
+		},
+		{
+			name: "delete",
+			ops: func(s *RequestAttrStore, _ *slogstest.FakeClock) {
+				s.Set("a", "user", "alice")
+				s.Delete("a")
+			},
+			token:   "a",
+			want:    nil,
+			wantLen: 0,
+		},
+		{
+			name: "full store evicts expired entries first",
+			ops: func(s *RequestAttrStore, clock *slogstest.FakeClock) {
+				s.Set("a", "n", 1)
+				clock.Advance(30 * time.Second)
+				s.Set("b", "n", 2)
+				clock.Advance(30 * time.Second)
+				s.Set("c", "n", 3)
+			},
+			token:   "b",
+			want:    []slog.Attr{slog.Int("n", 2)},
+			wantLen: 2,
+		},
+		{
+			name: "full store evicts entry closest to expiry",
+			ops: func(s *RequestAttrStore, clock *slogstest.FakeClock) {
+				s.Set("a", "n", 1)
+				clock.Advance(time.Second)
+				s.Set("b", "n", 2)
+				clock.Advance(time.Second)
+				s.Set("a", "n", 3)
+				s.Set("c", "n", 4)
+			},
+			token:   "b",
+			want:    nil,
+			wantLen: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			s := NewRequestAttrStore(time.Minute, 2, clock)
+
+			tt.ops(s, clock)
+
+			assert.Equal(t, tt.want, s.Get(tt.token))
+			assert.Equal(t, tt.wantLen, s.Len())
+		})
+	}
+}
+
+func TestRequestAttrStore_AbandonedTokens(t *testing.T) {
+	clock := newFakeClock()
+	s := NewRequestAttrStore(time.Minute, 100, clock)
+
+	for i := 0; i < 10000; i++ {
+		s.Set(fmt.Sprint(i), "i", i)
+		clock.Advance(time.Second)
+	}
+
+	assert.LessOrEqual(t, s.Len(), 100)
+	assert.Equal(t, []slog.Attr{slog.Int("i", 9999)}, s.Get("9999"))
+}
+
+func TestHandler_WithRequestAttrs(t *testing.T) {
+	type ctxKeyLegacyID struct{}
+
+	tests := []struct {
+		name    string
+		token   func(ctx context.Context) string
+		ctx     func() context.Context
+		want    string
+		notWant string
+	}{
+		{
+			name: "adds stored attributes",
+			ctx: func() context.Context {
+				return WithRequestToken(context.Background(), "req-1")
+			},
+			want: `"user":"alice","g":{"k":"v"}`,
+		},
+		{
+			name:    "no token",
+			ctx:     context.Background,
+			notWant: "user",
+		},
+		{
+			name: "unknown token",
+			ctx: func() context.Context {
+				return WithRequestToken(context.Background(), "req-2")
+			},
+			notWant: "user",
+		},
+		{
+			name: "custom token function",
+			token: func(ctx context.Context) string {
+				id, _ := ctx.Value(ctxKeyLegacyID{}).(string)
+				return id
+			},
+			ctx: func() context.Context {
+				return context.WithValue(context.Background(), ctxKeyLegacyID{}, "req-1")
+			},
+			want: `"user":"alice"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewRequestAttrStore(time.Minute, 0, newFakeClock())
+			store.Set("req-1", "user", "alice")
+
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewJSONHandler(buf, nil)).WithRequestAttrs(store, tt.token)
+			logger := New(h).WithGroup("g")
+
+			logger.InfoContext(tt.ctx(), "test", "k", "v")
+
+			if tt.want != "" {
+				assert.Contains(t, buf.String(), tt.want)
+			}
+			if tt.notWant != "" {
+				assert.NotContains(t, buf.String(), tt.notWant)
+			}
+		})
+	}
+}
+
+func TestHandler_WithRequestAttrs_NilStore(t *testing.T) {
+	h := NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, h, h.WithRequestAttrs(nil, nil))
+}
@@ -0,0 +1,251 @@
+package slogs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+var _ slog.Handler = (*RingHandler)(nil)
+
+// RingHandler keeps the most recent records of each level in memory, so that they can be
+// dumped for post-mortem debugging, e.g. after a panic.
+//
+// Records are passed through to the next handler if it is enabled for their level, and
+// retained whether it is or not: a RingHandler with a Debug ring keeps the last Debug records
+// even if the next handler only logs Info and above. Retaining a record only locks the ring
+// slot it is written to, so that concurrent logging goroutines are not serialized.
+//
+// Example:
+//
+//	ring := slogs.NewRingHandler(slog.NewJSONHandler(os.Stdout, nil), map[slog.Level]int{
+//		slog.LevelDebug: 100,
+//		slog.LevelError: 50,
+//	})
+//	logger := slogs.New(slogs.NewHandler(ring))
+//
+//	func main() {
+//		defer ring.DumpOnPanic(os.Stderr)
+//		// ...
+//	}
+type RingHandler struct {
+	next  slog.Handler
+	ops   []ringOp
+	rings *ringSet
+}
+
+// ringOp is a WithGroup call if group is set, and a WithAttrs call otherwise.
+type ringOp struct {
+	group string
+	attrs []slog.Attr
+}
+
+// ringEntry is a retained record together with the WithAttrs and WithGroup calls of the
+// handler it was logged with.
+type ringEntry struct {
+	seq    uint64
+	ops    []ringOp
+	record slog.Record
+}
+
+// ring holds the most recent entries of a level, overwriting the oldest one when full.
+//
+// Writers claim a slot by incrementing n atomically and then only lock that slot, so
+// concurrent Handle calls do not wait for each other unless the ring wraps around between
+// them, and entries are written in place without allocating.
+type ring struct {
+	level slog.Level
+	n     atomic.Uint64
+	slots []ringSlot
+}
+
+// ringSlot holds an entry of a ring; seq is 0 while the slot has never been written.
+type ringSlot struct {
+	mu    sync.Mutex
+	entry ringEntry
+}
+
+// ringSet holds the rings shared by a RingHandler and the handlers derived from it.
+type ringSet struct {
+	seq   atomic.Uint64
+	rings []*ring // sorted by decreasing level
+}
+
+// NewRingHandler creates a RingHandler forwarding records to next and retaining the last
+// capacities[level] records of each configured level.
+//
+// A record is retained in the ring of the highest configured level not above its own, so with
+// rings for Debug and Error, Info and Warn records share the Debug ring. Records below every
+// configured level and levels with a capacity <= 0 are not retained.
+//
+// Panics if next is nil.
+func NewRingHandler(next slog.Handler, capacities map[slog.Level]int) *RingHandler {
+	if next == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+
+	rings := &ringSet{}
+	for level, capacity := range capacities {
+		if capacity > 0 {
+			rings.rings = append(rings.rings, &ring{level: level, slots: make([]ringSlot, capacity)})
+		}
+	}
+	sort.Slice(rings.rings, func(i, j int) bool {
+		return rings.rings[i].level > rings.rings[j].level
+	})
+
+	return &RingHandler{next: next, rings: rings}
+}
+
+// Enabled reports whether records at the given level are retained or handled by the next handler.
+func (h *RingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.rings.find(level) != nil || h.next.Enabled(ctx, level)
+}
+
+// Handle retains r and passes it to the next handler if it is enabled for r's level.
+func (h *RingHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.rings.add(h.ops, r)
+
+	if !h.next.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a RingHandler sharing the same rings whose next handler has the given attributes.
+func (h *RingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	h2.ops = append(slices.Clip(h.ops), ringOp{attrs: attrs})
+	return &h2
+}
+
+// WithGroup returns a RingHandler sharing the same rings whose next handler has the given group.
+func (h *RingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	h2.ops = append(slices.Clip(h.ops), ringOp{group: name})
+	return &h2
+}
+
+// DumpAll writes the retained records of all levels to w as text, in the order they were
+// logged, with the attributes and groups of the handlers they were logged with.
+//
+// The rings are left unchanged. DumpAll does not block concurrent Handle calls, whose records
+// may or may not be included. It returns the first error returned while writing to w.
+func (h *RingHandler) DumpAll(w io.Writer) error {
+	entries := h.rings.snapshot()
+
+	ctx := context.Background()
+	base := slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.Level(math.MinInt)})
+	for _, e := range entries {
+		var next slog.Handler = base
+		for _, op := range e.ops {
+			if op.group != "" {
+				next = next.WithGroup(op.group)
+			} else {
+				next = next.WithAttrs(op.attrs)
+			}
+		}
+		if err := next.Handle(ctx, e.record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DumpOnPanic writes the retained records to w with DumpAll if the goroutine is panicking,
+// then panics again with the same value. It must be deferred directly:
+//
+//	defer ring.DumpOnPanic(os.Stderr)
+//
+// An error writing to w cannot be returned and must not replace the original panic, so it is
+// reported to os.Stderr, next to the panic message, before panicking again.
+func (h *RingHandler) DumpOnPanic(w io.Writer) {
+	if r := recover(); r != nil {
+		h.dumpOnPanic(w, os.Stderr)
+		panic(r)
+	}
+}
+
+// dumpOnPanic writes the retained records to w with DumpAll, reporting an error to errw.
+func (h *RingHandler) dumpOnPanic(w, errw io.Writer) {
+	if err := h.DumpAll(w); err != nil {
+		// The process is about to crash; there is nowhere left to report a failure of errw.
+		_, _ = fmt.Fprintf(errw, "slogs: dumping the ring buffers on panic: %v\n", err)
+	}
+}
+
+// find returns the ring retaining records at level, or nil.
+func (s *ringSet) find(level slog.Level) *ring {
+	for _, r := range s.rings {
+		if level >= r.level {
+			return r
+		}
+	}
+	return nil
+}
+
+// add retains r, logged after ops, in its level's ring.
+func (s *ringSet) add(ops []ringOp, r slog.Record) {
+	rg := s.find(r.Level)
+	if rg == nil {
+		return
+	}
+
+	// The record must outlive the call, so it is cloned.
+	r = r.Clone()
+	seq := s.seq.Add(1)
+	slot := &rg.slots[(rg.n.Add(1)-1)%uint64(len(rg.slots))]
+
+	slot.mu.Lock()
+	// A writer that claimed the slot earlier but got here later must not overwrite a newer entry.
+	if seq > slot.entry.seq {
+		slot.entry = ringEntry{seq: seq, ops: ops, record: r}
+	}
+	slot.mu.Unlock()
+}
+
+// snapshot returns the retained entries of all rings in the order they were logged.
+//
+// It never locks a whole ring, so it is only eventually consistent: an entry being written
+// concurrently may be missing.
+func (s *ringSet) snapshot() []ringEntry {
+	var entries []ringEntry
+	for _, rg := range s.rings {
+		for i := range rg.slots {
+			slot := &rg.slots[i]
+			slot.mu.Lock()
+			e := slot.entry
+			slot.mu.Unlock()
+			if e.seq != 0 {
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+	return entries
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *RingHandler) unwrap() slog.Handler {
+	return h.next
+}
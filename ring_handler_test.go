@@ -0,0 +1,251 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dumpedMessages returns the messages of the records dumped by h.
+func dumpedMessages(t *testing.T, h *RingHandler) []string {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	require.NoError(t, h.DumpAll(buf))
+
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		_, msg, ok := strings.Cut(line, "msg=")
+		require.True(t, ok, line)
+		msg, _, _ = strings.Cut(msg, " ")
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func TestRingHandler_Retention(t *testing.T) {
+	tests := []struct {
+		name       string
+		capacities map[slog.Level]int
+		log        func(l *slog.Logger)
+		want       []string
+	}{
+		{
+			name:       "keeps the last records of each level",
+			capacities: map[slog.Level]int{slog.LevelDebug: 2, slog.LevelError: 1},
+			log: func(l *slog.Logger) {
+				l.Debug("d1")
+				l.Error("e1")
+				l.Debug("d2")
+				l.Debug("d3")
+				l.Error("e2")
+			},
+			want: []string{"d2", "d3", "e2"},
+		},
+		{
+			name:       "levels share the ring of the highest level not above them",
+			capacities: map[slog.Level]int{slog.LevelDebug: 2, slog.LevelError: 2},
+			log: func(l *slog.Logger) {
+				l.Error("e1")
+				l.Info("i1")
+				l.Warn("w1")
+				l.Debug("d1")
+			},
+			want: []string{"e1", "w1", "d1"},
+		},
+		{
+			name:       "records below every ring are not retained",
+			capacities: map[slog.Level]int{slog.LevelWarn: 5, slog.LevelInfo: 0},
+			log: func(l *slog.Logger) {
+				l.Info("i1")
+				l.Warn("w1")
+			},
+			want: []string{"w1"},
+		},
+		{
+			name:       "no rings",
+			capacities: nil,
+			log:        func(l *slog.Logger) { l.Error("e1") },
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewRingHandler(newTestHandler(true), tt.capacities)
+			tt.log(slog.New(h))
+			assert.Equal(t, tt.want, dumpedMessages(t, h))
+		})
+	}
+}
+
+func TestRingHandler_PassThrough(t *testing.T) {
+	buf := &bytes.Buffer{}
+	next := slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	h := NewRingHandler(next, map[slog.Level]int{slog.LevelDebug: 10})
+	logger := slog.New(h)
+
+	assert.True(t, h.Enabled(context.Background(), slog.LevelDebug))
+	logger.Debug("retained_only")
+	logger.Info("both")
+
+	assert.NotContains(t, buf.String(), "retained_only")
+	assert.Contains(t, buf.String(), "both")
+	assert.Equal(t, []string{"retained_only", "both"}, dumpedMessages(t, h))
+}
+
+func TestRingHandler_DumpAllKeepsAttrsAndGroups(t *testing.T) {
+	h := NewRingHandler(newTestHandler(true), map[slog.Level]int{slog.LevelInfo: 10})
+	logger := slog.New(h).With("service", "api").WithGroup("req")
+
+	logger.Info("served", "status", 200)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, h.DumpAll(buf))
+	assert.Contains(t, buf.String(), "msg=served service=api req.status=200")
+}
+
+func TestRingHandler_DumpOnPanic(t *testing.T) {
+	h := NewRingHandler(newTestHandler(true), map[slog.Level]int{slog.LevelInfo: 10})
+	slog.New(h).Info("before crash")
+
+	buf := &bytes.Buffer{}
+	assert.PanicsWithValue(t, "boom", func() {
+		defer h.DumpOnPanic(buf)
+		panic("boom")
+	})
+	assert.Contains(t, buf.String(), "before crash")
+}
+
+func TestRingHandler_DumpOnPanic_NoPanic(t *testing.T) {
+	h := NewRingHandler(newTestHandler(true), map[slog.Level]int{slog.LevelInfo: 10})
+	slog.New(h).Info("fine")
+
+	buf := &bytes.Buffer{}
+	func() {
+		defer h.DumpOnPanic(buf)
+	}()
+	assert.Empty(t, buf.String())
+}
+
+func TestRingHandler_DumpOnPanic_WriteError(t *testing.T) {
+	h := NewRingHandler(newTestHandler(true), map[slog.Level]int{slog.LevelInfo: 10})
+	slog.New(h).Info("before crash")
+
+	errw := &bytes.Buffer{}
+	h.dumpOnPanic(failingWriter{err: errors.New("disk full")}, errw)
+	assert.Equal(t, "slogs: dumping the ring buffers on panic: disk full\n", errw.String())
+}
+
+// failingWriter fails every write with err.
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func TestRingHandler_Concurrent(t *testing.T) {
+	h := NewRingHandler(newTestHandler(true), map[slog.Level]int{slog.LevelDebug: 50, slog.LevelError: 10})
+	logger := slog.New(h)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Debug("d")
+				logger.Error("e")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, dumpedMessages(t, h), 60)
+}
+
+func TestRingHandler_DumpAllDuringHandle(t *testing.T) {
+	h := NewRingHandler(newTestHandler(true), map[slog.Level]int{slog.LevelInfo: 4})
+	logger := slog.New(h)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Info("m")
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		assert.LessOrEqual(t, len(dumpedMessages(t, h)), 4)
+	}
+	wg.Wait()
+
+	assert.Len(t, dumpedMessages(t, h), 4)
+}
+
+func TestNewRingHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewRingHandler(nil, nil)
+	})
+}
+
+// mutexRing is the straightforward ring guarded by a mutex, used as a baseline by
+// BenchmarkRingHandler_Parallel.
+type mutexRing struct {
+	mu      sync.Mutex
+	seq     uint64
+	entries []ringEntry
+	next    int
+}
+
+func (m *mutexRing) add(ops []ringOp, r slog.Record) {
+	r = r.Clone()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.seq++
+	m.entries[m.next] = ringEntry{seq: m.seq, ops: ops, record: r}
+	m.next = (m.next + 1) % len(m.entries)
+}
+
+func BenchmarkRingHandler_Parallel(b *testing.B) {
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("k", "v"))
+
+	b.Run("mutex", func(b *testing.B) {
+		m := &mutexRing{entries: make([]ringEntry, 1024)}
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				m.add(nil, r)
+			}
+		})
+	})
+
+	b.Run("slot-locked", func(b *testing.B) {
+		h := NewRingHandler(slog.NewTextHandler(io.Discard, nil), map[slog.Level]int{slog.LevelInfo: 1024})
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				h.rings.add(nil, r)
+			}
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+var _ slog.Handler = (*RoutingHandler)(nil)
+
+// RoutingHandler passes each record to a single handler chosen by the record's level, e.g.
+// errors to stderr and everything else to stdout. Unlike MultiHandler, a record is never
+// written to several handlers.
+//
+// Routes are checked in the order they were added and the first one covering the record's
+// level is used; records matching no route go to the default handler, or are dropped if there
+// is none.
+//
+// A RoutingHandler is built by chaining calls, each returning a new RoutingHandler:
+//
+//	handler := slogs.NewRoutingHandler().
+//		Route(slog.LevelWarn, slog.LevelError, slog.NewJSONHandler(os.Stderr, nil)).
+//		Default(slog.NewJSONHandler(os.Stdout, nil))
+//	logger := slogs.New(slogs.NewHandler(handler))
+type RoutingHandler struct {
+	routes []route
+	def    slog.Handler // nil if there is no default handler
+}
+
+// route passes the records from minLevel to maxLevel, inclusive, to next.
+type route struct {
+	minLevel slog.Level
+	maxLevel slog.Level
+	next     slog.Handler
+}
+
+// NewRoutingHandler creates a RoutingHandler without routes, which drops all records until
+// routes or a default handler are added.
+func NewRoutingHandler() *RoutingHandler {
+	return &RoutingHandler{}
+}
+
+// Route returns a RoutingHandler that also passes the records from minLevel to maxLevel,
+// inclusive, to next, unless they match an earlier route. A route with minLevel above maxLevel
+// matches no record.
+//
+// Panics if next is nil.
+func (h *RoutingHandler) Route(minLevel, maxLevel slog.Level, next slog.Handler) *RoutingHandler {
+	if next == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+
+	h2 := *h
+	h2.routes = append(slices.Clip(h.routes), route{minLevel: minLevel, maxLevel: maxLevel, next: next})
+	return &h2
+}
+
+// Default returns a RoutingHandler that passes the records matching no route to next. A nil
+// next drops them.
+func (h *RoutingHandler) Default(next slog.Handler) *RoutingHandler {
+	h2 := *h
+	h2.def = next
+	return &h2
+}
+
+// Enabled reports whether the handler selected for level exists and is enabled.
+func (h *RoutingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	next := h.find(level)
+	return next != nil && next.Enabled(ctx, level)
+}
+
+// Handle passes r to the handler selected for its level.
+func (h *RoutingHandler) Handle(ctx context.Context, r slog.Record) error {
+	next := h.find(r.Level)
+	if next == nil {
+		return nil
+	}
+	return next.Handle(ctx, r)
+}
+
+// WithAttrs returns a RoutingHandler whose handlers all have the given attributes.
+func (h *RoutingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.derive(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+// WithGroup returns a RoutingHandler whose handlers all have the given group.
+func (h *RoutingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.derive(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h *RoutingHandler) derive(fn func(next slog.Handler) slog.Handler) *RoutingHandler {
+	h2 := &RoutingHandler{routes: make([]route, len(h.routes))}
+	for i, rt := range h.routes {
+		rt.next = fn(rt.next)
+		h2.routes[i] = rt
+	}
+	if h.def != nil {
+		h2.def = fn(h.def)
+	}
+	return h2
+}
+
+// find returns the handler of the first route covering level, the default handler, or nil.
+func (h *RoutingHandler) find(level slog.Level) slog.Handler {
+	for _, rt := range h.routes {
+		if level >= rt.minLevel && level <= rt.maxLevel {
+			return rt.next
+		}
+	}
+	return h.def
+}
+
+// unwrapAll returns the handlers h passes records to, see handlerSinks.
+func (h *RoutingHandler) unwrapAll() []slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.routes)+1)
+	for _, rt := range h.routes {
+		handlers = append(handlers, rt.next)
+	}
+	if h.def != nil {
+		handlers = append(handlers, h.def)
+	}
+	return handlers
+}
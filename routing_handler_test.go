@@ -0,0 +1,84 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutingHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		withDef    bool
+		level      slog.Level
+		wantTarget string
+	}{
+		{name: "warn route", level: slog.LevelWarn, wantTarget: "warn"},
+		{name: "error route", level: slog.LevelError, wantTarget: "error"},
+		{name: "above error", level: slog.LevelError + 4, wantTarget: "error"},
+		{name: "first matching route", level: slog.LevelWarn + 2, wantTarget: "warn"},
+		{name: "default", withDef: true, level: slog.LevelInfo, wantTarget: "default"},
+		{name: "no route", level: slog.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bufs := map[string]*bytes.Buffer{"warn": {}, "error": {}, "default": {}}
+			h := NewRoutingHandler().
+				Route(slog.LevelWarn, slog.LevelError-1, slog.NewJSONHandler(bufs["warn"], nil)).
+				Route(slog.LevelWarn, slog.LevelError+8, slog.NewJSONHandler(bufs["error"], nil))
+			if tt.withDef {
+				h = h.Default(slog.NewJSONHandler(bufs["default"], nil))
+			}
+
+			assert.Equal(t, tt.wantTarget != "", h.Enabled(context.Background(), tt.level))
+			slog.New(h).With("a", 1).WithGroup("g").Log(context.Background(), tt.level, "m", "b", 2)
+
+			for name, buf := range bufs {
+				if name == tt.wantTarget {
+					assert.Contains(t, buf.String(), `"msg":"m","a":1,"g":{"b":2}}`, name)
+				} else {
+					assert.Empty(t, buf.String(), name)
+				}
+			}
+		})
+	}
+}
+
+func TestRoutingHandler_Enabled(t *testing.T) {
+	h := NewRoutingHandler().
+		Route(slog.LevelDebug, slog.LevelInfo, slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	assert.False(t, h.Enabled(context.Background(), slog.LevelDebug), "the route handler is disabled")
+	assert.True(t, h.Enabled(context.Background(), slog.LevelInfo))
+	assert.False(t, h.Enabled(context.Background(), slog.LevelWarn), "no route")
+}
+
+func TestRoutingHandler_Immutable(t *testing.T) {
+	base := NewRoutingHandler().Route(slog.LevelInfo, slog.LevelInfo, newTestHandler(true))
+	withWarn := base.Route(slog.LevelWarn, slog.LevelWarn, newTestHandler(true))
+	withDef := base.Default(newTestHandler(true))
+
+	assert.False(t, base.Enabled(context.Background(), slog.LevelWarn))
+	assert.True(t, withWarn.Enabled(context.Background(), slog.LevelWarn))
+	assert.True(t, withDef.Enabled(context.Background(), slog.LevelWarn))
+	assert.Same(t, base, base.WithAttrs(nil))
+	assert.Same(t, base, base.WithGroup(""))
+}
+
+func TestRoutingHandler_NilHandler(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewRoutingHandler().Route(slog.LevelInfo, slog.LevelError, nil)
+	})
+}
+
+func TestRoutingHandler_Sinks(t *testing.T) {
+	h := NewRoutingHandler().
+		Route(slog.LevelWarn, slog.LevelError, slog.NewJSONHandler(io.Discard, nil)).
+		Default(slog.NewTextHandler(io.Discard, nil))
+	assert.Equal(t, []string{"*slog.JSONHandler", "*slog.TextHandler"}, handlerSinks(h, nil))
+}
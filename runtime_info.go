@@ -0,0 +1,45 @@
+package slogs
+
+import (
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/debug"
+)
+
+// RuntimeInfoAttrs returns attributes describing the Go runtime of the process, for a one-time
+// log at startup that helps correlate performance issues with the runtime configuration:
+//   - go_version: the Go version the binary was built with
+//   - vcs_revision: the version control revision of the main module, if stamped in the binary
+//   - gomaxprocs: the current GOMAXPROCS setting
+//   - num_cpu: the number of logical CPUs usable by the process
+//   - gogc: the GOGC environment variable, or "100" if it is not set
+//   - memory_limit: the soft memory limit of the runtime in bytes, see debug.SetMemoryLimit
+//
+// Example:
+//
+//	logger.LogAttrs(ctx, slog.LevelInfo, "runtime info", slogs.RuntimeInfoAttrs()...)
+func RuntimeInfoAttrs() []slog.Attr {
+	attrs := []slog.Attr{slog.String("go_version", runtime.Version())}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				attrs = append(attrs, slog.String("vcs_revision", s.Value))
+				break
+			}
+		}
+	}
+
+	gogc := os.Getenv("GOGC")
+	if gogc == "" {
+		gogc = "100"
+	}
+
+	return append(attrs,
+		slog.Int("gomaxprocs", runtime.GOMAXPROCS(0)),
+		slog.Int("num_cpu", runtime.NumCPU()),
+		slog.String("gogc", gogc),
+		// A negative limit reads the current limit without changing it.
+		slog.Int64("memory_limit", debug.SetMemoryLimit(-1)),
+	)
+}
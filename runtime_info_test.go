@@ -0,0 +1,47 @@
+package slogs
+
+import (
+	"log/slog"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeInfoAttrs(t *testing.T) {
+	t.Setenv("GOGC", "")
+
+	got := make(map[string]slog.Value)
+	for _, a := range RuntimeInfoAttrs() {
+		got[a.Key] = a.Value
+	}
+
+	tests := []struct {
+		key  string
+		want slog.Value
+	}{
+		{key: "go_version", want: slog.StringValue(runtime.Version())},
+		{key: "gomaxprocs", want: slog.IntValue(runtime.GOMAXPROCS(0))},
+		{key: "num_cpu", want: slog.IntValue(runtime.NumCPU())},
+		{key: "gogc", want: slog.StringValue("100")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(got[tt.key]), "got %v", got[tt.key])
+		})
+	}
+	assert.Equal(t, slog.KindInt64, got["memory_limit"].Kind())
+}
+
+func TestRuntimeInfoAttrs_GOGC(t *testing.T) {
+	t.Setenv("GOGC", "off")
+
+	for _, a := range RuntimeInfoAttrs() {
+		if a.Key == "gogc" {
+			assert.Equal(t, "off", a.Value.String())
+			return
+		}
+	}
+	t.Fatal("gogc attribute not found")
+}
@@ -0,0 +1,87 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/rockcookies/go-slogs/internal/bufferpool"
+	"github.com/rockcookies/go-slogs/internal/stacktrace"
+)
+
+// Go runs fn in a new goroutine that recovers from panics and logs them through l, so that a
+// failing background goroutine neither crashes the process nor fails without a trace.
+//
+// A panic is logged at slog.LevelError with the message "panic recovered", the panic value
+// under "panic" and the stack of the goroutine under "stack". The stack starts at the function
+// that panicked, and the record source, when caller information is enabled, points to the
+// panic site rather than to Go. The goroutine then ends; the panic is not propagated.
+//
+// Example:
+//
+//	logger.Go(func() {
+//		refreshCache(ctx)
+//	})
+func (l *Logger) Go(fn func()) {
+	go func() {
+		defer l.recoverPanic()
+		fn()
+	}()
+}
+
+// recoverPanic logs the panic of the goroutine, if any. It must be deferred directly so that
+// recover stops the panic.
+func (l *Logger) recoverPanic() {
+	v := recover()
+	if v == nil {
+		return
+	}
+
+	ctx := context.Background()
+	if !l.Enabled(ctx, slog.LevelError) {
+		return
+	}
+
+	stack, pc := panicStack()
+	if !l.addCaller(ctx, slog.LevelError) {
+		pc = 0
+	}
+	r := slog.NewRecord(l.clock.Now(), slog.LevelError, "panic recovered", pc)
+	r.AddAttrs(slog.Any("panic", v), slog.String("stack", stack))
+	l.handle(ctx, r)
+}
+
+// panicStack returns the stack of the panicking goroutine from the panic site, as formatted by
+// Stack, and the program counter of the panic site. It must be called by the deferred function
+// that recovered.
+//
+// The frames of the deferred function and of the runtime panic machinery are skipped: the
+// runtime frames following runtime.gopanic, such as runtime.sigpanic for nil dereferences, are
+// the ones raising runtime errors.
+func panicStack() (string, uintptr) {
+	// Skip panicStack and the deferred function.
+	stack := stacktrace.Capture(2, stacktrace.Full)
+	defer stack.Free()
+
+	buf := bufferpool.Get()
+	defer buf.Free()
+	f := stacktrace.NewFormatter(buf)
+
+	var pc uintptr
+	panicking := false
+	for frame, more := stack.Next(); more; frame, more = stack.Next() {
+		if pc == 0 {
+			if frame.Function == "runtime.gopanic" {
+				panicking = true
+				continue
+			}
+			if !panicking || strings.HasPrefix(frame.Function, "runtime.") {
+				continue
+			}
+			// Frame.PC is the call instruction; the record expects a return address.
+			pc = frame.PC + 1
+		}
+		f.FormatFrame(frame)
+	}
+	return buf.String(), pc
+}
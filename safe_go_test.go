@@ -0,0 +1,87 @@
+package slogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
+
+func panicExplicitly() {
+	panic("boom")
+}
+
+func panicOnNil() {
+	var m *struct{ n int }
+	m.n++
+}
+
+func TestLogger_Go(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func()
+		wantPanic string
+		wantFunc  string
+	}{
+		{name: "explicit panic", fn: panicExplicitly, wantPanic: "boom", wantFunc: "slogs.panicExplicitly"},
+		{name: "runtime error", fn: panicOnNil, wantPanic: "nil pointer dereference", wantFunc: "slogs.panicOnNil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &syncBuffer{}
+			logger := New(NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true})), WithCaller(true))
+
+			logger.Go(tt.fn)
+			require.Eventually(t, func() bool { return len(buf.Bytes()) > 0 }, time.Second, time.Millisecond)
+
+			var entry struct {
+				Level  string
+				Msg    string
+				Panic  string
+				Stack  string
+				Source struct{ Function string }
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, "ERROR", entry.Level)
+			assert.Equal(t, "panic recovered", entry.Msg)
+			assert.Contains(t, entry.Panic, tt.wantPanic)
+			assert.True(t, strings.HasPrefix(entry.Stack, "github.com/rockcookies/go-slogs."+strings.TrimPrefix(tt.wantFunc, "slogs.")), entry.Stack)
+			assert.True(t, strings.HasSuffix(entry.Source.Function, tt.wantFunc), entry.Source.Function)
+		})
+	}
+}
+
+func TestLogger_Go_NoPanic(t *testing.T) {
+	next := newTestHandler(true)
+	done := make(chan struct{})
+
+	New(NewHandler(next)).Go(func() { close(done) })
+	<-done
+
+	assert.Zero(t, next.recordCount())
+}
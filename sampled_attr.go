@@ -0,0 +1,91 @@
+package slogs
+
+import (
+	"log/slog"
+	"math/rand"
+	"slices"
+)
+
+// sampledValue marks an attribute value that is only included in a fraction of records.
+type sampledValue struct {
+	rate  float64
+	value slog.Value
+}
+
+// LogValue returns the wrapped value, so handlers that do not sample attributes
+// always include it.
+func (v sampledValue) LogValue() slog.Value {
+	return v.value
+}
+
+// SampledAttr constructs a field that is only included in a fraction of the records it is
+// logged with, to bound the cost of expensive values such as full request bodies while
+// still logging every record.
+//
+// rate is the probability, between 0 and 1, that the field is kept; each record draws
+// independently. Sampling is performed by Handler, including for attributes added with
+// With; other handlers always include the value.
+//
+// Example:
+//
+//	logger.Info("request", "path", r.URL.Path, slogs.SampledAttr("body", 0.01, body))
+func SampledAttr(key string, rate float64, v any) slog.Attr {
+	return slog.Any(key, sampledValue{rate: rate, value: slog.AnyValue(v)})
+}
+
+// sampleAttrs resolves the sampled attributes in attrs, recursing into groups.
+//
+// Sampled attributes are replaced by their value or dropped. attrs is returned unchanged
+// if it contains no sampled attribute.
+func sampleAttrs(attrs []slog.Attr, keep func(rate float64) bool) []slog.Attr {
+	for i, a := range attrs {
+		if !isSampled(a) {
+			continue
+		}
+
+		out := slices.Clone(attrs[:i])
+		for _, a := range attrs[i:] {
+			if a, ok := sampleAttr(a, keep); ok {
+				out = append(out, a)
+			}
+		}
+		return out
+	}
+	return attrs
+}
+
+// sampleAttr resolves a single attribute and reports whether it is kept.
+func sampleAttr(a slog.Attr, keep func(rate float64) bool) (slog.Attr, bool) {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		if isSampled(a) {
+			a.Value = slog.GroupValue(sampleAttrs(a.Value.Group(), keep)...)
+		}
+	case slog.KindLogValuer:
+		if v, ok := a.Value.Any().(sampledValue); ok {
+			if !keep(v.rate) {
+				return slog.Attr{}, false
+			}
+			a.Value = v.value
+		}
+	}
+	return a, true
+}
+
+// isSampled reports whether a is or contains a sampled attribute.
+func isSampled(a slog.Attr) bool {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		return slices.ContainsFunc(a.Value.Group(), isSampled)
+	case slog.KindLogValuer:
+		_, ok := a.Value.Any().(sampledValue)
+		return ok
+	default:
+		return false
+	}
+}
+
+// keepSampled draws whether a sampled attribute with the given rate is kept.
+func keepSampled(rate float64) bool {
+	return rand.Float64() < rate
+}
@@ -0,0 +1,116 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleAttrs(t *testing.T) {
+	keepAbove := func(threshold float64) func(float64) bool {
+		return func(rate float64) bool { return rate > threshold }
+	}
+
+	tests := []struct {
+		name     string
+		attrs    []slog.Attr
+		expected []slog.Attr
+	}{
+		{
+			name:     "no sampled attrs",
+			attrs:    []slog.Attr{slog.String("a", "1")},
+			expected: []slog.Attr{slog.String("a", "1")},
+		},
+		{
+			name: "kept and dropped",
+			attrs: []slog.Attr{
+				slog.String("a", "1"),
+				SampledAttr("kept", 0.9, "body"),
+				SampledAttr("dropped", 0.1, "body"),
+				slog.String("b", "2"),
+			},
+			expected: []slog.Attr{
+				slog.String("a", "1"),
+				slog.String("kept", "body"),
+				slog.String("b", "2"),
+			},
+		},
+		{
+			name: "inside group",
+			attrs: []slog.Attr{
+				slog.Group("req", slog.String("path", "/"), SampledAttr("body", 0.1, "payload")),
+			},
+			expected: []slog.Attr{
+				slog.Group("req", slog.String("path", "/")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sampleAttrs(tt.attrs, keepAbove(0.5))
+			require.Len(t, got, len(tt.expected))
+			for i := range tt.expected {
+				assert.True(t, tt.expected[i].Equal(got[i]), "attr %d: got %v", i, got[i])
+			}
+		})
+	}
+}
+
+func TestSampledAttr_Handler(t *testing.T) {
+	tests := []struct {
+		name         string
+		rate         float64
+		expected     string
+		expectedWith string
+	}{
+		{"always", 1, "level=INFO msg=request path=/ body=payload\n", "level=INFO msg=request body=payload path=/\n"},
+		{"never", 0, "level=INFO msg=request path=/\n", "level=INFO msg=request path=/\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New(NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+
+			logger.Info("request", "path", "/", SampledAttr("body", tt.rate, "payload"))
+			assert.Equal(t, tt.expected, buf.String())
+
+			buf.Reset()
+			logger.With(SampledAttr("body", tt.rate, "payload")).Info("request", "path", "/")
+			assert.Equal(t, tt.expectedWith, buf.String())
+		})
+	}
+}
+
+func TestSampledAttr_Rate(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewHandler(next)
+
+	for i := 0; i < 1000; i++ {
+		r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+		r.AddAttrs(SampledAttr("body", 0.5, "payload"))
+		require.NoError(t, h.Handle(context.Background(), r))
+	}
+
+	kept := 0
+	for _, r := range next.getRecords() {
+		if recordHasAttr(r, "body", "payload") {
+			kept++
+		}
+	}
+	assert.Equal(t, 1000, next.recordCount(), "records are never dropped")
+	assert.InDelta(t, 500, kept, 150)
+}
+
+func TestSampledAttr_OtherHandlers(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).Info("request", SampledAttr("body", 0, "payload"))
+
+	assert.Equal(t, "level=INFO msg=request body=payload\n", buf.String())
+}
@@ -0,0 +1,268 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ slog.Handler = (*SamplingHandler)(nil)
+
+// SamplingHandler drops repeated records to bound the volume of hot log lines.
+//
+// Records are keyed by level and message. Within each tick, the first records of a key are
+// passed to the next handler and after that only every thereafter-th record is, the rest are
+// dropped. Counting starts over with the next tick. This is the strategy of zap's sampler, so
+// settings tuned for zap carry over.
+//
+// The handler keeps per-key statistics, available through Stats, which report how many
+// records were seen and dropped and help tune first and thereafter. To bound memory, the
+// counters and statistics of the keys not seen during the previous tick are evicted.
+//
+// Example:
+//
+//	sampler := slogs.NewSamplingHandler(slog.NewJSONHandler(os.Stdout, nil), nil, time.Second, 100, 100)
+//	logger := slogs.New(slogs.NewHandler(sampler))
+type SamplingHandler struct {
+	next     slog.Handler
+	sampler  *sampler
+	reporter DropReporter
+}
+
+// sampler is the state shared by a SamplingHandler and the handlers derived from it.
+type sampler struct {
+	clock      Clock
+	tick       time.Duration
+	first      uint64
+	thereafter uint64
+
+	// mu guards counters. Records only take the read lock to look up their counter; the write
+	// lock is taken to add a counter or to evict counters.
+	mu       sync.RWMutex
+	counters map[sampleKey]*sampleCounter
+	// nextSweep is when the counters of the keys not seen during the previous tick are next evicted.
+	nextSweep atomic.Int64
+}
+
+// sampleKey identifies the records that are sampled together.
+type sampleKey struct {
+	level   slog.Level
+	message string
+}
+
+// sampleCounter counts the records of one key. It is updated without holding the sampler lock.
+type sampleCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+
+	seen    atomic.Uint64
+	emitted atomic.Uint64
+}
+
+// SampleStats reports the sampling decisions made for one key since it was last evicted.
+type SampleStats struct {
+	// Seen is the number of records handled.
+	Seen uint64
+	// Emitted is the number of records passed to the next handler.
+	Emitted uint64
+	// Dropped is the number of records dropped by sampling.
+	Dropped uint64
+}
+
+// Ratio returns the effective sampling rate, the fraction of seen records that were emitted.
+//
+// It returns 1 if no record was seen.
+func (s SampleStats) Ratio() float64 {
+	if s.Seen == 0 {
+		return 1
+	}
+	return float64(s.Emitted) / float64(s.Seen)
+}
+
+// NewSamplingHandler creates a SamplingHandler that passes sampled records to next.
+//
+// Within each tick, the first records of a level and message are emitted, then every
+// thereafter-th record; a thereafter <= 0 drops all records past first. The tick window is
+// measured with clock, or DefaultClock if clock is nil.
+//
+// Panics if next is nil; see NewSamplingHandlerE.
+func NewSamplingHandler(next slog.Handler, clock Clock, tick time.Duration, first, thereafter int) *SamplingHandler {
+	h, err := NewSamplingHandlerE(next, clock, tick, first, thereafter)
+	if err != nil {
+		panic(err.Error())
+	}
+	return h
+}
+
+// NewSamplingHandlerE is like NewSamplingHandler but returns ErrNilNextHandler instead of
+// panicking if next is nil.
+func NewSamplingHandlerE(next slog.Handler, clock Clock, tick time.Duration, first, thereafter int) (*SamplingHandler, error) {
+	if next == nil {
+		return nil, ErrNilNextHandler
+	}
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	s := &sampler{
+		clock:    clock,
+		tick:     tick,
+		counters: make(map[sampleKey]*sampleCounter),
+	}
+	if first > 0 {
+		s.first = uint64(first)
+	}
+	if thereafter > 0 {
+		s.thereafter = uint64(thereafter)
+	}
+
+	return &SamplingHandler{next: next, sampler: s}, nil
+}
+
+// Stats returns the sampling statistics per key.
+//
+// Keys are formatted as the level followed by the message, e.g. "INFO cache hit". The keys
+// not seen during the previous tick are evicted and do not appear. Only the key lookup takes
+// a lock, for reading; the counters are read atomically, so calling Stats does not block
+// logging.
+func (h *SamplingHandler) Stats() map[string]SampleStats {
+	s := h.sampler
+
+	s.mu.RLock()
+	counters := make(map[sampleKey]*sampleCounter, len(s.counters))
+	for k, c := range s.counters {
+		counters[k] = c
+	}
+	s.mu.RUnlock()
+
+	stats := make(map[string]SampleStats, len(counters))
+	for k, c := range counters {
+		// Load emitted first so that Dropped cannot underflow while records are being handled.
+		emitted := c.emitted.Load()
+		seen := c.seen.Load()
+		stats[k.level.String()+" "+k.message] = SampleStats{
+			Seen:    seen,
+			Emitted: emitted,
+			Dropped: seen - emitted,
+		}
+	}
+	return stats
+}
+
+// WithDropReporter returns a SamplingHandler sharing the same counters that reports every
+// dropped record to reporter with ReasonSampled. A nil reporter disables reporting.
+func (h *SamplingHandler) WithDropReporter(reporter DropReporter) *SamplingHandler {
+	h2 := *h
+	h2.reporter = reporter
+	return &h2
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes r to the next handler if it is sampled and drops it otherwise.
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.sampler.sample(r.Level, r.Message) {
+		if h.reporter != nil {
+			h.reporter.Dropped(r.Level, ReasonSampled, 1)
+		}
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a SamplingHandler sharing the same counters whose next handler has the given attributes.
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a SamplingHandler sharing the same counters whose next handler has the given group.
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+// sample records a record of the given level and message and reports whether it should be emitted.
+func (s *sampler) sample(level slog.Level, message string) bool {
+	now := s.clock.Now()
+	c := s.counter(sampleKey{level: level, message: message}, now.UnixNano())
+	c.seen.Add(1)
+
+	n := c.incCheckReset(now, s.tick)
+	if n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0) {
+		return false
+	}
+
+	c.emitted.Add(1)
+	return true
+}
+
+// counter returns the counter of key, evicting at most once per tick the counters whose last
+// tick ended before the previous tick at now. A record counted concurrently with the eviction
+// of its counter is not counted.
+//
+// Existing counters are looked up under the read lock, so that records do not contend on the
+// sampler; the write lock is only taken for a new key or an eviction.
+func (s *sampler) counter(key sampleKey, now int64) *sampleCounter {
+	if now < s.nextSweep.Load() {
+		s.mu.RLock()
+		c, ok := s.counters[key]
+		s.mu.RUnlock()
+		if ok {
+			return c
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now >= s.nextSweep.Load() {
+		tick := s.tick.Nanoseconds()
+		for k, c := range s.counters {
+			if c.resetAt.Load()+tick <= now {
+				delete(s.counters, k)
+			}
+		}
+		s.nextSweep.Store(now + tick)
+	}
+
+	c, ok := s.counters[key]
+	if !ok {
+		c = &sampleCounter{}
+		s.counters[key] = c
+	}
+	return c
+}
+
+// incCheckReset increments the count of the current tick, starting a new tick if the
+// current one has ended at t, and returns the updated count.
+func (c *sampleCounter) incCheckReset(t time.Time, tick time.Duration) uint64 {
+	now := t.UnixNano()
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.count.Add(1)
+	}
+
+	c.count.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now+tick.Nanoseconds()) {
+		// Another goroutine started the new tick first.
+		return c.count.Add(1)
+	}
+	return 1
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *SamplingHandler) unwrap() slog.Handler {
+	return h.next
+}
@@ -0,0 +1,198 @@
+package slogs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSamplingHandler_NilNext(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewSamplingHandler(nil, nil, time.Second, 1, 1)
+	})
+
+	h, err := NewSamplingHandlerE(nil, nil, time.Second, 1, 1)
+	assert.ErrorIs(t, err, ErrNilNextHandler)
+	assert.Nil(t, h)
+}
+
+func TestSamplingHandler_Sampling(t *testing.T) {
+	tests := []struct {
+		name       string
+		first      int
+		thereafter int
+		logs       int
+		expected   int
+	}{
+		{name: "below first", first: 5, thereafter: 10, logs: 3, expected: 3},
+		{name: "first then every thereafter", first: 2, thereafter: 3, logs: 11, expected: 5}, // 1, 2, 5, 8, 11
+		{name: "drop after first", first: 2, thereafter: 0, logs: 10, expected: 2},
+		{name: "only every thereafter", first: 0, thereafter: 4, logs: 8, expected: 2}, // 4, 8
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			h := NewSamplingHandler(next, newFakeClock(), time.Second, tt.first, tt.thereafter)
+
+			for i := 0; i < tt.logs; i++ {
+				require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "hot", 0)))
+			}
+
+			assert.Equal(t, tt.expected, next.recordCount())
+		})
+	}
+}
+
+func TestSamplingHandler_Keys(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewSamplingHandler(next, newFakeClock(), time.Second, 1, 0)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "a", 0)))
+		require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "b", 0)))
+		require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelWarn, "a", 0)))
+	}
+
+	assert.Equal(t, []string{"a", "b", "a"}, messages(next.getRecords()))
+}
+
+func TestSamplingHandler_Tick(t *testing.T) {
+	next := newTestHandler(true)
+	clock := newFakeClock()
+	h := NewSamplingHandler(next, clock, time.Second, 2, 0)
+
+	log := func() {
+		require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "hot", 0)))
+	}
+
+	log()
+	log()
+	log()
+	assert.Equal(t, 2, next.recordCount())
+
+	clock.Advance(500 * time.Millisecond)
+	log()
+	assert.Equal(t, 2, next.recordCount(), "tick has not ended yet")
+
+	clock.Advance(500 * time.Millisecond)
+	log()
+	log()
+	log()
+	assert.Equal(t, 4, next.recordCount(), "counting starts over with the next tick")
+}
+
+func TestSamplingHandler_Stats(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewSamplingHandler(next, newFakeClock(), time.Second, 2, 3)
+
+	ctx := context.Background()
+	for i := 0; i < 11; i++ {
+		require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "hot", 0)))
+	}
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelError, "rare", 0)))
+
+	stats := h.Stats()
+	assert.Equal(t, map[string]SampleStats{
+		"INFO hot":   {Seen: 11, Emitted: 5, Dropped: 6},
+		"ERROR rare": {Seen: 1, Emitted: 1, Dropped: 0},
+	}, stats)
+	assert.InDelta(t, 5.0/11.0, stats["INFO hot"].Ratio(), 1e-9)
+	assert.Equal(t, 1.0, stats["ERROR rare"].Ratio())
+	assert.Equal(t, 1.0, SampleStats{}.Ratio())
+}
+
+func TestSamplingHandler_EvictsIdleKeys(t *testing.T) {
+	clock := newFakeClock()
+	h := NewSamplingHandler(newTestHandler(true), clock, time.Second, 1, 0)
+
+	log := func(msg string) {
+		require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, msg, 0)))
+	}
+
+	log("idle")
+	clock.Advance(time.Second)
+	log("busy")
+	assert.Len(t, h.Stats(), 2, "keys seen during the previous tick are kept")
+
+	clock.Advance(time.Second)
+	log("busy")
+	assert.Equal(t, map[string]SampleStats{"INFO busy": {Seen: 2, Emitted: 2}}, h.Stats())
+
+	for i := 0; i < 100; i++ {
+		clock.Advance(time.Second)
+		log(fmt.Sprintf("unique %d", i))
+	}
+	assert.LessOrEqual(t, len(h.Stats()), 2, "the counters are bounded")
+}
+
+func TestSamplingHandler_SharedState(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewSamplingHandler(next, newFakeClock(), time.Second, 1, 0)
+
+	ctx := context.Background()
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hot", 0)
+	require.NoError(t, h.Handle(ctx, r))
+	require.NoError(t, h.WithAttrs([]slog.Attr{slog.String("k", "v")}).Handle(ctx, r))
+	require.NoError(t, h.WithGroup("g").Handle(ctx, r))
+
+	assert.Equal(t, 1, next.recordCount())
+	assert.Equal(t, uint64(3), h.Stats()["INFO hot"].Seen)
+}
+
+func TestSamplingHandler_Concurrent(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewSamplingHandler(next, newFakeClock(), time.Second, 10, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "hot", 0)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, next.recordCount())
+	assert.Equal(t, SampleStats{Seen: 800, Emitted: 10, Dropped: 790}, h.Stats()["INFO hot"])
+}
+
+func TestSamplingHandler_WithDropReporter(t *testing.T) {
+	next := newTestHandler(true)
+	reporter := newDropCounter()
+	h := NewSamplingHandler(next, newFakeClock(), time.Second, 1, 0).WithDropReporter(reporter)
+	logger := New(NewHandler(h)).With("k", "v")
+
+	for i := 0; i < 3; i++ {
+		logger.Info("info")
+		logger.Warn("warn")
+	}
+
+	assert.Equal(t, 2, next.recordCount())
+	assert.Equal(t, map[string]int{"INFO sampled": 2, "WARN sampled": 2}, reporter.get())
+}
+
+func BenchmarkSamplingHandler_Parallel(b *testing.B) {
+	h := NewSamplingHandler(newTestHandler(true), nil, time.Hour, 1, 0)
+	ctx := context.Background()
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hot", 0)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := h.Handle(ctx, r); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
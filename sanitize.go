@@ -0,0 +1,76 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WithUTF8Sanitize returns a new Handler that replaces invalid UTF-8 sequences in the message
+// and in string attribute values with the Unicode replacement character U+FFFD.
+//
+// This keeps a single bad byte, e.g. from binary data logged as a string, from producing a
+// line that downstream JSON parsers reject. Attribute keys and values of other kinds are left
+// unchanged. If enabled is false, h is returned.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(slog.NewJSONHandler(os.Stdout, nil)).WithUTF8Sanitize(true)
+func (h *Handler) WithUTF8Sanitize(enabled bool) *Handler {
+	if !enabled {
+		return h
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		sanitized, _ := sanitizeAttrs(attrs)
+		return sanitizeUTF8(rm), sanitized
+	})
+}
+
+// sanitizeUTF8 replaces the invalid UTF-8 sequences of s with the replacement character.
+func sanitizeUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	return strings.ToValidUTF8(s, string(utf8.RuneError))
+}
+
+// sanitizeAttrs sanitizes the string values in attrs, recursing into groups, and reports
+// whether any value was changed. attrs is returned unchanged if none was.
+func sanitizeAttrs(attrs []slog.Attr) ([]slog.Attr, bool) {
+	var out []slog.Attr
+	for i, a := range attrs {
+		changed := false
+		switch v := a.Value.Resolve(); v.Kind() {
+		case slog.KindString:
+			if s := v.String(); !utf8.ValidString(s) {
+				a.Value = slog.StringValue(sanitizeUTF8(s))
+				changed = true
+			}
+		case slog.KindGroup:
+			if group, ok := sanitizeAttrs(v.Group()); ok {
+				a.Value = slog.GroupValue(group...)
+				changed = true
+			}
+		}
+
+		if !changed {
+			if out != nil {
+				out = append(out, a)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]slog.Attr, i, len(attrs))
+			copy(out, attrs[:i])
+		}
+		out = append(out, a)
+	}
+
+	if out == nil {
+		return attrs, false
+	}
+	return out, true
+}
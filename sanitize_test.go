@@ -0,0 +1,54 @@
+package slogs
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_WithUTF8Sanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l *Logger)
+		want string
+	}{
+		{
+			name: "message",
+			log:  func(l *Logger) { l.Info("bad \xff byte") },
+			want: `msg="bad � byte"`,
+		},
+		{
+			name: "string attribute",
+			log:  func(l *Logger) { l.Info("m", "data", "a\xc3\x28b") },
+			want: `msg=m data="a�(b"`,
+		},
+		{
+			name: "nested group attribute",
+			log:  func(l *Logger) { l.WithGroup("req").Info("m", slog.Group("body", "raw", "\xfe\xff")) },
+			want: `msg=m req.body.raw="�"`,
+		},
+		{
+			name: "valid values are unchanged",
+			log:  func(l *Logger) { l.Info("héllo", "k", "日本", "n", 1) },
+			want: `msg=héllo k=日本 n=1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithUTF8Sanitize(true)
+
+			tt.log(New(h))
+
+			assert.Equal(t, "level=INFO "+tt.want+"\n", buf.String())
+		})
+	}
+}
+
+func TestHandler_WithUTF8Sanitize_Disabled(t *testing.T) {
+	h := NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, h, h.WithUTF8Sanitize(false))
+}
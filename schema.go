@@ -0,0 +1,140 @@
+package slogs
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FieldInfo describes an attribute key seen by a SchemaRecorder.
+type FieldInfo struct {
+	// Path is the key of the attribute, prefixed by the groups containing it and joined
+	// with dots, e.g. "http.status".
+	Path string `json:"path"`
+	// Kinds lists the kinds of the values seen for the attribute, e.g. "Int64", sorted.
+	Kinds []string `json:"kinds"`
+}
+
+// SchemaRecorder records the attribute keys emitted by a Handler, with their group paths and
+// the kinds of their values, so that logging schemas can be enforced and new fields detected.
+//
+// Attach it to a Handler with Handler.WithSchemaRecorder, then query it with Schema or serve
+// it over HTTP, since it implements http.Handler. Only one record out of every sampled ones
+// is inspected, and known fields are only looked up, keeping the overhead low.
+//
+// Example:
+//
+//	schema := slogs.NewSchemaRecorder(100)
+//	handler := slogs.NewHandler(next).WithSchemaRecorder(schema)
+//	http.Handle("/debug/log-schema", schema)
+type SchemaRecorder struct {
+	every uint64
+	seen  atomic.Uint64
+
+	mu     sync.RWMutex
+	fields map[string]map[slog.Kind]struct{}
+}
+
+// NewSchemaRecorder creates a SchemaRecorder inspecting one record out of every, or every
+// record if every <= 1.
+func NewSchemaRecorder(every int) *SchemaRecorder {
+	if every < 1 {
+		every = 1
+	}
+	return &SchemaRecorder{every: uint64(every), fields: make(map[string]map[slog.Kind]struct{})}
+}
+
+// WithSchemaRecorder returns a new Handler that reports the attributes of its records to rec.
+//
+// The attributes are observed after the HandleFunc, so they include the attributes coming from
+// the context and the groups opened with WithGroup. Records are not modified. A nil rec
+// returns h.
+func (h *Handler) WithSchemaRecorder(rec *SchemaRecorder) *Handler {
+	if rec == nil {
+		return h
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		rec.observe(attrs)
+		return rm, attrs
+	})
+}
+
+// Schema returns the fields seen so far, sorted by path.
+func (s *SchemaRecorder) Schema() []FieldInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fields := make([]FieldInfo, 0, len(s.fields))
+	for path, kinds := range s.fields {
+		info := FieldInfo{Path: path, Kinds: make([]string, 0, len(kinds))}
+		for kind := range kinds {
+			info.Kinds = append(info.Kinds, kind.String())
+		}
+		sort.Strings(info.Kinds)
+		fields = append(fields, info)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Path < fields[j].Path
+	})
+	return fields
+}
+
+// ServeHTTP writes the result of Schema as a JSON array.
+func (s *SchemaRecorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Schema()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// observe records the fields of attrs if the record is sampled.
+func (s *SchemaRecorder) observe(attrs []slog.Attr) {
+	if s.seen.Add(1)%s.every != 0 {
+		return
+	}
+	s.observeGroup("", attrs)
+}
+
+func (s *SchemaRecorder) observeGroup(prefix string, attrs []slog.Attr) {
+	for _, a := range attrs {
+		if a.Key == "" && a.Value.Kind() != slog.KindGroup {
+			continue
+		}
+
+		v := a.Value.Resolve()
+		if v.Kind() == slog.KindGroup {
+			groupPrefix := prefix
+			if a.Key != "" {
+				groupPrefix = prefix + a.Key + "."
+			}
+			s.observeGroup(groupPrefix, v.Group())
+			continue
+		}
+		s.add(prefix+a.Key, v.Kind())
+	}
+}
+
+// add records that a value of kind was seen at path.
+func (s *SchemaRecorder) add(path string, kind slog.Kind) {
+	s.mu.RLock()
+	_, known := s.fields[path][kind]
+	s.mu.RUnlock()
+	if known {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kinds, ok := s.fields[path]
+	if !ok {
+		kinds = make(map[slog.Kind]struct{}, 1)
+		s.fields[path] = kinds
+	}
+	kinds[kind] = struct{}{}
+}
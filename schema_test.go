@@ -0,0 +1,95 @@
+package slogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRecorder(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l *Logger)
+		want []FieldInfo
+	}{
+		{
+			name: "keys and kinds",
+			log: func(l *Logger) {
+				l.Info("m", "user", "alice", "count", 1)
+				l.Info("m", "count", "many")
+			},
+			want: []FieldInfo{
+				{Path: "count", Kinds: []string{"Int64", "String"}},
+				{Path: "user", Kinds: []string{"String"}},
+			},
+		},
+		{
+			name: "group paths",
+			log: func(l *Logger) {
+				l.WithGroup("http").Info("m", "status", 200, slog.Group("req", "method", "GET"))
+				l.Info("m", slog.Group("", "inline", true))
+			},
+			want: []FieldInfo{
+				{Path: "http.req.method", Kinds: []string{"String"}},
+				{Path: "http.status", Kinds: []string{"Int64"}},
+				{Path: "inline", Kinds: []string{"Bool"}},
+			},
+		},
+		{
+			name: "any values",
+			log:  func(l *Logger) { l.Info("m", "err", errors.New("boom")) },
+			want: []FieldInfo{{Path: "err", Kinds: []string{"Any"}}},
+		},
+		{
+			name: "no attributes",
+			log:  func(l *Logger) { l.Info("m") },
+			want: []FieldInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewSchemaRecorder(0)
+			tt.log(New(NewHandler(newTestHandler(true)).WithSchemaRecorder(rec)))
+			assert.Equal(t, tt.want, rec.Schema())
+		})
+	}
+}
+
+func TestSchemaRecorder_Sampling(t *testing.T) {
+	rec := NewSchemaRecorder(3)
+	logger := New(NewHandler(newTestHandler(true)).WithSchemaRecorder(rec))
+
+	logger.Info("m", "first", 1)
+	logger.Info("m", "second", 1)
+	logger.Info("m", "third", 1)
+	logger.Info("m", "fourth", 1)
+
+	assert.Equal(t, []FieldInfo{{Path: "third", Kinds: []string{"Int64"}}}, rec.Schema())
+}
+
+func TestSchemaRecorder_ServeHTTP(t *testing.T) {
+	rec := NewSchemaRecorder(1)
+	New(NewHandler(newTestHandler(true)).WithSchemaRecorder(rec)).Info("m", "user", "alice")
+
+	w := httptest.NewRecorder()
+	rec.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/schema", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var got []FieldInfo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, []FieldInfo{{Path: "user", Kinds: []string{"String"}}}, got)
+}
+
+func TestHandler_WithSchemaRecorder_Nil(t *testing.T) {
+	h := NewHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, h, h.WithSchemaRecorder(nil))
+}
@@ -0,0 +1,66 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// OnShutdown blocks until the process receives one of sigs, or os.Interrupt or SIGTERM if sigs
+// is empty, or until ctx is done. It then logs a "shutting down" record through logger and
+// closes registry, so that buffered output is flushed before main returns.
+//
+// The record is logged at slog.LevelInfo with the received signal under "signal", or the
+// error of ctx under "reason". registry is closed with a context that carries the values of
+// ctx but is not canceled with it, since ctx may be what triggered the shutdown, and that
+// expires after closeTimeout, so that a hung sink cannot block the shutdown forever. A
+// closeTimeout <= 0 waits for the Closers as long as they take. It returns the received
+// signal, nil if ctx is done, and the error of registry.Close.
+//
+// Example:
+//
+//	func main() {
+//		registry := slogs.NewRegistry()
+//		buffered := slogs.NewBufferedWriter(os.Stdout, 0)
+//		registry.Register(slogs.NewPeriodicFlusher(buffered.Flush, nil, time.Second))
+//		logger := slogs.New(slogs.NewHandler(slog.NewJSONHandler(buffered, nil)))
+//
+//		go serve(logger)
+//
+//		if _, err := slogs.OnShutdown(context.Background(), logger, registry, 5*time.Second); err != nil {
+//			fmt.Fprintln(os.Stderr, "flushing logs:", err)
+//			os.Exit(1)
+//		}
+//	}
+func OnShutdown(ctx context.Context, logger *Logger, registry *Registry, closeTimeout time.Duration, sigs ...os.Signal) (os.Signal, error) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, sigs...)
+	defer signal.Stop(signals)
+
+	return waitShutdown(ctx, signals, logger, registry, closeTimeout)
+}
+
+// waitShutdown implements OnShutdown with the signals received from signals.
+func waitShutdown(ctx context.Context, signals <-chan os.Signal, logger *Logger, registry *Registry, closeTimeout time.Duration) (os.Signal, error) {
+	var sig os.Signal
+	select {
+	case sig = <-signals:
+		logger.LogAttrs(ctx, slog.LevelInfo, "shutting down", slog.String("signal", sig.String()))
+	case <-ctx.Done():
+		logger.LogAttrs(ctx, slog.LevelInfo, "shutting down", slog.String("reason", ctx.Err().Error()))
+	}
+
+	closeCtx := context.WithoutCancel(ctx)
+	if closeTimeout > 0 {
+		var cancel context.CancelFunc
+		closeCtx, cancel = context.WithTimeout(closeCtx, closeTimeout)
+		defer cancel()
+	}
+	return sig, registry.Close(closeCtx)
+}
@@ -0,0 +1,81 @@
+package slogs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitShutdown(t *testing.T) {
+	errFlush := errors.New("flush failed")
+
+	tests := []struct {
+		name      string
+		signal    bool
+		closeErr  error
+		wantSig   os.Signal
+		wantAttr  slog.Attr
+		wantError error
+	}{
+		{name: "signal", signal: true, wantSig: os.Interrupt, wantAttr: slog.String("signal", "interrupt")},
+		{name: "context done", wantAttr: slog.String("reason", "context canceled")},
+		{name: "flush error", signal: true, closeErr: errFlush, wantSig: os.Interrupt, wantAttr: slog.String("signal", "interrupt"), wantError: errFlush},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			logger := New(NewHandler(next))
+			registry := NewRegistry()
+			closed := false
+			require.NoError(t, registry.Register(CloserFunc(func(ctx context.Context) error {
+				closed = true
+				assert.NoError(t, ctx.Err(), "the registry is closed with a live context")
+				return tt.closeErr
+			})))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			signals := make(chan os.Signal, 1)
+			if tt.signal {
+				signals <- os.Interrupt
+			} else {
+				cancel()
+			}
+
+			sig, err := waitShutdown(ctx, signals, logger, registry, 0)
+
+			assert.Equal(t, tt.wantSig, sig)
+			assert.ErrorIs(t, err, tt.wantError)
+			assert.True(t, closed)
+			records := next.getRecords()
+			require.Len(t, records, 1)
+			assert.Equal(t, "shutting down", records[0].Message)
+			assert.True(t, recordHasAttr(records[0], tt.wantAttr.Key, tt.wantAttr.Value.String()))
+		})
+	}
+}
+
+func TestWaitShutdown_CloseTimeout(t *testing.T) {
+	logger := New(NewHandler(newTestHandler(true)))
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(CloserFunc(func(ctx context.Context) error {
+		// A hung sink only returns once the close context expires.
+		<-ctx.Done()
+		return ctx.Err()
+	})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err := waitShutdown(ctx, make(chan os.Signal), logger, registry, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,64 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// WithMaxSliceLength returns a new Handler that truncates the slice and array values of
+// attributes to their first n elements, followed by a "...N more" marker element giving the
+// number of elements left out, so that e.g. a list of 10k identifiers cannot blow up the size
+// of a record.
+//
+// Attributes are matched at every level, including inside groups. The truncated value is a
+// []any holding the kept elements and the marker. Only the length of values is inspected with
+// reflection and only the kept elements are copied, so the cost is bounded by n whatever the
+// size of the slice; elements are not inspected, so nested slices are kept whole. Byte slices,
+// which handlers render as text, and slices of at most n elements are left unchanged. If
+// n <= 0, h is returned.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithMaxSliceLength(3)
+//	logger := slog.New(handler)
+//	logger.Info("batch", "ids", []int{1, 2, 3, 4, 5}) // {"msg":"batch","ids":[1,2,3,"...2 more"]}
+func (h *Handler) WithMaxSliceLength(n int) *Handler {
+	if n <= 0 {
+		return h
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, replaceAttrs(nil, attrs, func(_ []string, a slog.Attr) slog.Attr {
+			if v, ok := truncateSlice(a.Value, n); ok {
+				a.Value = v
+			}
+			return a
+		})
+	})
+}
+
+// truncateSlice returns v truncated to n elements and a marker if v holds a longer slice or
+// array, other than a byte slice, and reports whether it did.
+func truncateSlice(v slog.Value, n int) (slog.Value, bool) {
+	if v.Kind() != slog.KindAny {
+		return v, false
+	}
+	if _, ok := v.Any().([]byte); ok {
+		return v, false
+	}
+
+	rv := reflect.ValueOf(v.Any())
+	if k := rv.Kind(); k != reflect.Slice && k != reflect.Array || rv.Len() <= n {
+		return v, false
+	}
+
+	kept := make([]any, n+1)
+	for i := 0; i < n; i++ {
+		kept[i] = rv.Index(i).Interface()
+	}
+	kept[n] = "..." + strconv.Itoa(rv.Len()-n) + " more"
+	return slog.AnyValue(kept), true
+}
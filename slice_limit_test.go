@@ -0,0 +1,100 @@
+package slogs
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_WithMaxSliceLength(t *testing.T) {
+	large := make([]int, 10000)
+	for i := range large {
+		large[i] = i
+	}
+
+	tests := []struct {
+		name     string
+		log      func(l *slog.Logger)
+		expected string
+	}{
+		{
+			name:     "large slice",
+			log:      func(l *slog.Logger) { l.Info("m", "ids", large) },
+			expected: `{"msg":"m","ids":[0,1,2,"...9997 more"]}`,
+		},
+		{
+			name:     "short slice unchanged",
+			log:      func(l *slog.Logger) { l.Info("m", "ids", []string{"a", "b", "c"}) },
+			expected: `{"msg":"m","ids":["a","b","c"]}`,
+		},
+		{
+			name:     "array",
+			log:      func(l *slog.Logger) { l.Info("m", "ids", [5]string{"a", "b", "c", "d", "e"}) },
+			expected: `{"msg":"m","ids":["a","b","c","...2 more"]}`,
+		},
+		{
+			name:     "nested groups",
+			log:      func(l *slog.Logger) { l.WithGroup("g").Info("m", slog.Group("h", "ids", large[:5], "n", 1)) },
+			expected: `{"msg":"m","g":{"h":{"ids":[0,1,2,"...2 more"],"n":1}}}`,
+		},
+		{
+			name:     "attributes from With",
+			log:      func(l *slog.Logger) { l.With("ids", large[:4]).Info("m") },
+			expected: `{"msg":"m","ids":[0,1,2,"...1 more"]}`,
+		},
+		{
+			name:     "nested slices kept whole",
+			log:      func(l *slog.Logger) { l.Info("m", "ids", [][]int{{1, 2, 3, 4}, {5}}) },
+			expected: `{"msg":"m","ids":[[1,2,3,4],[5]]}`,
+		},
+		{
+			name:     "bytes unchanged",
+			log:      func(l *slog.Logger) { l.Info("m", "raw", []byte("abcdef")) },
+			expected: `{"msg":"m","raw":"YWJjZGVm"}`,
+		},
+		{
+			name: "other values unchanged",
+			log: func(l *slog.Logger) {
+				l.Info("m", "s", "abcdef", "n", 12345, "map", map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+			},
+			expected: `{"msg":"m","s":"abcdef","n":12345,"map":{"a":1,"b":2,"c":3,"d":4}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTimeAndLevel})).WithMaxSliceLength(3)
+
+			tt.log(slog.New(h))
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestHandler_WithMaxSliceLength_Disabled(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+	assert.Same(t, h, h.WithMaxSliceLength(0))
+}
+
+func BenchmarkHandler_WithMaxSliceLength(b *testing.B) {
+	large := make([]int, 100000)
+	logger := slog.New(NewHandler(slog.NewJSONHandler(io.Discard, nil)).WithMaxSliceLength(10))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("m", "ids", large)
+	}
+}
+
+// dropTimeAndLevel removes the time and level from the output of slog handlers.
+func dropTimeAndLevel(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+		return slog.Attr{}
+	}
+	return a
+}
@@ -0,0 +1,159 @@
+// Package slogstest provides helpers for testing code that uses slogs.
+package slogstest
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a slogs.Clock whose time only moves when Advance is called, so
+// that the handlers working on time windows can be tested deterministically.
+//
+// Its tickers deliver their ticks from Advance rather than from a timer: a
+// ticker with period p created at time t ticks at t+p, t+2p, and so on, once
+// the clock has been advanced past those times. Likewise, the functions of
+// AfterFunc are called by Advance.
+//
+// Example:
+//
+//	clock := slogstest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	counting := slogs.NewCountingHandler(next, clock, nil, time.Minute)
+//	// ...
+//	clock.Advance(time.Minute) // the summary records are emitted
+type FakeClock struct {
+	// advanceMu serializes Advance calls, so that ticks are delivered in order.
+	advanceMu sync.Mutex
+
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// fakeTicker is a ticker of a FakeClock, or a timer of AfterFunc if f is set.
+type fakeTicker struct {
+	c      chan time.Time
+	period time.Duration
+	next   time.Time
+	f      func()
+}
+
+// NewFakeClock creates a FakeClock whose current time is start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a ticker whose channel only receives ticks from Advance.
+//
+// Stopping the ticker has no effect, so the clock must not be advanced past
+// its next tick once nothing receives from it anymore.
+//
+// Panics if d <= 0, like time.NewTicker.
+func (c *FakeClock) NewTicker(d time.Duration) *time.Ticker {
+	if d <= 0 {
+		panic("slogstest: non-positive interval for NewTicker")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTicker{c: make(chan time.Time), period: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	return &time.Ticker{C: t.c}
+}
+
+// AfterFunc calls f once the clock has been advanced by d, and returns a
+// function that stops the call, reporting whether it stopped it before it
+// happened. Unlike tickers, timers that fired or were stopped are forgotten.
+//
+// f is called by Advance, which waits for it to return, so f must not call
+// Advance.
+//
+// Panics if d <= 0.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) func() bool {
+	if d <= 0 {
+		panic("slogstest: non-positive duration for AfterFunc")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTicker{next: c.now.Add(d), f: f}
+	c.tickers = append(c.tickers, t)
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.remove(t)
+	}
+}
+
+// Advance moves the clock forward by d and delivers the ticks that fall within
+// that time, in time order, and calls the functions of AfterFunc that are due.
+//
+// Tick delivery is synchronous: Advance blocks until each tick is received,
+// and the clock is moved to the time of each tick before it is sent. Since a
+// receiver only gets back to its ticker after handling the previous tick, a
+// subsequent Advance delivering a tick to the same ticker also waits for the
+// previous tick to be handled.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.advanceMu.Lock()
+	defer c.advanceMu.Unlock()
+
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		t := c.due(end)
+		if t == nil {
+			c.now = end
+			c.mu.Unlock()
+			return
+		}
+		tick := t.next
+		c.now = tick
+		if t.f != nil {
+			c.remove(t)
+			c.mu.Unlock()
+			t.f()
+			continue
+		}
+		t.next = tick.Add(t.period)
+		c.mu.Unlock()
+
+		t.c <- tick
+	}
+}
+
+// remove forgets t, reporting whether it was still there. c.mu must be held.
+func (c *FakeClock) remove(t *fakeTicker) bool {
+	for i, other := range c.tickers {
+		if other == t {
+			c.tickers = append(c.tickers[:i], c.tickers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// due returns the ticker with the earliest tick not after end, or nil.
+// c.mu must be held.
+func (c *FakeClock) due(end time.Time) *fakeTicker {
+	var first *fakeTicker
+	for _, t := range c.tickers {
+		if t.next.After(end) {
+			continue
+		}
+		if first == nil || t.next.Before(first.next) {
+			first = t
+		}
+	}
+	return first
+}
@@ -0,0 +1,100 @@
+package slogstest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeClock_Now(t *testing.T) {
+	clock := NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), clock.Now())
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	clock := NewFakeClock(start)
+	ticker := clock.NewTicker(time.Minute)
+
+	// Nothing receives from the ticker, so Advance would block on a due tick.
+	clock.Advance(30 * time.Second)
+
+	advanced := make(chan struct{})
+	go func() {
+		defer close(advanced)
+		clock.Advance(90 * time.Second)
+	}()
+	assert.Equal(t, start.Add(time.Minute), <-ticker.C)
+	assert.Equal(t, start.Add(2*time.Minute), <-ticker.C)
+	<-advanced
+	assert.Equal(t, start.Add(2*time.Minute), clock.Now())
+
+	go clock.Advance(time.Minute)
+	assert.Equal(t, start.Add(3*time.Minute), <-ticker.C)
+}
+
+func TestFakeClock_TickerOrder(t *testing.T) {
+	clock := NewFakeClock(start)
+	fast := clock.NewTicker(time.Second)
+	slow := clock.NewTicker(3 * time.Second)
+
+	var got []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for len(got) < 4 {
+			select {
+			case <-fast.C:
+				got = append(got, "fast")
+			case <-slow.C:
+				got = append(got, "slow")
+			}
+		}
+	}()
+
+	clock.Advance(3 * time.Second)
+	<-done
+
+	// Both tickers tick at 3s; the earliest created one is delivered first.
+	assert.Equal(t, []string{"fast", "fast", "fast", "slow"}, got)
+}
+
+func TestFakeClock_NewTickerPanics(t *testing.T) {
+	clock := NewFakeClock(start)
+	assert.Panics(t, func() { clock.NewTicker(0) })
+	assert.Panics(t, func() { clock.NewTicker(-time.Second) })
+}
+
+func TestFakeClock_AfterFunc(t *testing.T) {
+	clock := NewFakeClock(start)
+
+	var fired []time.Time
+	clock.AfterFunc(time.Minute, func() { fired = append(fired, clock.Now()) })
+	stop := clock.AfterFunc(2*time.Minute, func() { t.Error("stopped timer fired") })
+
+	clock.Advance(30 * time.Second)
+	assert.Empty(t, fired)
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, []time.Time{start.Add(time.Minute)}, fired)
+
+	assert.True(t, stop())
+	assert.False(t, stop(), "the timer is already stopped")
+	clock.Advance(time.Hour)
+	assert.Len(t, fired, 1, "timers fire once")
+
+	assert.Panics(t, func() { clock.AfterFunc(0, func() {}) })
+}
+
+func TestFakeClock_AfterFuncStop(t *testing.T) {
+	clock := NewFakeClock(start)
+	stop := clock.AfterFunc(time.Minute, func() {})
+
+	clock.Advance(time.Minute)
+	assert.False(t, stop(), "the timer already fired")
+}
@@ -0,0 +1,93 @@
+package slogs
+
+import (
+	"log/slog"
+	"runtime"
+)
+
+// Keys of the source attributes emitted by a Handler configured with WithSourceInline.
+const (
+	SourceFileKey = "file"
+	SourceLineKey = "line"
+	SourceFuncKey = "func"
+)
+
+// sourceRendering describes how a Handler renders the caller of records itself, instead of
+// leaving it to the terminal handler.
+type sourceRendering struct {
+	key    string
+	inline bool
+}
+
+// WithSourceKey returns a new Handler that renders the caller of records itself, as a group
+// named key holding the function, file and line attributes of slog.Source, e.g. "caller"
+// instead of slog's "source".
+//
+// The record passed to the next handler no longer carries the caller, so the terminal handler
+// does not add its own source attribute even with slog.HandlerOptions.AddSource set. Since the
+// attributes are built by the middleware, they are rendered the same way by every sink. The
+// caller is only known for records logged with caller information, see WithCaller.
+//
+// An empty key restores the default: the caller is left to the terminal handler, unless
+// WithSourceInline is enabled.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(slog.NewJSONHandler(os.Stdout, nil)).WithSourceKey("caller")
+//	// {"level":"INFO","msg":"started","caller":{"function":"main.main","file":"/app/main.go","line":12}}
+func (h *Handler) WithSourceKey(key string) *Handler {
+	h2 := h.Clone()
+	src := sourceRendering{key: key}
+	if h.source != nil {
+		src.inline = h.source.inline
+	}
+	h2.source = src.orNil()
+	return h2
+}
+
+// WithSourceInline returns a new Handler that, when inline is true, renders the caller of
+// records as the root attributes file, line and func (see SourceFileKey, SourceLineKey and
+// SourceFuncKey) rather than as a group. It takes precedence over WithSourceKey.
+//
+// As with WithSourceKey, the caller is removed from the record passed to the next handler.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(slog.NewTextHandler(os.Stdout, nil)).WithSourceInline(true)
+//	// level=INFO msg=started file=/app/main.go line=12 func=main.main
+func (h *Handler) WithSourceInline(inline bool) *Handler {
+	h2 := h.Clone()
+	src := sourceRendering{inline: inline}
+	if h.source != nil {
+		src.key = h.source.key
+	}
+	h2.source = src.orNil()
+	return h2
+}
+
+// orNil returns nil if s leaves the caller to the terminal handler.
+func (s sourceRendering) orNil() *sourceRendering {
+	if s.key == "" && !s.inline {
+		return nil
+	}
+	return &s
+}
+
+// attrs returns the attributes rendering the caller at pc.
+func (s *sourceRendering) attrs(pc uintptr) []slog.Attr {
+	fs := runtime.CallersFrames([]uintptr{pc})
+	f, _ := fs.Next()
+
+	if s.inline {
+		return []slog.Attr{
+			slog.String(SourceFileKey, f.File),
+			slog.Int(SourceLineKey, f.Line),
+			slog.String(SourceFuncKey, f.Function),
+		}
+	}
+	return []slog.Attr{slog.Group(s.key,
+		slog.String("function", f.Function),
+		slog.String("file", f.File),
+		slog.Int("line", f.Line),
+	)}
+}
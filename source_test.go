@@ -0,0 +1,87 @@
+package slogs
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_WithSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(h *Handler) *Handler
+		check   func(t *testing.T, entry map[string]any)
+	}{
+		{
+			name:    "default",
+			handler: func(h *Handler) *Handler { return h },
+			check: func(t *testing.T, entry map[string]any) {
+				assert.Contains(t, entry, slog.SourceKey)
+			},
+		},
+		{
+			name:    "renamed group",
+			handler: func(h *Handler) *Handler { return h.WithSourceKey("caller") },
+			check: func(t *testing.T, entry map[string]any) {
+				assert.NotContains(t, entry, slog.SourceKey)
+				caller, ok := entry["caller"].(map[string]any)
+				require.True(t, ok)
+				assert.Contains(t, caller["file"], "source_test.go")
+				assert.Contains(t, caller["function"], "TestHandler_WithSource")
+				assert.NotZero(t, caller["line"])
+			},
+		},
+		{
+			name:    "inline",
+			handler: func(h *Handler) *Handler { return h.WithSourceInline(true) },
+			check: func(t *testing.T, entry map[string]any) {
+				assert.NotContains(t, entry, slog.SourceKey)
+				assert.Contains(t, entry[SourceFileKey], "source_test.go")
+				assert.Contains(t, entry[SourceFuncKey], "TestHandler_WithSource")
+				assert.NotZero(t, entry[SourceLineKey])
+			},
+		},
+		{
+			name:    "inline takes precedence",
+			handler: func(h *Handler) *Handler { return h.WithSourceKey("caller").WithSourceInline(true) },
+			check: func(t *testing.T, entry map[string]any) {
+				assert.NotContains(t, entry, "caller")
+				assert.Contains(t, entry, SourceFileKey)
+			},
+		},
+		{
+			name:    "reset",
+			handler: func(h *Handler) *Handler { return h.WithSourceKey("caller").WithSourceKey("") },
+			check: func(t *testing.T, entry map[string]any) {
+				assert.NotContains(t, entry, "caller")
+				assert.Contains(t, entry, slog.SourceKey)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := tt.handler(NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true})))
+
+			New(h, WithCaller(true)).With("a", 1).WithGroup("g").Info("msg", "b", 2)
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			tt.check(t, entry)
+		})
+	}
+}
+
+func TestHandler_WithSource_NoCaller(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(buf, nil)).WithSourceInline(true)
+
+	New(h, WithCaller(false)).Info("msg")
+
+	assert.NotContains(t, buf.String(), SourceFileKey)
+}
@@ -0,0 +1,510 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	_ slog.Handler = (*SpillHandler)(nil)
+	_ Closer       = (*SpillHandler)(nil)
+)
+
+// spillExt is the extension of the segment files of a spill directory.
+const spillExt = ".spill"
+
+// SpillOptions configures a SpillHandler. The zero value uses the defaults.
+type SpillOptions struct {
+	// MaxBytes bounds the disk space used by spilled records. When it is exceeded, the oldest
+	// segments are deleted along with their records. Defaults to 64 MiB.
+	MaxBytes int64
+
+	// SegmentBytes is the size at which a segment file is closed and a new one started; records
+	// are deleted a segment at a time. Defaults to 1 MiB.
+	SegmentBytes int64
+
+	// RetryInterval is how often spilled records are replayed into the primary handler.
+	// Defaults to 5 seconds.
+	RetryInterval time.Duration
+
+	// MaxAttempts is the number of failed replays of a record after which it is dropped, so
+	// that a record the primary handler always rejects does not block the records spilled
+	// after it. Defaults to 10.
+	MaxAttempts int
+
+	// DropReporter, if set, is notified of the records dropped after MaxAttempts failed
+	// replays, with ReasonReplayFailed.
+	DropReporter DropReporter
+
+	// Clock drives RetryInterval. Defaults to DefaultClock.
+	Clock Clock
+}
+
+// SpillHandler passes records to a primary handler, typically sending them to a remote log
+// sink, and spills them to a directory on disk when the primary handler fails.
+//
+// Spilled records are stored in segment files and replayed into the primary handler in order
+// by a background goroutine every RetryInterval, or by Drain; records handled while records
+// are spilled are spilled too, so that order is preserved. Segments are deleted once replayed.
+// Disk usage is bounded by MaxBytes by deleting the oldest segments; the segment being replayed
+// is never deleted, so usage may exceed MaxBytes by up to one segment.
+//
+// Spilled records survive a crash or restart: NewSpillHandler picks up the segments left in the
+// directory. A record may be delivered twice if the process stops while its segment is being
+// replayed, and incomplete lines left by a crash are skipped. Records are written without
+// fsync, so they survive a process crash but not necessarily a power loss.
+//
+// A record the primary handler fails to handle MaxAttempts times in a row while it is replayed
+// is dropped and reported to the DropReporter. Attempts are counted in memory, so they start
+// over when the directory is picked up by a new SpillHandler.
+//
+// Spilled records are replayed with the attributes and groups of the handler that spilled them.
+// LogValuers are resolved and values of kinds other than slog's basic kinds are replayed as
+// strings; the record's PC is not kept.
+//
+// Close must be called to stop the background goroutine.
+//
+// Example:
+//
+//	spill, err := slogs.NewSpillHandler(remote, "/var/lib/app/log-spill", &slogs.SpillOptions{MaxBytes: 1 << 30})
+//	if err != nil {
+//		return err
+//	}
+//	defer spill.Close(context.Background())
+//	logger := slogs.New(slogs.NewHandler(spill))
+type SpillHandler struct {
+	next  slog.Handler
+	ops   []spilledOp
+	queue *spillQueue
+}
+
+// spillQueue is the on-disk queue shared by a SpillHandler and the handlers derived from it.
+type spillQueue struct {
+	root         slog.Handler
+	dir          string
+	maxBytes     int64
+	segmentBytes int64
+	maxAttempts  int
+	reporter     DropReporter
+
+	mu       sync.Mutex
+	segments []spillSegment
+	total    int64
+	seq      uint64
+	// file is the open last segment, or nil if the last segment is closed.
+	file *os.File
+	// draining is the name of the segment being replayed, which is never deleted by the cap,
+	// and offset is the number of its bytes already replayed.
+	draining string
+	offset   int
+	// failedAt is the offset in the draining segment of the record that failed to be replayed
+	// attempts times in a row.
+	failedAt int
+	attempts int
+	closed   bool
+
+	// pending reports whether there are segments, so that Handle does not take the lock
+	// while nothing is spilled.
+	pending atomic.Bool
+
+	// drainMu serializes replays.
+	drainMu sync.Mutex
+
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// spillSegment is a segment file of the queue.
+type spillSegment struct {
+	name string
+	size int64
+}
+
+// NewSpillHandler creates a SpillHandler passing records to primary and spilling them to dir,
+// which is created if needed. Segments left in dir by a previous process are replayed first.
+// opts may be nil.
+//
+// Panics if primary is nil.
+func NewSpillHandler(primary slog.Handler, dir string, opts *SpillOptions) (*SpillHandler, error) {
+	if primary == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+	if opts == nil {
+		opts = &SpillOptions{}
+	}
+
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = 64 << 20
+	}
+	segmentBytes := opts.SegmentBytes
+	if segmentBytes <= 0 {
+		segmentBytes = 1 << 20
+	}
+	retryInterval := opts.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = 5 * time.Second
+	}
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+	clock := opts.Clock
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	q := &spillQueue{
+		root:         primary,
+		dir:          dir,
+		maxBytes:     maxBytes,
+		segmentBytes: segmentBytes,
+		maxAttempts:  maxAttempts,
+		reporter:     opts.DropReporter,
+		done:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+	if err := q.recover(); err != nil {
+		return nil, err
+	}
+
+	go q.run(clock.NewTicker(retryInterval))
+	return &SpillHandler{next: primary, queue: q}, nil
+}
+
+// Drain replays the spilled records into the primary handler until none is left.
+//
+// It stops at the first error returned by the primary handler, leaving the records from the
+// failed one on disk, and returns the error, unless the record failed MaxAttempts times: it is
+// then dropped and the replay goes on. Drain is called periodically by the background
+// goroutine; calling it directly, e.g. before shutting down, is safe.
+func (h *SpillHandler) Drain(ctx context.Context) error {
+	return h.queue.drain(ctx)
+}
+
+// Close stops the background goroutine and closes the current segment file. Records still
+// spilled stay on disk for the next SpillHandler using the directory.
+//
+// It returns ctx.Err() if ctx is done before the background goroutine stops. Records handled
+// after Close fail with ErrHandlerClosed if they need to be spilled.
+func (h *SpillHandler) Close(ctx context.Context) error {
+	q := h.queue
+	q.once.Do(func() { close(q.done) })
+
+	select {
+	case <-q.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	return q.closeFile()
+}
+
+// Enabled reports whether the primary handler handles records at the given level.
+func (h *SpillHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes r to the primary handler, or spills it if the primary handler fails or
+// records are already spilled. It only returns an error if r cannot be spilled.
+func (h *SpillHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.queue.pending.Load() {
+		err := h.next.Handle(ctx, r)
+		if err == nil {
+			return nil
+		}
+	}
+
+	line, err := encodeSpilled(h.ops, r)
+	if err != nil {
+		return err
+	}
+	return h.queue.append(line)
+}
+
+// WithAttrs returns a SpillHandler sharing the same spill directory whose primary handler has the given attributes.
+func (h *SpillHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	h2.ops = append(slices.Clip(h.ops), spilledOp{Attrs: spillAttrs(attrs)})
+	return &h2
+}
+
+// WithGroup returns a SpillHandler sharing the same spill directory whose primary handler has the given group.
+func (h *SpillHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	h2.ops = append(slices.Clip(h.ops), spilledOp{Group: name})
+	return &h2
+}
+
+// recover loads the segments left in the directory.
+func (q *spillQueue) recover() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+
+	type found struct {
+		seq uint64
+		seg spillSegment
+	}
+	var segments []found
+	for _, e := range entries {
+		name := e.Name()
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, spillExt), 10, 64)
+		if e.IsDir() || !strings.HasSuffix(name, spillExt) || err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		segments = append(segments, found{seq: seq, seg: spillSegment{name: name, size: info.Size()}})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].seq < segments[j].seq })
+
+	for _, f := range segments {
+		q.segments = append(q.segments, f.seg)
+		q.total += f.seg.size
+		q.seq = f.seq
+	}
+	q.pending.Store(len(q.segments) > 0)
+	return nil
+}
+
+// append writes a line to the last segment, starting a new one if needed, and enforces the cap.
+func (q *spillQueue) append(line []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrHandlerClosed
+	}
+
+	if q.file == nil || q.segments[len(q.segments)-1].size+int64(len(line)) > q.segmentBytes {
+		if err := q.closeFile(); err != nil {
+			return err
+		}
+		q.seq++
+		name := fmt.Sprintf("%020d%s", q.seq, spillExt)
+		f, err := os.OpenFile(filepath.Join(q.dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		q.file = f
+		q.segments = append(q.segments, spillSegment{name: name})
+	}
+
+	n, err := q.file.Write(line)
+	q.segments[len(q.segments)-1].size += int64(n)
+	q.total += int64(n)
+	q.pending.Store(true)
+	if err != nil {
+		return err
+	}
+
+	return q.enforceCap()
+}
+
+// enforceCap deletes the oldest segments until the queue fits in maxBytes, sparing the
+// segment being replayed and the one being written. q.mu must be held.
+func (q *spillQueue) enforceCap() error {
+	for i := 0; q.total > q.maxBytes && i < len(q.segments)-1; {
+		seg := q.segments[i]
+		if seg.name == q.draining {
+			i++
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(q.dir, seg.name)); err != nil {
+			return err
+		}
+		q.segments = slices.Delete(q.segments, i, i+1)
+		q.total -= seg.size
+	}
+	return nil
+}
+
+// closeFile closes the last segment so that it can be replayed. q.mu must be held.
+func (q *spillQueue) closeFile() error {
+	if q.file == nil {
+		return nil
+	}
+	err := q.file.Close()
+	q.file = nil
+	return err
+}
+
+// drain replays the segments in order, deleting each one once it is replayed.
+func (q *spillQueue) drain(ctx context.Context) error {
+	q.drainMu.Lock()
+	defer q.drainMu.Unlock()
+
+	for {
+		q.mu.Lock()
+		if len(q.segments) == 0 {
+			q.pending.Store(false)
+			q.mu.Unlock()
+			return nil
+		}
+		seg := q.segments[0]
+		if len(q.segments) == 1 {
+			if err := q.closeFile(); err != nil {
+				q.mu.Unlock()
+				return err
+			}
+		}
+		if q.draining != seg.name {
+			q.draining, q.offset = seg.name, 0
+			q.failedAt, q.attempts = 0, 0
+		}
+		offset := q.offset
+		q.mu.Unlock()
+
+		data, err := os.ReadFile(filepath.Join(q.dir, seg.name))
+		if err != nil {
+			return err
+		}
+		if offset, err = q.replay(ctx, data, offset); err != nil {
+			q.mu.Lock()
+			q.offset = offset
+			q.mu.Unlock()
+			return err
+		}
+
+		q.mu.Lock()
+		err = os.Remove(filepath.Join(q.dir, seg.name))
+		if err == nil || errors.Is(err, os.ErrNotExist) {
+			err = nil
+			q.segments = q.segments[1:]
+			q.total -= seg.size
+			q.draining, q.offset = "", 0
+			q.failedAt, q.attempts = 0, 0
+		}
+		q.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// replay passes the records of data from offset to the primary handler. It returns the
+// offset of the first record that was not replayed, or len(data).
+func (q *spillQueue) replay(ctx context.Context, data []byte, offset int) (int, error) {
+	for offset < len(data) {
+		line := data[offset:]
+		end := bytes.IndexByte(line, '\n')
+		if end < 0 {
+			// An incomplete line left by a crash.
+			return len(data), nil
+		}
+		line = line[:end]
+
+		// Records that cannot be decoded, e.g. corrupted on disk, are skipped.
+		if next, r, err := q.decode(line); err == nil {
+			if err := next.Handle(ctx, r); err != nil {
+				if !q.exhausted(offset) {
+					return offset, err
+				}
+				if q.reporter != nil {
+					q.reporter.Dropped(r.Level, ReasonReplayFailed, 1)
+				}
+			}
+		}
+		offset += end + 1
+	}
+	return offset, nil
+}
+
+// exhausted counts a failed replay of the record at offset in the draining segment, and reports
+// whether it reached the maximum number of attempts and must be dropped.
+func (q *spillQueue) exhausted(offset int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.attempts > 0 && q.failedAt == offset {
+		q.attempts++
+	} else {
+		q.failedAt, q.attempts = offset, 1
+	}
+	if q.attempts < q.maxAttempts {
+		return false
+	}
+	q.attempts = 0
+	return true
+}
+
+// decode decodes a spilled record along with the handler to replay it into.
+func (q *spillQueue) decode(line []byte) (slog.Handler, slog.Record, error) {
+	ops, r, err := decodeSpilled(line)
+	if err != nil {
+		return nil, slog.Record{}, err
+	}
+
+	next := q.root
+	for _, op := range ops {
+		if op.Group != "" {
+			next = next.WithGroup(op.Group)
+			continue
+		}
+		attrs, err := unspillAttrs(op.Attrs)
+		if err != nil {
+			return nil, slog.Record{}, err
+		}
+		next = next.WithAttrs(attrs)
+	}
+	return next, r, nil
+}
+
+func (q *spillQueue) run(ticker *time.Ticker) {
+	defer close(q.stopped)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !q.pending.Load() {
+				continue
+			}
+			if err := q.drain(context.Background()); err != nil {
+				// The primary handler still fails: the records stay spilled until the next tick.
+				continue
+			}
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *SpillHandler) unwrap() slog.Handler {
+	return h.next
+}
@@ -0,0 +1,267 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSinkDown = errors.New("sink down")
+
+// flakyHandler writes text lines to a shared buffer and fails while failing is set.
+type flakyHandler struct {
+	slog.Handler
+	failing *atomic.Bool
+	out     *lockedBuffer
+}
+
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newFlakyHandler() *flakyHandler {
+	out := &lockedBuffer{}
+	return &flakyHandler{
+		Handler: slog.NewTextHandler(out, &slog.HandlerOptions{ReplaceAttr: dropTime}),
+		failing: &atomic.Bool{},
+		out:     out,
+	}
+}
+
+func (h *flakyHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.failing.Load() {
+		return errSinkDown
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *flakyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &flakyHandler{Handler: h.Handler.WithAttrs(attrs), failing: h.failing, out: h.out}
+}
+
+func (h *flakyHandler) WithGroup(name string) slog.Handler {
+	return &flakyHandler{Handler: h.Handler.WithGroup(name), failing: h.failing, out: h.out}
+}
+
+func spillFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+spillExt))
+	require.NoError(t, err)
+	return matches
+}
+
+func TestNewSpillHandler_NilPrimary(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewSpillHandler(nil, t.TempDir(), nil)
+	})
+}
+
+func TestSpillHandler_SpillAndDrain(t *testing.T) {
+	primary := newFlakyHandler()
+	dir := t.TempDir()
+	h, err := NewSpillHandler(primary, dir, &SpillOptions{Clock: newFakeClock()})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, h.Close(context.Background())) })
+	logger := New(NewHandler(h))
+
+	logger.Info("before")
+	primary.failing.Store(true)
+	logger.With("k", "v").WithGroup("g").Warn("spilled", "n", 1)
+	primary.failing.Store(false)
+	logger.Info("after") // spilled too, to keep the order
+
+	assert.Equal(t, "level=INFO msg=before\n", primary.out.String())
+	assert.Len(t, spillFiles(t, dir), 1)
+
+	require.NoError(t, h.Drain(context.Background()))
+	assert.Equal(t, ""+
+		"level=INFO msg=before\n"+
+		"level=WARN msg=spilled k=v g.n=1\n"+
+		"level=INFO msg=after\n",
+		primary.out.String())
+	assert.Empty(t, spillFiles(t, dir))
+
+	logger.Info("direct")
+	assert.Contains(t, primary.out.String(), "msg=direct")
+}
+
+func TestSpillHandler_DrainStopsOnError(t *testing.T) {
+	primary := newFlakyHandler()
+	h, err := NewSpillHandler(primary, t.TempDir(), &SpillOptions{Clock: newFakeClock()})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, h.Close(context.Background())) })
+	logger := New(NewHandler(h))
+
+	primary.failing.Store(true)
+	logger.Info("a")
+	logger.Info("b")
+
+	assert.ErrorIs(t, h.Drain(context.Background()), errSinkDown)
+	assert.Empty(t, primary.out.String())
+
+	primary.failing.Store(false)
+	require.NoError(t, h.Drain(context.Background()))
+	assert.Equal(t, "level=INFO msg=a\nlevel=INFO msg=b\n", primary.out.String())
+}
+
+// poisonHandler rejects the records with the message "poison" and passes the others to next.
+type poisonHandler struct {
+	*flakyHandler
+}
+
+func (h poisonHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Message == "poison" {
+		return errSinkDown
+	}
+	return h.flakyHandler.Handle(ctx, r)
+}
+
+func TestSpillHandler_PoisonRecord(t *testing.T) {
+	primary := newFlakyHandler()
+	var dropped []string
+	h, err := NewSpillHandler(poisonHandler{primary}, t.TempDir(), &SpillOptions{
+		Clock:       newFakeClock(),
+		MaxAttempts: 3,
+		DropReporter: DropReporterFunc(func(level slog.Level, reason string, n int) {
+			dropped = append(dropped, level.String()+" "+reason)
+		}),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, h.Close(context.Background())) })
+	logger := New(NewHandler(h))
+
+	logger.Error("poison")
+	logger.Info("after")
+
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, h.Drain(context.Background()), errSinkDown)
+	}
+	assert.Empty(t, primary.out.String())
+	assert.Empty(t, dropped)
+
+	require.NoError(t, h.Drain(context.Background()), "the record is dropped after MaxAttempts failures")
+	assert.Equal(t, "level=INFO msg=after\n", primary.out.String())
+	assert.Equal(t, []string{"ERROR replay_failed"}, dropped)
+
+	logger.Info("direct")
+	assert.Contains(t, primary.out.String(), "msg=direct", "records are no longer spilled")
+}
+
+func TestSpillHandler_RetryLoop(t *testing.T) {
+	primary := newFlakyHandler()
+	clock := newFakeClock()
+	h, err := NewSpillHandler(primary, t.TempDir(), &SpillOptions{Clock: clock, RetryInterval: time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, h.Close(context.Background())) })
+	logger := New(NewHandler(h))
+
+	primary.failing.Store(true)
+	logger.Info("spilled")
+	clock.Advance(time.Second)
+	clock.Advance(time.Second) // the first tick was handled, the primary handler still fails
+	assert.Empty(t, primary.out.String())
+
+	primary.failing.Store(false)
+	clock.Advance(time.Second)
+	assert.Eventually(t, func() bool {
+		return primary.out.String() == "level=INFO msg=spilled\n"
+	}, time.Second, time.Millisecond)
+}
+
+func TestSpillHandler_DropOldest(t *testing.T) {
+	primary := newFlakyHandler()
+	dir := t.TempDir()
+	line, err := encodeSpilled(nil, slog.NewRecord(time.Time{}, slog.LevelInfo, "msg-0", 0))
+	require.NoError(t, err)
+	size := int64(len(line))
+
+	h, err := NewSpillHandler(primary, dir, &SpillOptions{
+		Clock:        newFakeClock(),
+		SegmentBytes: 2 * size,
+		MaxBytes:     4 * size,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, h.Close(context.Background())) })
+
+	primary.failing.Store(true)
+	for _, msg := range []string{"msg-0", "msg-1", "msg-2", "msg-3", "msg-4", "msg-5", "msg-6"} {
+		require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, msg, 0)))
+	}
+	assert.Len(t, spillFiles(t, dir), 2)
+
+	primary.failing.Store(false)
+	require.NoError(t, h.Drain(context.Background()))
+	assert.Equal(t, "level=INFO msg=msg-4\nlevel=INFO msg=msg-5\nlevel=INFO msg=msg-6\n", primary.out.String())
+}
+
+func TestSpillHandler_Recovery(t *testing.T) {
+	dir := t.TempDir()
+
+	primary := newFlakyHandler()
+	primary.failing.Store(true)
+	h, err := NewSpillHandler(primary, dir, &SpillOptions{Clock: newFakeClock()})
+	require.NoError(t, err)
+	New(NewHandler(h)).With("k", "v").Info("first")
+	require.NoError(t, h.Close(context.Background()))
+	assert.ErrorIs(t, h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "closed", 0)), ErrHandlerClosed)
+
+	// Simulate a crash in the middle of a write.
+	files := spillFiles(t, dir)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"t":"2024`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	primary = newFlakyHandler()
+	h, err = NewSpillHandler(primary, dir, &SpillOptions{Clock: newFakeClock()})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, h.Close(context.Background())) })
+	logger := New(NewHandler(h))
+
+	logger.Info("second") // queued after the recovered records
+	assert.Empty(t, primary.out.String())
+
+	require.NoError(t, h.Drain(context.Background()))
+	assert.Equal(t, "level=INFO msg=first k=v\nlevel=INFO msg=second\n", primary.out.String())
+	assert.Empty(t, spillFiles(t, dir))
+}
+
+func TestSpillHandler_IgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad"+spillExt), []byte("x"), 0o600))
+
+	primary := newFlakyHandler()
+	h, err := NewSpillHandler(primary, dir, &SpillOptions{Clock: newFakeClock()})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, h.Close(context.Background())) })
+
+	New(NewHandler(h)).Info("direct")
+	assert.True(t, strings.HasSuffix(primary.out.String(), "msg=direct\n"))
+}
@@ -0,0 +1,178 @@
+package slogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// spilledRecord is the on-disk form of a record spilled by a SpillHandler, one JSON object per line.
+type spilledRecord struct {
+	// Ops are the WithAttrs and WithGroup calls the record went through, to replay it on
+	// an equivalent handler.
+	Ops     []spilledOp   `json:"o,omitempty"`
+	Time    string        `json:"t"`
+	Level   slog.Level    `json:"l"`
+	Message string        `json:"m"`
+	Attrs   []spilledAttr `json:"a,omitempty"`
+}
+
+// spilledOp is a WithGroup call if Group is set, and a WithAttrs call otherwise.
+type spilledOp struct {
+	Group string        `json:"g,omitempty"`
+	Attrs []spilledAttr `json:"a,omitempty"`
+}
+
+// spilledAttr is an attribute whose value is stored as a string tagged with its kind,
+// or as a list of attributes for groups.
+type spilledAttr struct {
+	Key   string        `json:"k"`
+	Kind  string        `json:"t"`
+	Value string        `json:"v,omitempty"`
+	Group []spilledAttr `json:"g,omitempty"`
+}
+
+// Kinds of spilled attribute values. Values of other kinds are stored as strings.
+const (
+	spillString   = "s"
+	spillInt64    = "i"
+	spillUint64   = "u"
+	spillFloat64  = "f"
+	spillBool     = "b"
+	spillDuration = "d"
+	spillTime     = "t"
+	spillGroup    = "g"
+)
+
+// encodeSpilled encodes r, handled after ops, as a line.
+func encodeSpilled(ops []spilledOp, r slog.Record) ([]byte, error) {
+	sr := spilledRecord{
+		Ops:     ops,
+		Time:    r.Time.Format(time.RFC3339Nano),
+		Level:   r.Level,
+		Message: r.Message,
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		sr.Attrs = append(sr.Attrs, spillAttr(a))
+		return true
+	})
+
+	line, err := json.Marshal(sr)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+// decodeSpilled decodes a line written by encodeSpilled.
+func decodeSpilled(line []byte) (ops []spilledOp, r slog.Record, err error) {
+	var sr spilledRecord
+	if err := json.Unmarshal(line, &sr); err != nil {
+		return nil, slog.Record{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, sr.Time)
+	if err != nil {
+		return nil, slog.Record{}, err
+	}
+	attrs, err := unspillAttrs(sr.Attrs)
+	if err != nil {
+		return nil, slog.Record{}, err
+	}
+
+	r = slog.NewRecord(t, sr.Level, sr.Message, 0)
+	r.AddAttrs(attrs...)
+	return sr.Ops, r, nil
+}
+
+// spillAttrs converts attrs to their on-disk form.
+func spillAttrs(attrs []slog.Attr) []spilledAttr {
+	out := make([]spilledAttr, 0, len(attrs))
+	for _, a := range attrs {
+		out = append(out, spillAttr(a))
+	}
+	return out
+}
+
+// spillAttr converts a to its on-disk form, resolving LogValuers.
+func spillAttr(a slog.Attr) spilledAttr {
+	v := a.Value.Resolve()
+	sa := spilledAttr{Key: a.Key}
+
+	switch v.Kind() {
+	case slog.KindGroup:
+		sa.Kind = spillGroup
+		sa.Group = spillAttrs(v.Group())
+	case slog.KindInt64:
+		sa.Kind, sa.Value = spillInt64, strconv.FormatInt(v.Int64(), 10)
+	case slog.KindUint64:
+		sa.Kind, sa.Value = spillUint64, strconv.FormatUint(v.Uint64(), 10)
+	case slog.KindFloat64:
+		sa.Kind, sa.Value = spillFloat64, strconv.FormatFloat(v.Float64(), 'g', -1, 64)
+	case slog.KindBool:
+		sa.Kind, sa.Value = spillBool, strconv.FormatBool(v.Bool())
+	case slog.KindDuration:
+		sa.Kind, sa.Value = spillDuration, strconv.FormatInt(int64(v.Duration()), 10)
+	case slog.KindTime:
+		sa.Kind, sa.Value = spillTime, v.Time().Format(time.RFC3339Nano)
+	case slog.KindString:
+		sa.Kind, sa.Value = spillString, v.String()
+	default:
+		sa.Kind = spillString
+		if err, ok := v.Any().(error); ok {
+			sa.Value = err.Error()
+		} else {
+			sa.Value = fmt.Sprintf("%+v", v.Any())
+		}
+	}
+	return sa
+}
+
+// unspillAttrs converts attributes from their on-disk form.
+func unspillAttrs(sas []spilledAttr) ([]slog.Attr, error) {
+	attrs := make([]slog.Attr, 0, len(sas))
+	for _, sa := range sas {
+		a, err := unspillAttr(sa)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs, nil
+}
+
+// unspillAttr converts an attribute from its on-disk form.
+func unspillAttr(sa spilledAttr) (slog.Attr, error) {
+	switch sa.Kind {
+	case spillGroup:
+		attrs, err := unspillAttrs(sa.Group)
+		if err != nil {
+			return slog.Attr{}, err
+		}
+		return slog.Attr{Key: sa.Key, Value: slog.GroupValue(attrs...)}, nil
+	case spillInt64:
+		n, err := strconv.ParseInt(sa.Value, 10, 64)
+		return slog.Int64(sa.Key, n), err
+	case spillUint64:
+		n, err := strconv.ParseUint(sa.Value, 10, 64)
+		return slog.Uint64(sa.Key, n), err
+	case spillFloat64:
+		f, err := strconv.ParseFloat(sa.Value, 64)
+		return slog.Float64(sa.Key, f), err
+	case spillBool:
+		b, err := strconv.ParseBool(sa.Value)
+		return slog.Bool(sa.Key, b), err
+	case spillDuration:
+		n, err := strconv.ParseInt(sa.Value, 10, 64)
+		return slog.Duration(sa.Key, time.Duration(n)), err
+	case spillTime:
+		t, err := time.Parse(time.RFC3339Nano, sa.Value)
+		return slog.Time(sa.Key, t), err
+	case spillString:
+		return slog.String(sa.Key, sa.Value), nil
+	default:
+		return slog.Attr{}, fmt.Errorf("slogs: unknown spilled attribute kind %q", sa.Kind)
+	}
+}
@@ -0,0 +1,79 @@
+package slogs
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpilledRecord_RoundTrip(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want slog.Attr
+	}{
+		{name: "string", attr: slog.String("k", "v"), want: slog.String("k", "v")},
+		{name: "int", attr: slog.Int64("k", -1<<62), want: slog.Int64("k", -1<<62)},
+		{name: "uint", attr: slog.Uint64("k", 1<<63), want: slog.Uint64("k", 1<<63)},
+		{name: "float", attr: slog.Float64("k", 0.1), want: slog.Float64("k", 0.1)},
+		{name: "bool", attr: slog.Bool("k", true), want: slog.Bool("k", true)},
+		{name: "duration", attr: slog.Duration("k", time.Second), want: slog.Duration("k", time.Second)},
+		{name: "time", attr: slog.Time("k", now), want: slog.Time("k", now)},
+		{name: "error", attr: slog.Any("k", errors.New("boom")), want: slog.String("k", "boom")},
+		{name: "any", attr: slog.Any("k", []int{1, 2}), want: slog.String("k", "[1 2]")},
+		{name: "log valuer", attr: slog.Any("k", Metric("m", 1, "Count").Value.Any()), want: slog.Group("k", slog.Float64("value", 1), slog.String("unit", "Count"))},
+		{name: "group", attr: slog.Group("g", slog.Int("n", 1), slog.Group("h", slog.String("s", "x"))), want: slog.Group("g", slog.Int("n", 1), slog.Group("h", slog.String("s", "x")))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := slog.NewRecord(now, slog.LevelWarn, "msg", 0)
+			r.AddAttrs(tt.attr)
+			ops := []spilledOp{{Attrs: spillAttrs([]slog.Attr{slog.String("a", "b")})}, {Group: "g"}}
+
+			line, err := encodeSpilled(ops, r)
+			require.NoError(t, err)
+			assert.Equal(t, byte('\n'), line[len(line)-1])
+
+			gotOps, got, err := decodeSpilled(line)
+			require.NoError(t, err)
+			assert.Equal(t, ops, gotOps)
+			assert.True(t, now.Equal(got.Time))
+			assert.Equal(t, slog.LevelWarn, got.Level)
+			assert.Equal(t, "msg", got.Message)
+
+			var attrs []slog.Attr
+			got.Attrs(func(a slog.Attr) bool {
+				attrs = append(attrs, a)
+				return true
+			})
+			require.Len(t, attrs, 1)
+			assert.True(t, tt.want.Equal(attrs[0]), "got %v", attrs[0])
+		})
+	}
+}
+
+func TestDecodeSpilled_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "not json", line: `{"t":`},
+		{name: "bad time", line: `{"t":"yesterday","l":0,"m":"msg"}`},
+		{name: "bad kind", line: `{"t":"2024-01-01T00:00:00Z","l":0,"m":"msg","a":[{"k":"k","t":"?"}]}`},
+		{name: "bad int", line: `{"t":"2024-01-01T00:00:00Z","l":0,"m":"msg","a":[{"k":"k","t":"i","v":"x"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeSpilled([]byte(tt.line))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package slogs
+
+import (
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/rockcookies/go-slogs/internal/bufferpool"
+	"github.com/rockcookies/go-slogs/internal/stacktrace"
+)
+
+// Keys of the attributes added by Handler.WithStackOnce.
+const (
+	StackKey      = "stack"
+	StackRefKey   = "stack_ref"
+	OccurrenceKey = "occurrence"
+)
+
+// stackOnce tracks the error fingerprints whose stack was logged, see Handler.WithStackOnce.
+type stackOnce struct {
+	window time.Duration
+	clock  Clock
+
+	mu      sync.Mutex
+	entries map[string]*stackOnceEntry
+	// nextSweep is when expired entries are next removed.
+	nextSweep time.Time
+}
+
+// stackOnceEntry is the current window of a fingerprint.
+type stackOnceEntry struct {
+	end time.Time
+	n   int
+}
+
+// WithStackOnce returns a new Handler that adds a stack trace to error records, but only to the
+// first occurrence of an error in a window, so that a recurring error does not flood the logs
+// with identical stacks.
+//
+// Records at slog.LevelError or above holding an error attribute, including those added with
+// With or from the context and those in groups, are identified by the same fingerprint as
+// WithFingerprint's default: the message template and the types of the errors.
+// The first record of a fingerprint starts a window of the given duration, measured with clock,
+// or DefaultClock if clock is nil, and gets the stack trace as a StackKey attribute. The
+// following records of the window get an OccurrenceKey attribute counting the occurrences
+// instead, starting at 2. All of them get a StackRefKey attribute holding the fingerprint, so
+// that the stack of an occurrence can be found:
+//
+//	level=ERROR msg="db query failed" error="connection refused" stack="main.run\n\t/app/main.go:42\n..." stack_ref=8c3f0b5d2a1e4f67
+//	level=ERROR msg="db query failed" error="connection refused" stack_ref=8c3f0b5d2a1e4f67 occurrence=2
+//
+// The stack starts at the caller of the logging method if the record has a program counter, as
+// with WithCaller(true), and at the caller of Handle otherwise. Records without an error
+// attribute are left unchanged. Combined with WithErrorCoalescing, records suppressed by the
+// coalescing are not counted, and summaries carry no stack. Handlers derived from the returned
+// Handler share its windows.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).
+//		WithErrorCoalescing(time.Minute, nil).
+//		WithStackOnce(time.Hour, nil)
+func (h *Handler) WithStackOnce(window time.Duration, clock Clock) *Handler {
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	h2 := h.Clone()
+	h2.stackOnce = &stackOnce{
+		window:  window,
+		clock:   clock,
+		entries: make(map[string]*stackOnceEntry),
+	}
+	return h2
+}
+
+// attrs returns the attributes to add to an error record with the given fingerprint, capturing
+// the stack if it is the first occurrence of its window. Without pc, the stack starts skip
+// frames above the caller of attrs.
+func (s *stackOnce) attrs(fp string, pc uintptr, skip int) []slog.Attr {
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	if !now.Before(s.nextSweep) {
+		for key, e := range s.entries {
+			if !now.Before(e.end) {
+				delete(s.entries, key)
+			}
+		}
+		s.nextSweep = now.Add(s.window)
+	}
+
+	e, ok := s.entries[fp]
+	if ok && now.Before(e.end) {
+		e.n++
+		n := e.n
+		s.mu.Unlock()
+		return []slog.Attr{slog.String(StackRefKey, fp), slog.Int(OccurrenceKey, n)}
+	}
+	s.entries[fp] = &stackOnceEntry{end: now.Add(s.window), n: 1}
+	s.mu.Unlock()
+
+	return []slog.Attr{slog.String(StackKey, callerStack(pc, skip+1)), slog.String(StackRefKey, fp)}
+}
+
+// callerStack returns the stack of the calling goroutine as formatted by Stack, starting at the
+// frame of pc if it is set and found, or skip frames above the caller of callerStack otherwise.
+// skip=0 identifies the caller of callerStack.
+func callerStack(pc uintptr, skip int) string {
+	stack := stacktrace.Capture(skip+1, stacktrace.Full)
+	defer stack.Free()
+
+	var start runtime.Frame
+	if pc != 0 {
+		start, _ = runtime.CallersFrames([]uintptr{pc}).Next()
+	}
+
+	buf := bufferpool.Get()
+	defer buf.Free()
+	f := stacktrace.NewFormatter(buf)
+
+	// The frames up to the one of pc belong to slog and to this package.
+	found := pc == 0
+	for frame, more := stack.Next(); more; frame, more = stack.Next() {
+		if !found {
+			if frame.Function != start.Function || frame.File != start.File || frame.Line != start.Line {
+				continue
+			}
+			found = true
+		}
+		f.FormatFrame(frame)
+	}
+	if found {
+		return buf.String()
+	}
+
+	// pc is not on the stack, e.g. the record was handled by another goroutine.
+	return stacktrace.Take(skip + 1)
+}
@@ -0,0 +1,175 @@
+package slogs
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordAttrValues returns the top-level attributes of r as strings, by key.
+func recordAttrValues(r slog.Record) map[string]string {
+	values := make(map[string]string, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		values[a.Key] = a.Value.String()
+		return true
+	})
+	return values
+}
+
+func TestHandler_WithStackOnce(t *testing.T) {
+	next := newTestHandler(true)
+	clock := newFakeClock()
+	logger := New(NewHandler(next).WithStackOnce(time.Minute, clock), WithCaller(true))
+
+	errRefused := errors.New("connection refused")
+	for i := 0; i < 3; i++ {
+		logger.Error("query failed", "error", errRefused)
+	}
+
+	records := next.getRecords()
+	require.Len(t, records, 3)
+
+	first := recordAttrValues(records[0])
+	assert.True(t, strings.HasPrefix(first[StackKey], "github.com/rockcookies/go-slogs.TestHandler_WithStackOnce\n"),
+		"the stack should start at the logging call:\n%s", first[StackKey])
+	assert.NotContains(t, first, OccurrenceKey)
+	require.NotEmpty(t, first[StackRefKey])
+
+	for i, r := range records[1:] {
+		values := recordAttrValues(r)
+		assert.NotContains(t, values, StackKey)
+		assert.Equal(t, first[StackRefKey], values[StackRefKey])
+		assert.Equal(t, []string{"2", "3"}[i], values[OccurrenceKey])
+	}
+
+	clock.Advance(time.Minute)
+	logger.Error("query failed", "error", errRefused)
+	values := recordAttrValues(next.getRecords()[3])
+	assert.NotEmpty(t, values[StackKey], "a new window starts with a stack")
+	assert.Equal(t, first[StackRefKey], values[StackRefKey])
+}
+
+func TestHandler_WithStackOnce_Records(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       func(l *Logger)
+		wantStack []bool
+	}{
+		{
+			name: "without error attribute",
+			log: func(l *Logger) {
+				l.Error("failed", "reason", "unknown")
+			},
+			wantStack: []bool{false},
+		},
+		{
+			name: "error in group",
+			log: func(l *Logger) {
+				l.WithGroup("db").Error("failed", "error", errors.New("boom"))
+			},
+			wantStack: []bool{true},
+		},
+		{
+			name: "below error level",
+			log: func(l *Logger) {
+				l.Warn("failed", "error", errors.New("boom"))
+			},
+			wantStack: []bool{false},
+		},
+		{
+			name: "error values do not matter",
+			log: func(l *Logger) {
+				l.Error("failed", "error", errors.New("boom"))
+				l.Error("failed", "error", errors.New("bang"))
+			},
+			wantStack: []bool{true, false},
+		},
+		{
+			name: "distinct error types",
+			log: func(l *Logger) {
+				l.Error("failed", "error", errors.New("boom"))
+				l.Error("failed", "error", fs.ErrNotExist)
+				l.Error("failed", "error", &fs.PathError{Op: "open", Path: "a", Err: fs.ErrNotExist})
+			},
+			wantStack: []bool{true, false, true},
+		},
+		{
+			name: "distinct messages",
+			log: func(l *Logger) {
+				l.Error("read failed", "error", errors.New("boom"))
+				l.Error("write failed", "error", errors.New("boom"))
+			},
+			wantStack: []bool{true, true},
+		},
+		{
+			name: "message template",
+			log: func(l *Logger) {
+				l.Sugar().With("error", errors.New("boom")).Errorf("order %d failed", 1)
+				l.Sugar().With("error", errors.New("boom")).Errorf("order %d failed", 2)
+			},
+			wantStack: []bool{true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			logger := New(NewHandler(next).WithStackOnce(time.Minute, newFakeClock()))
+
+			tt.log(logger)
+
+			records := next.getRecords()
+			require.Len(t, records, len(tt.wantStack))
+			for i, r := range records {
+				_, hasStack := recordAttrValues(r)[StackKey]
+				assert.Equal(t, tt.wantStack[i], hasStack, "record %d", i)
+			}
+		})
+	}
+}
+
+func TestHandler_WithStackOnce_WithoutCaller(t *testing.T) {
+	next := newTestHandler(true)
+	logger := New(NewHandler(next).WithStackOnce(time.Minute, newFakeClock()), WithCaller(false))
+
+	logger.Error("failed", "error", errors.New("boom"))
+
+	stack := recordAttrValues(next.getRecords()[0])[StackKey]
+	assert.Contains(t, stack, "TestHandler_WithStackOnce_WithoutCaller", "the stack should include the logging call")
+}
+
+func TestHandler_WithStackOnce_Coalescing(t *testing.T) {
+	next := newTestHandler(true)
+	clock := newFakeClock()
+	logger := New(NewHandler(next).WithErrorCoalescing(time.Second, clock).WithStackOnce(time.Minute, clock))
+
+	errBoom := errors.New("boom")
+	logger.Error("failed", "error", errBoom)
+	logger.Error("failed", "error", errBoom)
+	clock.Advance(time.Second)
+	require.Eventually(t, func() bool { return next.recordCount() == 2 }, time.Second, time.Millisecond,
+		"the summary is emitted when the window ends")
+	logger.Error("failed", "error", errBoom)
+
+	records := next.getRecords()
+	require.Len(t, records, 3)
+
+	first := recordAttrValues(records[0])
+	assert.NotEmpty(t, first[StackKey])
+
+	summary := recordAttrValues(records[1])
+	assert.Equal(t, "2", summary["occurrences"])
+	assert.NotContains(t, summary, StackKey, "summaries carry no stack")
+
+	// The suppressed record is not counted as an occurrence.
+	last := recordAttrValues(records[2])
+	assert.NotContains(t, last, StackKey)
+	assert.Equal(t, "2", last[OccurrenceKey])
+	assert.Equal(t, first[StackRefKey], last[StackRefKey])
+}
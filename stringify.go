@@ -0,0 +1,57 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithStringifyValues returns a new Handler that converts the values of the attributes with the
+// given keys to strings, so that a key always has the same type across records. This is meant
+// for sinks with a rigid schema, which reject a key logged as a number in one record and as a
+// string in another. With no keys, the values of all attributes are converted.
+//
+// Keys are matched against leaf attributes at every level, including inside groups; groups
+// themselves are kept. LogValuers are resolved before conversion. Times are formatted with
+// time.RFC3339Nano, and other values as by slog.Value.String, e.g. durations as "1.5s" rather
+// than as a number of nanoseconds.
+//
+// Floats are formatted with the shortest representation that parses back to the same float64,
+// so no precision is lost in the conversion itself, but large and small magnitudes use exponent
+// notation, e.g. "1e+21", and non-finite values become "NaN", "+Inf" or "-Inf". Sinks parsing
+// the strings back into numbers of lower precision may round them.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithStringifyValues("user_id", "status")
+//	logger := slog.New(handler)
+//	logger.Info("request", "user_id", 42, "status", 200) // {"user_id":"42","status":"200"}
+func (h *Handler) WithStringifyValues(keys ...string) *Handler {
+	var only map[string]struct{}
+	if len(keys) > 0 {
+		only = make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			only[key] = struct{}{}
+		}
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, replaceAttrs(nil, attrs, func(_ []string, a slog.Attr) slog.Attr {
+			if only != nil {
+				if _, ok := only[a.Key]; !ok {
+					return a
+				}
+			}
+			a.Value = slog.StringValue(stringifyValue(a.Value))
+			return a
+		})
+	})
+}
+
+// stringifyValue returns the string form of the resolved value v.
+func stringifyValue(v slog.Value) string {
+	if v.Kind() == slog.KindTime {
+		return v.Time().Format(time.RFC3339Nano)
+	}
+	return v.String()
+}
@@ -0,0 +1,124 @@
+package slogs
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_WithStringifyValues(t *testing.T) {
+	noTime := &slog.HandlerOptions{ReplaceAttr: dropTime}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	tests := []struct {
+		name     string
+		keys     []string
+		log      func(l *slog.Logger)
+		expected string
+	}{
+		{
+			name:     "int",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", 42) },
+			expected: `{"level":"INFO","msg":"m","v":"42"}`,
+		},
+		{
+			name:     "uint",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", uint64(math.MaxUint64)) },
+			expected: `{"level":"INFO","msg":"m","v":"18446744073709551615"}`,
+		},
+		{
+			name:     "float",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", 0.1) },
+			expected: `{"level":"INFO","msg":"m","v":"0.1"}`,
+		},
+		{
+			name:     "large float",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", 1e21) },
+			expected: `{"level":"INFO","msg":"m","v":"1e+21"}`,
+		},
+		{
+			name:     "non-finite float",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", math.Inf(1)) },
+			expected: `{"level":"INFO","msg":"m","v":"+Inf"}`,
+		},
+		{
+			name:     "bool",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", true) },
+			expected: `{"level":"INFO","msg":"m","v":"true"}`,
+		},
+		{
+			name:     "duration",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", 1500*time.Millisecond) },
+			expected: `{"level":"INFO","msg":"m","v":"1.5s"}`,
+		},
+		{
+			name:     "time",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", at) },
+			expected: `{"level":"INFO","msg":"m","v":"2024-01-02T03:04:05.000000006Z"}`,
+		},
+		{
+			name:     "error",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", errors.New("boom")) },
+			expected: `{"level":"INFO","msg":"m","v":"boom"}`,
+		},
+		{
+			name:     "LogValuer",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", stringifyValuer{}) },
+			expected: `{"level":"INFO","msg":"m","v":"7"}`,
+		},
+		{
+			name:     "other keys unchanged",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.Info("m", "v", 1, "n", 2) },
+			expected: `{"level":"INFO","msg":"m","v":"1","n":2}`,
+		},
+		{
+			name:     "nested groups",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.WithGroup("g").Info("m", slog.Group("h", "v", 1, "n", 2)) },
+			expected: `{"level":"INFO","msg":"m","g":{"h":{"v":"1","n":2}}}`,
+		},
+		{
+			name:     "attributes from With",
+			keys:     []string{"v"},
+			log:      func(l *slog.Logger) { l.With("v", 1).Info("m") },
+			expected: `{"level":"INFO","msg":"m","v":"1"}`,
+		},
+		{
+			name:     "all keys",
+			log:      func(l *slog.Logger) { l.Info("m", "a", 1, slog.Group("g", "b", false), "c", "s") },
+			expected: `{"level":"INFO","msg":"m","a":"1","g":{"b":"false"},"c":"s"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandler(slog.NewJSONHandler(&buf, noTime)).WithStringifyValues(tt.keys...)
+
+			tt.log(slog.New(h))
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+// stringifyValuer is a LogValuer resolving to an int.
+type stringifyValuer struct{}
+
+func (stringifyValuer) LogValue() slog.Value { return slog.IntValue(7) }
@@ -0,0 +1,159 @@
+package slogs
+
+import (
+	"encoding"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// structMaxDepth bounds how many levels of nested structs Struct descends into.
+// It also protects against cycles through pointer fields.
+const structMaxDepth = 5
+
+var (
+	logValuerType     = reflect.TypeOf((*slog.LogValuer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// Struct constructs a group field from the exported fields of the struct v.
+//
+// Fields are selected and named with the "log" struct tag:
+//
+//	type Request struct {
+//		ID       string `log:"id"`
+//		Path     string `log:"path,omitempty"`
+//		Password string `log:"password,redact"` // never logged
+//		Internal string `log:"-"`               // never logged
+//		Client   Client `log:"client"`          // nested group
+//	}
+//
+// Untagged exported fields are logged under their Go field name. The "omitempty" option
+// skips zero values, and "redact" marks sensitive fields which are always skipped.
+// Exported embedded structs without a tag name are inlined into the parent group.
+//
+// Nested structs are rendered as nested groups up to a fixed depth; deeper values are
+// replaced by "...". Types that know how to log themselves (slog.LogValuer,
+// encoding.TextMarshaler, fmt.Stringer, time.Time) are logged as values rather than expanded.
+//
+// If v is not a struct or a pointer to one, Struct behaves like slog.Any.
+func Struct(key string, v any) slog.Attr {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return slog.Any(key, nil)
+		}
+		rv = rv.Elem()
+	}
+
+	if !isExpandableStruct(rv) {
+		return slog.Any(key, v)
+	}
+	return slog.Attr{Key: key, Value: slog.GroupValue(structAttrs(rv, 1)...)}
+}
+
+// structAttrs builds the attributes for the fields of the struct value rv.
+func structAttrs(rv reflect.Value, depth int) []slog.Attr {
+	rt := rv.Type()
+	attrs := make([]slog.Attr, 0, rt.NumField())
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, omitEmpty, skip := parseLogTag(field)
+		if skip {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			if inner, ok := derefStruct(fv); ok {
+				attrs = append(attrs, structAttrs(inner, depth)...)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		attrs = append(attrs, structFieldAttr(name, fv, depth))
+	}
+
+	return attrs
+}
+
+// structFieldAttr converts a single struct field into an attribute, descending into nested structs.
+func structFieldAttr(name string, fv reflect.Value, depth int) slog.Attr {
+	inner, ok := derefStruct(fv)
+	if !ok {
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			return slog.Any(name, nil)
+		}
+		return slog.Any(name, fv.Interface())
+	}
+
+	if depth >= structMaxDepth {
+		return slog.String(name, "...")
+	}
+	return slog.Attr{Key: name, Value: slog.GroupValue(structAttrs(inner, depth+1)...)}
+}
+
+// derefStruct follows pointers and reports whether the result is a struct that should be expanded.
+func derefStruct(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, isExpandableStruct(v)
+}
+
+// isExpandableStruct reports whether v is a struct that does not render itself.
+func isExpandableStruct(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	t := v.Type()
+	pt := reflect.PointerTo(t)
+	for _, iface := range []reflect.Type{logValuerType, textMarshalerType, stringerType} {
+		if t.Implements(iface) || pt.Implements(iface) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLogTag interprets the "log" struct tag of field.
+func parseLogTag(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	if !field.IsExported() {
+		return "", false, true
+	}
+
+	tag, ok := field.Tag.Lookup("log")
+	if !ok {
+		return "", false, false
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		switch opt {
+		case "omitempty":
+			omitEmpty = true
+		case "redact":
+			skip = true
+		}
+	}
+	return name, omitEmpty, skip
+}
@@ -0,0 +1,142 @@
+package slogs
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type structTestClient struct {
+	IP   string `log:"ip"`
+	Port int    `log:"port,omitempty"`
+}
+
+type StructTestBase struct {
+	Service string `log:"service"`
+}
+
+type structTestRequest struct {
+	StructTestBase
+	ID       string            `log:"id"`
+	Path     string            `log:"path,omitempty"`
+	Password string            `log:"password,redact"`
+	Internal string            `log:"-"`
+	Client   structTestClient  `log:"client"`
+	Peer     *structTestClient `log:"peer,omitempty"`
+	At       time.Time         `log:"at"`
+	Untagged bool
+	private  string
+}
+
+type structTestNode struct {
+	Name string          `log:"name"`
+	Next *structTestNode `log:"next"`
+}
+
+func TestStruct(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	loop := &structTestNode{Name: "loop"}
+	loop.Next = loop
+
+	tests := []struct {
+		name    string
+		v       any
+		want    string
+		notWant []string
+	}{
+		{
+			name: "tags, omitempty, redact and nesting",
+			v: structTestRequest{
+				StructTestBase: StructTestBase{Service: "api"},
+				ID:             "r1",
+				Password:       "secret",
+				Internal:       "hidden",
+				Client:         structTestClient{IP: "127.0.0.1"},
+				At:             at,
+				Untagged:       true,
+				private:        "x",
+			},
+			want:    `"req":{"service":"api","id":"r1","client":{"ip":"127.0.0.1"},"at":"2024-01-02T03:04:05Z","Untagged":true}`,
+			notWant: []string{"secret", "hidden", "path", "peer", "private"},
+		},
+		{
+			name: "pointer to struct",
+			v:    &structTestClient{IP: "10.0.0.1", Port: 80},
+			want: `"req":{"ip":"10.0.0.1","port":80}`,
+		},
+		{
+			name: "nested pointer field",
+			v:    structTestRequest{ID: "r2", Peer: &structTestClient{IP: "1.1.1.1"}, At: at},
+			want: `"peer":{"ip":"1.1.1.1"}`,
+		},
+		{
+			name: "cycles are cut at max depth",
+			v:    loop,
+			want: `"req":{"name":"loop","next":{"name":"loop","next":{"name":"loop","next":{"name":"loop","next":{"name":"loop","next":"..."}}}}}`,
+		},
+		{
+			name: "non-struct behaves like Any",
+			v:    42,
+			want: `"req":42`,
+		},
+		{
+			name: "nil pointer",
+			v:    (*structTestClient)(nil),
+			want: `"req":null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			slog.New(slog.NewJSONHandler(buf, nil)).Info("m", Struct("req", tt.v))
+
+			assert.Contains(t, buf.String(), tt.want)
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, buf.String(), notWant)
+			}
+		})
+	}
+}
+
+func TestParseLogTag(t *testing.T) {
+	type sample struct {
+		A string
+		B string `log:"b"`
+		C string `log:"c,omitempty"`
+		D string `log:",omitempty,redact"`
+		E string `log:"-"`
+		f string
+	}
+
+	tests := []struct {
+		field         string
+		wantName      string
+		wantOmitEmpty bool
+		wantSkip      bool
+	}{
+		{field: "A"},
+		{field: "B", wantName: "b"},
+		{field: "C", wantName: "c", wantOmitEmpty: true},
+		{field: "D", wantOmitEmpty: true, wantSkip: true},
+		{field: "E", wantSkip: true},
+		{field: "f", wantSkip: true},
+	}
+
+	typ := reflect.TypeOf(sample{})
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			f, ok := typ.FieldByName(tt.field)
+			assert.True(t, ok)
+
+			name, omitEmpty, skip := parseLogTag(f)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantOmitEmpty, omitEmpty)
+			assert.Equal(t, tt.wantSkip, skip)
+		})
+	}
+}
@@ -65,6 +65,11 @@ func (l *SugaredLogger) Named(s string) *SugaredLogger {
 	return &SugaredLogger{base: l.base.Named(s)}
 }
 
+// NamedReset returns a new SugaredLogger whose name chain is replaced by the given name.
+func (l *SugaredLogger) NamedReset(s string) *SugaredLogger {
+	return &SugaredLogger{base: l.base.NamedReset(s)}
+}
+
 // Name returns the logger's name.
 func (l *SugaredLogger) Name() string {
 	return l.base.Name()
@@ -180,10 +185,14 @@ func (l *SugaredLogger) log(ctx context.Context, level slog.Level, template stri
 	}
 
 	msg := getMessage(template, fmtArgs)
+	if template != "" && len(fmtArgs) > 0 {
+		// Keep the template available to handlers, see MessageTemplate.
+		ctx = context.WithValue(ctx, messageTemplateKey{}, template)
+	}
 	pc := l.base.capturePC(ctx, level)
 	r := slog.NewRecord(l.base.clock.Now(), level, msg, pc)
 
-	_ = l.base.handler.Handle(ctx, r)
+	l.base.handle(ctx, r)
 }
 
 // getMessage formats the message using Sprint, Sprintf, or returns as-is.
@@ -299,3 +299,16 @@ func TestSugaredLogger_Name_Empty(t *testing.T) {
 
 	assert.Equal(t, "", sugar.Name())
 }
+
+func TestSugaredLogger_Named_Chain(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewHandler(slog.NewJSONHandler(buf, nil))
+	sugar := New(h).Sugar().Named("a").Named("b")
+
+	assert.Equal(t, "a.b", sugar.Name())
+	assert.Equal(t, "c", sugar.NamedReset("c").Name())
+	assert.Equal(t, "c.d", sugar.NamedReset("c").Named("d").Name())
+
+	sugar.Info("message")
+	assert.Contains(t, buf.String(), "[a.b] message")
+}
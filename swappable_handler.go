@@ -0,0 +1,138 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+)
+
+var _ slog.Handler = (*SwappableHandler)(nil)
+
+// SwappableHandler is a slog.Handler whose underlying handler can be replaced at runtime.
+//
+// Handlers derived with WithAttrs and WithGroup follow swaps: their attributes and groups
+// are applied again to the new handler the first time it is used, so a whole logger tree
+// switches over at once without being rebuilt.
+//
+// Example:
+//
+//	swappable := slogs.NewSwappableHandler(slog.NewTextHandler(os.Stderr, nil))
+//	logger := slogs.New(slogs.NewHandler(swappable))
+//	// Later, e.g. on SIGHUP:
+//	swappable.Swap(slog.NewJSONHandler(os.Stdout, nil))
+type SwappableHandler struct {
+	current *atomic.Pointer[swappedHandler]
+
+	// ops re-create the attributes and groups of this handler on top of the current handler.
+	ops []func(slog.Handler) slog.Handler
+
+	// cache holds the current handler with ops applied.
+	cache atomic.Pointer[swapCache]
+}
+
+// swappedHandler is a handler installed by Swap. Its address identifies the swap.
+type swappedHandler struct {
+	handler slog.Handler
+}
+
+// swapCache is a derived handler built on top of base.
+type swapCache struct {
+	base    *swappedHandler
+	handler slog.Handler
+}
+
+// NewSwappableHandler creates a SwappableHandler that initially forwards to h.
+//
+// Panics if h is nil; see NewSwappableHandlerE.
+func NewSwappableHandler(h slog.Handler) *SwappableHandler {
+	sh, err := NewSwappableHandlerE(h)
+	if err != nil {
+		panic(err.Error())
+	}
+	return sh
+}
+
+// NewSwappableHandlerE is like NewSwappableHandler but returns ErrNilNextHandler instead of
+// panicking if h is nil.
+func NewSwappableHandlerE(h slog.Handler) (*SwappableHandler, error) {
+	if h == nil {
+		return nil, ErrNilNextHandler
+	}
+
+	current := &atomic.Pointer[swappedHandler]{}
+	current.Store(&swappedHandler{handler: h})
+	return &SwappableHandler{current: current}, nil
+}
+
+// Swap atomically replaces the underlying handler of h and of every handler derived from it.
+//
+// Records being handled concurrently may still reach the previous handler.
+// Panics if next is nil.
+func (h *SwappableHandler) Swap(next slog.Handler) {
+	if next == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+	h.current.Store(&swappedHandler{handler: next})
+}
+
+// Enabled reports whether the current handler handles records at the given level.
+func (h *SwappableHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.resolve().Enabled(ctx, level)
+}
+
+// Handle passes r to the current handler.
+func (h *SwappableHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.resolve().Handle(ctx, r)
+}
+
+// WithAttrs returns a SwappableHandler that follows the swaps of h and adds the given attributes.
+func (h *SwappableHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.derive(func(next slog.Handler) slog.Handler {
+		return next.WithAttrs(attrs)
+	})
+}
+
+// WithGroup returns a SwappableHandler that follows the swaps of h and opens the given group.
+func (h *SwappableHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.derive(func(next slog.Handler) slog.Handler {
+		return next.WithGroup(name)
+	})
+}
+
+func (h *SwappableHandler) derive(op func(slog.Handler) slog.Handler) *SwappableHandler {
+	return &SwappableHandler{
+		current: h.current,
+		ops:     append(slices.Clip(h.ops), op),
+	}
+}
+
+// resolve returns the current handler with the attributes and groups of h applied.
+func (h *SwappableHandler) resolve() slog.Handler {
+	base := h.current.Load()
+	if len(h.ops) == 0 {
+		return base.handler
+	}
+
+	if c := h.cache.Load(); c != nil && c.base == base {
+		return c.handler
+	}
+
+	next := base.handler
+	for _, op := range h.ops {
+		next = op(next)
+	}
+	h.cache.Store(&swapCache{base: base, handler: next})
+	return next
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *SwappableHandler) unwrap() slog.Handler {
+	return h.resolve()
+}
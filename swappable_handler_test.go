@@ -0,0 +1,89 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSwappableHandler_Nil(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewSwappableHandler(nil)
+	})
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewSwappableHandler(newTestHandler(true)).Swap(nil)
+	})
+
+	h, err := NewSwappableHandlerE(nil)
+	assert.ErrorIs(t, err, ErrNilNextHandler)
+	assert.Nil(t, h)
+}
+
+func TestSwappableHandler_Swap(t *testing.T) {
+	var first, second bytes.Buffer
+	h := NewSwappableHandler(slog.NewTextHandler(&first, nil))
+
+	derived := h.WithAttrs([]slog.Attr{slog.String("app", "api")}).WithGroup("req")
+
+	ctx := context.Background()
+	r := slog.NewRecord(time.Time{}, slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("method", "GET"))
+
+	require.NoError(t, derived.Handle(ctx, r))
+	assert.Equal(t, "level=INFO msg=msg app=api req.method=GET\n", first.String())
+
+	h.Swap(slog.NewJSONHandler(&second, nil))
+	require.NoError(t, derived.Handle(ctx, r))
+	require.NoError(t, h.Handle(ctx, r))
+
+	assert.Equal(t, "level=INFO msg=msg app=api req.method=GET\n", first.String(), "previous handler must not receive records after Swap")
+	assert.Equal(t,
+		`{"level":"INFO","msg":"msg","app":"api","req":{"method":"GET"}}`+"\n"+
+			`{"level":"INFO","msg":"msg","method":"GET"}`+"\n",
+		second.String())
+}
+
+func TestSwappableHandler_Enabled(t *testing.T) {
+	h := NewSwappableHandler(newTestHandler(true))
+	derived := h.WithGroup("g")
+
+	assert.True(t, derived.Enabled(context.Background(), slog.LevelInfo))
+	h.Swap(newTestHandler(false))
+	assert.False(t, derived.Enabled(context.Background(), slog.LevelInfo))
+}
+
+func TestSwappableHandler_EmptyDerive(t *testing.T) {
+	h := NewSwappableHandler(newTestHandler(true))
+
+	assert.Same(t, h, h.WithAttrs(nil))
+	assert.Same(t, h, h.WithGroup(""))
+}
+
+func TestSwappableHandler_Concurrent(t *testing.T) {
+	h := NewSwappableHandler(newTestHandler(true))
+	derived := h.WithAttrs([]slog.Attr{slog.String("k", "v")})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.NoError(t, derived.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				h.Swap(newTestHandler(true))
+			}
+		}()
+	}
+	wg.Wait()
+}
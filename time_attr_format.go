@@ -0,0 +1,47 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithTimeAttrFormat returns a new Handler that formats the time values of attributes with
+// layout, e.g. time.RFC3339, so that timestamps logged as attributes, such as "created_at", are
+// rendered the same way by every sink: the JSON and text handlers of log/slog format times
+// differently, and other sinks may not format them at all. An empty layout means
+// time.RFC3339Nano.
+//
+// Attributes are matched at every level, including inside groups, and LogValuers are resolved
+// first. The record time is left unchanged. Times are formatted in their own location and
+// without their monotonic clock reading. The zero time is rendered as an empty string rather
+// than as year 1.
+//
+// Example:
+//
+//	handler := slogs.NewHandler(next).WithTimeAttrFormat(time.RFC3339)
+//	logger := slog.New(handler)
+//	logger.Info("user created", "created_at", createdAt) // {"created_at":"2024-01-02T03:04:05Z"}
+func (h *Handler) WithTimeAttrFormat(layout string) *Handler {
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		return rm, replaceAttrs(nil, attrs, func(_ []string, a slog.Attr) slog.Attr {
+			if a.Value.Kind() != slog.KindTime {
+				return a
+			}
+			a.Value = slog.StringValue(formatTimeAttr(a.Value.Time(), layout))
+			return a
+		})
+	})
+}
+
+// formatTimeAttr formats t with layout, or returns "" if t is the zero time.
+func formatTimeAttr(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
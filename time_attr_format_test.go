@@ -0,0 +1,117 @@
+package slogs
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// timeAttrValuer is a LogValuer resolving to a time.
+type timeAttrValuer struct{ t time.Time }
+
+func (v timeAttrValuer) LogValue() slog.Value { return slog.TimeValue(v.t) }
+
+func TestHandler_WithTimeAttrFormat(t *testing.T) {
+	noTime := &slog.HandlerOptions{ReplaceAttr: dropTime}
+	jsonSink := func(w io.Writer) slog.Handler { return slog.NewJSONHandler(w, noTime) }
+	textSink := func(w io.Writer) slog.Handler { return slog.NewTextHandler(w, noTime) }
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name     string
+		sink     func(w io.Writer) slog.Handler
+		layout   string
+		log      func(l *slog.Logger)
+		expected string
+	}{
+		{
+			name:     "json",
+			sink:     jsonSink,
+			layout:   time.RFC3339,
+			log:      func(l *slog.Logger) { l.Info("m", "created_at", at) },
+			expected: `{"level":"INFO","msg":"m","created_at":"2024-01-02T03:04:05+01:00"}`,
+		},
+		{
+			name:     "text",
+			sink:     textSink,
+			layout:   time.RFC3339,
+			log:      func(l *slog.Logger) { l.Info("m", "created_at", at) },
+			expected: `level=INFO msg=m created_at=2024-01-02T03:04:05+01:00`,
+		},
+		{
+			name:     "default layout",
+			sink:     jsonSink,
+			log:      func(l *slog.Logger) { l.Info("m", "created_at", at) },
+			expected: `{"level":"INFO","msg":"m","created_at":"2024-01-02T03:04:05.000000006+01:00"}`,
+		},
+		{
+			name:     "zero time",
+			sink:     jsonSink,
+			layout:   time.RFC3339,
+			log:      func(l *slog.Logger) { l.Info("m", "deleted_at", time.Time{}) },
+			expected: `{"level":"INFO","msg":"m","deleted_at":""}`,
+		},
+		{
+			name:     "other values unchanged",
+			sink:     jsonSink,
+			layout:   time.RFC3339,
+			log:      func(l *slog.Logger) { l.Info("m", "n", 1, "d", time.Second, "s", "x") },
+			expected: `{"level":"INFO","msg":"m","n":1,"d":1000000000,"s":"x"}`,
+		},
+		{
+			name:     "LogValuer",
+			sink:     jsonSink,
+			layout:   time.DateOnly,
+			log:      func(l *slog.Logger) { l.Info("m", "v", timeAttrValuer{at}) },
+			expected: `{"level":"INFO","msg":"m","v":"2024-01-02"}`,
+		},
+		{
+			name:     "nested groups json",
+			sink:     jsonSink,
+			layout:   time.DateOnly,
+			log:      func(l *slog.Logger) { l.WithGroup("g").Info("m", slog.Group("h", "at", at, "n", 2)) },
+			expected: `{"level":"INFO","msg":"m","g":{"h":{"at":"2024-01-02","n":2}}}`,
+		},
+		{
+			name:     "nested groups text",
+			sink:     textSink,
+			layout:   time.DateOnly,
+			log:      func(l *slog.Logger) { l.WithGroup("g").Info("m", slog.Group("h", "at", at, "n", 2)) },
+			expected: `level=INFO msg=m g.h.at=2024-01-02 g.h.n=2`,
+		},
+		{
+			name:     "attributes from With",
+			sink:     jsonSink,
+			layout:   time.DateOnly,
+			log:      func(l *slog.Logger) { l.With("at", at).Info("m") },
+			expected: `{"level":"INFO","msg":"m","at":"2024-01-02"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandler(tt.sink(&buf)).WithTimeAttrFormat(tt.layout)
+
+			tt.log(slog.New(h))
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestHandler_WithTimeAttrFormat_Monotonic(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithTimeAttrFormat(time.RFC3339Nano)
+
+	// time.Now carries a monotonic clock reading, which time.Time.String would include.
+	now := time.Now()
+	slog.New(h).Info("m", "at", now)
+
+	assert.Equal(t, "level=INFO msg=m at="+now.Format(time.RFC3339Nano)+"\n", buf.String())
+	assert.NotContains(t, buf.String(), "m=+")
+}
@@ -0,0 +1,132 @@
+package slogs
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"math"
+)
+
+var _ slog.Handler = (*TraceSamplingHandler)(nil)
+
+// TraceSamplingHandler samples records per request rather than per record: all the records of
+// a trace share one decision, so a request's logs are either all kept or all dropped.
+//
+// The decision is a hash of the trace ID, so it is the same for every record of the trace, in
+// every process using the same rate, without keeping any per-request state. Records at or above
+// the keep level are always passed on, so errors of dropped requests are not lost. Records
+// without a trace ID are passed on.
+//
+// The trace ID is read from the context with the traceID function, e.g. otel.TraceID from the
+// otel module.
+//
+// Example:
+//
+//	sampler := slogs.NewTraceSamplingHandler(slog.NewJSONHandler(os.Stdout, nil), otel.TraceID, 0.1, nil)
+//	logger := slogs.New(slogs.NewHandler(sampler))
+type TraceSamplingHandler struct {
+	next      slog.Handler
+	traceID   func(ctx context.Context) string
+	threshold uint64
+	keep      slog.Leveler
+	reporter  DropReporter
+}
+
+// NewTraceSamplingHandler creates a TraceSamplingHandler keeping the records of a fraction rate,
+// between 0 and 1, of the traces, and passing them to next. Records at or above keepLevel are
+// always kept; a nil keepLevel means slog.LevelError.
+//
+// Panics if next or traceID is nil.
+func NewTraceSamplingHandler(next slog.Handler, traceID func(ctx context.Context) string, rate float64, keepLevel slog.Leveler) *TraceSamplingHandler {
+	if next == nil {
+		panic("slogs: next handler cannot be nil")
+	}
+	if traceID == nil {
+		panic("slogs: trace ID function cannot be nil")
+	}
+	if keepLevel == nil {
+		keepLevel = slog.LevelError
+	}
+
+	var threshold uint64
+	switch {
+	case rate >= 1:
+		threshold = math.MaxUint64
+	case rate > 0:
+		threshold = uint64(rate * math.MaxUint64)
+	}
+
+	return &TraceSamplingHandler{next: next, traceID: traceID, threshold: threshold, keep: keepLevel}
+}
+
+// WithDropReporter returns a TraceSamplingHandler that reports every dropped record to reporter
+// with ReasonSampled. A nil reporter disables reporting.
+func (h *TraceSamplingHandler) WithDropReporter(reporter DropReporter) *TraceSamplingHandler {
+	h2 := *h
+	h2.reporter = reporter
+	return &h2
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *TraceSamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes r to the next handler if its trace is sampled, it has no trace or its level is
+// at or above the keep level, and drops it otherwise.
+func (h *TraceSamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < h.keep.Level() {
+		if id := h.traceID(ctx); id != "" && !h.sampled(id) {
+			if h.reporter != nil {
+				h.reporter.Dropped(r.Level, ReasonSampled, 1)
+			}
+			return nil
+		}
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a TraceSamplingHandler whose next handler has the given attributes.
+func (h *TraceSamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a TraceSamplingHandler whose next handler has the given group.
+func (h *TraceSamplingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	return &h2
+}
+
+// sampled reports whether the records of the trace with the given ID are kept.
+func (h *TraceSamplingHandler) sampled(traceID string) bool {
+	if h.threshold == math.MaxUint64 {
+		return true
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(traceID))
+	return mix64(hash.Sum64()) < h.threshold
+}
+
+// mix64 is the finalizer of MurmurHash3. It spreads every bit of x over the high bits, which
+// FNV leaves poorly mixed for IDs differing only in their last characters.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// unwrap returns the handler h passes records to, see handlerSinks.
+func (h *TraceSamplingHandler) unwrap() slog.Handler {
+	return h.next
+}
@@ -0,0 +1,86 @@
+package slogs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// traceIDKey holds the trace ID read by testTraceID.
+type traceIDKey struct{}
+
+func testTraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+func withTraceID(id string) context.Context {
+	return context.WithValue(context.Background(), traceIDKey{}, id)
+}
+
+func TestTraceSamplingHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		rate      float64
+		keepLevel slog.Leveler
+		ctx       context.Context
+		level     slog.Level
+		want      bool
+	}{
+		{name: "rate 0 drops traced records", rate: 0, ctx: withTraceID("t1"), level: slog.LevelWarn, want: false},
+		{name: "rate 0 keeps errors", rate: 0, ctx: withTraceID("t1"), level: slog.LevelError, want: true},
+		{name: "custom keep level", rate: 0, keepLevel: slog.LevelWarn, ctx: withTraceID("t1"), level: slog.LevelWarn, want: true},
+		{name: "rate 0 keeps records without trace", rate: 0, ctx: context.Background(), level: slog.LevelInfo, want: true},
+		{name: "rate 1 keeps traced records", rate: 1, ctx: withTraceID("t1"), level: slog.LevelDebug, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newTestHandler(true)
+			h := NewTraceSamplingHandler(next, testTraceID, tt.rate, tt.keepLevel)
+
+			slog.New(h).Log(tt.ctx, tt.level, "m")
+
+			assert.Equal(t, tt.want, next.recordCount() == 1)
+		})
+	}
+}
+
+func TestTraceSamplingHandler_ConsistentPerTrace(t *testing.T) {
+	next := newTestHandler(true)
+	drops := newDropCounter()
+	logger := slog.New(NewTraceSamplingHandler(next, testTraceID, 0.5, nil).WithDropReporter(drops))
+
+	const traces, perTrace = 1000, 5
+	kept := 0
+	for i := 0; i < traces; i++ {
+		ctx := withTraceID(fmt.Sprintf("%032x", i))
+		before := next.recordCount()
+		for j := 0; j < perTrace; j++ {
+			logger.InfoContext(ctx, "step")
+		}
+
+		switch next.recordCount() - before {
+		case perTrace:
+			kept++
+		case 0:
+		default:
+			t.Fatalf("trace %d was partially sampled", i)
+		}
+	}
+
+	assert.InDelta(t, traces/2, kept, traces/10)
+	assert.Equal(t, map[string]int{"INFO " + ReasonSampled: (traces - kept) * perTrace}, drops.get())
+}
+
+func TestNewTraceSamplingHandler_Nil(t *testing.T) {
+	assert.PanicsWithValue(t, "slogs: next handler cannot be nil", func() {
+		NewTraceSamplingHandler(nil, testTraceID, 1, nil)
+	})
+	assert.PanicsWithValue(t, "slogs: trace ID function cannot be nil", func() {
+		NewTraceSamplingHandler(newTestHandler(true), nil, 1, nil)
+	})
+}
@@ -0,0 +1,140 @@
+package slogs
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ValueFormatters maps Go types to functions that format attribute values of that type.
+//
+// Register the formatters once at startup and install them with Handler.WithValueFormatters;
+// every record of the handler and the handlers derived from it is then formatted consistently,
+// without wrapping values at each call site. Registering is safe while records are being
+// handled, but records already in flight may miss the new formatter.
+//
+// Example:
+//
+//	formatters := slogs.NewValueFormatters()
+//	formatters.Register(reflect.TypeOf(time.Duration(0)), func(v any) slog.Value {
+//		return slog.StringValue(v.(time.Duration).String())
+//	})
+//	handler := slogs.NewHandler(next).WithValueFormatters(formatters)
+type ValueFormatters struct {
+	mu    sync.Mutex
+	state atomic.Pointer[formatterState]
+}
+
+// formatterState is an immutable snapshot of the registered formatters.
+type formatterState struct {
+	byType map[reflect.Type]func(any) slog.Value
+	// kinds has bit k set if a value of kind k can hold one of the registered types.
+	kinds uint32
+}
+
+// NewValueFormatters creates an empty set of formatters.
+func NewValueFormatters() *ValueFormatters {
+	return &ValueFormatters{}
+}
+
+// Register sets fn as the formatter of values whose dynamic type is exactly typ,
+// replacing any formatter previously registered for typ.
+//
+// Formatters take precedence over LogValuer: a type implementing slog.LogValuer is passed
+// to fn unresolved. The value returned by fn is used as is.
+//
+// Panics if typ or fn is nil.
+func (f *ValueFormatters) Register(typ reflect.Type, fn func(any) slog.Value) {
+	if typ == nil || fn == nil {
+		panic("slogs: value formatter type and function cannot be nil")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	next := &formatterState{byType: make(map[reflect.Type]func(any) slog.Value)}
+	if cur := f.state.Load(); cur != nil {
+		for t, fn := range cur.byType {
+			next.byType[t] = fn
+		}
+		next.kinds = cur.kinds
+	}
+	next.byType[typ] = fn
+	// slog stores some types (e.g. time.Duration) in a dedicated kind rather than KindAny.
+	next.kinds |= 1 << slog.AnyValue(reflect.Zero(typ).Interface()).Kind()
+
+	f.state.Store(next)
+}
+
+// WithValueFormatters returns a new Handler that formats attribute values using formatters.
+//
+// Values are matched by their exact dynamic type at every group level, after the HandleFunc,
+// so attributes from the context are formatted too. Only attributes whose kind can hold a
+// registered type are inspected, and matching costs one map lookup per inspected value.
+func (h *Handler) WithValueFormatters(formatters *ValueFormatters) *Handler {
+	if formatters == nil {
+		return h
+	}
+
+	return h.use(func(_ context.Context, _ *HandlerContext, _ time.Time, _ slog.Level, rm string, attrs []slog.Attr) (string, []slog.Attr) {
+		state := formatters.state.Load()
+		if state == nil {
+			return rm, attrs
+		}
+		formatted, _ := state.format(attrs)
+		return rm, formatted
+	})
+}
+
+// format applies the formatters to attrs, recursing into groups, and reports whether any
+// value was formatted. attrs is returned unchanged if none was.
+func (s *formatterState) format(attrs []slog.Attr) ([]slog.Attr, bool) {
+	var out []slog.Attr
+	for i, a := range attrs {
+		formatted, ok := s.formatAttr(a)
+		if !ok {
+			if out != nil {
+				out = append(out, a)
+			}
+			continue
+		}
+
+		if out == nil {
+			out = make([]slog.Attr, i, len(attrs))
+			copy(out, attrs[:i])
+		}
+		out = append(out, formatted)
+	}
+
+	if out == nil {
+		return attrs, false
+	}
+	return out, true
+}
+
+// formatAttr returns a with its value formatted and reports whether anything changed.
+func (s *formatterState) formatAttr(a slog.Attr) (slog.Attr, bool) {
+	kind := a.Value.Kind()
+	if kind == slog.KindGroup {
+		formatted, ok := s.format(a.Value.Group())
+		if ok {
+			a.Value = slog.GroupValue(formatted...)
+		}
+		return a, ok
+	}
+
+	if s.kinds&(1<<kind) == 0 {
+		return a, false
+	}
+
+	v := a.Value.Any()
+	fn, ok := s.byType[reflect.TypeOf(v)]
+	if !ok {
+		return a, false
+	}
+	a.Value = fn(v)
+	return a, true
+}
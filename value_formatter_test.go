@@ -0,0 +1,120 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testID is a custom type formatted by a registered formatter.
+type testID [2]byte
+
+// testSecret implements LogValuer; formatters take precedence over it.
+type testSecret string
+
+func (testSecret) LogValue() slog.Value {
+	return slog.StringValue("from LogValue")
+}
+
+func newTestFormatters() *ValueFormatters {
+	f := NewValueFormatters()
+	f.Register(reflect.TypeOf(time.Duration(0)), func(v any) slog.Value {
+		return slog.StringValue(v.(time.Duration).String())
+	})
+	f.Register(reflect.TypeOf(testID{}), func(v any) slog.Value {
+		id := v.(testID)
+		return slog.StringValue(fmt.Sprintf("id-%02x%02x", id[0], id[1]))
+	})
+	f.Register(reflect.TypeOf(testSecret("")), func(any) slog.Value {
+		return slog.StringValue("from formatter")
+	})
+	return f
+}
+
+func TestHandler_WithValueFormatters(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []any
+		expected string
+	}{
+		{
+			name:     "dedicated kind",
+			args:     []any{"elapsed", 1500 * time.Millisecond},
+			expected: "level=INFO msg=msg elapsed=1.5s\n",
+		},
+		{
+			name:     "any kind",
+			args:     []any{"id", testID{0xab, 0x01}},
+			expected: "level=INFO msg=msg id=id-ab01\n",
+		},
+		{
+			name:     "precedence over LogValuer",
+			args:     []any{"secret", testSecret("x")},
+			expected: "level=INFO msg=msg secret=\"from formatter\"\n",
+		},
+		{
+			name:     "nested group",
+			args:     []any{slog.Group("req", "id", testID{0, 1}, "n", 1)},
+			expected: "level=INFO msg=msg req.id=id-0001 req.n=1\n",
+		},
+		{
+			name:     "unregistered types untouched",
+			args:     []any{"n", 1, "s", "x", "t", 3},
+			expected: "level=INFO msg=msg n=1 s=x t=3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+				WithValueFormatters(newTestFormatters())
+
+			New(h).Info("msg", tt.args...)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestHandler_WithValueFormatters_ContextAttrs(t *testing.T) {
+	next := newTestHandler(true)
+	h := NewHandler(next).WithValueFormatters(newTestFormatters())
+
+	ctx := Prepend(context.Background(), "timeout", 2*time.Second)
+	require.NoError(t, h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)))
+
+	require.Equal(t, 1, next.recordCount())
+	assert.True(t, recordHasAttr(next.getRecords()[0], "timeout", "2s"))
+}
+
+func TestHandler_WithValueFormatters_LateRegistration(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewValueFormatters()
+	logger := New(NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})).WithValueFormatters(f))
+
+	logger.Info("msg", "elapsed", time.Second)
+	f.Register(reflect.TypeOf(time.Duration(0)), func(v any) slog.Value {
+		return slog.StringValue("formatted")
+	})
+	logger.Info("msg", "elapsed", time.Second)
+
+	assert.Equal(t, "level=INFO msg=msg elapsed=1s\nlevel=INFO msg=msg elapsed=formatted\n", buf.String())
+}
+
+func TestValueFormatters_RegisterNil(t *testing.T) {
+	f := NewValueFormatters()
+	assert.Panics(t, func() { f.Register(nil, func(any) slog.Value { return slog.Value{} }) })
+	assert.Panics(t, func() { f.Register(reflect.TypeOf(0), nil) })
+}
+
+func TestHandler_WithValueFormatters_Nil(t *testing.T) {
+	h := NewHandler(newTestHandler(true))
+	assert.Same(t, h, h.WithValueFormatters(nil))
+}
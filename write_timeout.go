@@ -0,0 +1,82 @@
+package slogs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// maxStuckWrites bounds the number of calls to the next handler that timed out and are still
+// running, see Handler.WithWriteTimeout.
+const maxStuckWrites = 4
+
+// writeTimeout bounds the time the next handler of a Handler may take, see Handler.WithWriteTimeout.
+type writeTimeout struct {
+	timeout time.Duration
+	// stuck counts the calls to the next handler that timed out and are still running.
+	stuck atomic.Int32
+}
+
+// WithWriteTimeout returns a new Handler that gives up on the next handler after timeout.
+//
+// It protects callers from sinks that block forever, such as a network handler writing to a
+// hung connection. The next handler runs in its own goroutine, so records are handled
+// concurrently as without the timeout, and receives a context that is canceled after timeout
+// but not when the caller's context is: a record logged on a canceled context, such as a
+// request whose client disconnected, is still written. If the next handler has not returned
+// after timeout, Handle returns an error wrapping context.DeadlineExceeded.
+//
+// A goroutine that timed out keeps running until the next handler returns. To bound the number
+// of such goroutines, once 4 of them are running for the returned Handler and the handlers
+// derived from it, records fail right away with an error wrapping context.DeadlineExceeded,
+// without being passed to the next handler, until one of them returns. A timeout <= 0 disables
+// the protection.
+func (h *Handler) WithWriteTimeout(timeout time.Duration) *Handler {
+	h2 := h.Clone()
+	if timeout <= 0 {
+		h2.writeTimeout = nil
+		return h2
+	}
+
+	h2.writeTimeout = &writeTimeout{timeout: timeout}
+	return h2
+}
+
+// forwardWithTimeout passes r to the next handler, applying the write timeout if one is set.
+func (h *Handler) forwardWithTimeout(ctx context.Context, r slog.Record) error {
+	wt := h.writeTimeout
+	if wt == nil {
+		return h.next.Handle(ctx, r)
+	}
+
+	if n := wt.stuck.Load(); n >= maxStuckWrites {
+		return fmt.Errorf("slogs: handler timed out after %s, %d calls still running: %w", wt.timeout, n, context.DeadlineExceeded)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), wt.timeout)
+	defer cancel()
+
+	// settled is set by the first of the goroutine returning and the caller giving up on it. If
+	// the caller is first, the call counts as stuck until the goroutine returns.
+	var settled atomic.Bool
+	done := make(chan error, 1)
+	go func() {
+		done <- h.next.Handle(timeoutCtx, r)
+		if !settled.CompareAndSwap(false, true) {
+			wt.stuck.Add(-1)
+		}
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timeoutCtx.Done():
+	}
+
+	if settled.CompareAndSwap(false, true) {
+		wt.stuck.Add(1)
+	}
+	return fmt.Errorf("slogs: handler timed out after %s: %w", wt.timeout, context.DeadlineExceeded)
+}
@@ -0,0 +1,158 @@
+package slogs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingWriter blocks every write until release is closed.
+type blockingWriter struct {
+	release chan struct{}
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestHandler_WithWriteTimeout(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+
+	w := &blockingWriter{release: make(chan struct{})}
+	h := NewHandler(slog.NewTextHandler(w, &slog.HandlerOptions{ReplaceAttr: dropTime})).
+		WithWriteTimeout(timeout)
+
+	for i := 0; i < maxStuckWrites; i++ {
+		start := time.Now()
+		err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "stuck", 0))
+		elapsed := time.Since(start)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, elapsed, 10*timeout)
+	}
+
+	start := time.Now()
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "skipped", 0))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), timeout, "records fail right away while too many calls are stuck")
+
+	close(w.release)
+	want := strings.Repeat("level=INFO msg=stuck\n", maxStuckWrites)
+	assert.Eventually(t, func() bool {
+		return w.String() == want && h.writeTimeout.stuck.Load() == 0
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "fast", 0)))
+	assert.Equal(t, want+"level=INFO msg=fast\n", w.String())
+}
+
+func TestHandler_WithWriteTimeout_Concurrent(t *testing.T) {
+	// Each record waits for the other one to be handled, which only succeeds if the records
+	// are not serialized.
+	var entered sync.WaitGroup
+	entered.Add(2)
+	next := newTestHandler(true)
+	h := NewHandler(barrierHandler{testHandler: next, entered: &entered}).WithWriteTimeout(time.Second)
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errs <- h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0))
+		}()
+	}
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+	assert.Equal(t, 2, next.recordCount())
+}
+
+// barrierHandler waits in Handle until entered reaches zero.
+type barrierHandler struct {
+	*testHandler
+	entered *sync.WaitGroup
+}
+
+func (h barrierHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.entered.Done()
+	h.entered.Wait()
+	return h.testHandler.Handle(ctx, r)
+}
+
+func TestHandler_WithWriteTimeout_CallerContext(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var nextErr error
+	next := slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: dropTime})
+	h := NewHandler(ctxCheckingHandler{Handler: next, err: &nextErr}).WithWriteTimeout(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "client disconnected", 0))
+	require.NoError(t, err, "a canceled caller context does not drop the record")
+	require.NoError(t, nextErr, "the caller's cancellation is not passed to the next handler")
+	assert.Equal(t, "level=INFO msg=\"client disconnected\"\n", buf.String())
+
+	w := &blockingWriter{release: make(chan struct{})}
+	defer close(w.release)
+	h = NewHandler(slog.NewTextHandler(w, nil)).WithWriteTimeout(10 * time.Millisecond)
+	err = h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "timed out", "only the write timeout is reported")
+}
+
+// ctxCheckingHandler records the error of the context it receives before handling the record.
+type ctxCheckingHandler struct {
+	slog.Handler
+	err *error
+}
+
+func (h ctxCheckingHandler) Handle(ctx context.Context, r slog.Record) error {
+	*h.err = ctx.Err()
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestHandler_WithWriteTimeout_Disabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{name: "zero", timeout: 0},
+		{name: "negative", timeout: -time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(newTestHandler(true)).WithWriteTimeout(time.Second).WithWriteTimeout(tt.timeout)
+			assert.Nil(t, h.writeTimeout)
+		})
+	}
+}
+
+func TestHandler_WithWriteTimeout_Derived(t *testing.T) {
+	next := newTestHandler(true)
+	logger := New(NewHandler(next).WithWriteTimeout(time.Second)).With("k", "v").WithGroup("g")
+
+	logger.Info("msg", "a", 1)
+
+	require.Equal(t, 1, next.recordCount())
+	assert.True(t, recordHasAttr(next.getRecords()[0], "k", "v"))
+}